@@ -0,0 +1,218 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Tzinspect prints what package time builds for a time zone.
+// 打印 LoadLocation 为某个时区构建出来的内部数据
+//
+// Usage:
+//
+//	tzinspect [flags] zone...
+//
+// Each zone is a name understood by time.LoadLocation, such as
+// "Europe/Berlin" or "Local". With -file, the arguments are instead
+// paths to TZif files, which are parsed with time.LoadLocationFromTZData.
+//
+// The flags are:
+//
+//	-file
+//		treat arguments as TZif file paths
+//	-timeline from-to
+//		print the offset timeline for the years from through to
+//		instead of the zone tables
+//	-svg
+//		with -timeline, write the timeline as SVG
+//	-tzif
+//		print the header fields and records of the TZif data of each
+//		zone, read from the file the zone was loaded from, or with
+//		-file from the file given
+//	-list
+//		list the available zones, or those beginning with one of the
+//		arguments, instead of inspecting zones
+//	-convert
+//		print the time given by -at in each zone
+//	-abbrev
+//		list the zones using each argument as their abbreviation at
+//		the time given by -at, such as "IST" or "EST"
+//	-at time
+//		the time for -convert and -abbrev: "now" (the default), a
+//		Unix time such as "@1546300800", an RFC 3339 time, or a wall
+//		clock time such as "2019-03-31 02:30" in the zone of -from
+//	-from zone
+//		the zone of a wall clock time given by -at (default Local)
+//	-trace
+//		report every step of the zone-loading path on standard error
+//
+// Only one of -timeline, -tzif, -list, -convert and -abbrev may be given. A
+// wall clock time skipped or repeated by a transition is reported, and
+// resolved as time.ResolveEarlier does.
+//
+// Examples:
+//
+//	tzinspect Europe/Berlin
+//	tzinspect -timeline 2018-2020 Antarctica/Troll
+//	tzinspect -timeline 2018-2018 -svg America/New_York > ny.svg
+//	tzinspect -file /etc/localtime
+//	tzinspect -tzif Europe/London
+//	tzinspect -list Europe/
+//	tzinspect -convert -at "2019-11-03 01:30" -from America/New_York UTC Asia/Tokyo
+//	tzinspect -abbrev -at 2019-07-01T00:00:00Z IST
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"time/timedebug"
+)
+
+var (
+	fileFlag     = flag.Bool("file", false, "treat arguments as TZif file paths")
+	timelineFlag = flag.String("timeline", "", "print the offset timeline for the `years` from-to")
+	svgFlag      = flag.Bool("svg", false, "with -timeline, write SVG")
+	tzifFlag     = flag.Bool("tzif", false, "print the TZif header fields and records")
+	listFlag     = flag.Bool("list", false, "list the available zones with the prefixes given as arguments")
+	convertFlag  = flag.Bool("convert", false, "print the time given by -at in each zone")
+	abbrevFlag   = flag.Bool("abbrev", false, "list the zones using each argument as abbreviation at -at")
+	atFlag       = flag.String("at", "now", "the `time` for -convert and -abbrev")
+	fromFlag     = flag.String("from", "Local", "the `zone` of a wall clock time given by -at")
+	traceFlag    = flag.Bool("trace", false, "trace the zone-loading path on standard error")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: tzinspect [flags] zone...\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("tzinspect: ")
+	flag.Usage = usage
+	flag.Parse()
+
+	modes := 0
+	for _, on := range []bool{*timelineFlag != "", *tzifFlag, *listFlag, *convertFlag, *abbrevFlag} {
+		if on {
+			modes++
+		}
+	}
+	if modes > 1 {
+		log.Fatal("only one of -timeline, -tzif, -list, -convert and -abbrev may be given")
+	}
+	if flag.NArg() == 0 && !*listFlag {
+		usage()
+	}
+
+	var from, to int
+	if *timelineFlag != "" {
+		var err error
+		from, to, err = parseYears(*timelineFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else if *svgFlag {
+		log.Fatal("-svg requires -timeline")
+	}
+
+	if *traceFlag {
+		timedebug.SetLoadTracer(func(e timedebug.LoadEvent) {
+			fmt.Fprintf(os.Stderr, "trace: %-5s %s", e.Kind, e.Name)
+			if p := e.Path(); p != "" {
+				fmt.Fprintf(os.Stderr, " from %s", p)
+			} else if e.Source != "" {
+				fmt.Fprintf(os.Stderr, " from %s", e.Source)
+			}
+			if e.Size != 0 {
+				fmt.Fprintf(os.Stderr, " %d bytes", e.Size)
+			}
+			fmt.Fprintf(os.Stderr, " in %v", e.Elapsed)
+			if e.Err != nil {
+				fmt.Fprintf(os.Stderr, ": %v", e.Err)
+			}
+			fmt.Fprintln(os.Stderr)
+		})
+	}
+
+	switch {
+	case *listFlag:
+		os.Exit(list(flag.Args()))
+	case *abbrevFlag:
+		os.Exit(abbrev(flag.Args()))
+	case *convertFlag:
+		os.Exit(convert(flag.Args()))
+	}
+
+	exit := 0
+	for i, arg := range flag.Args() {
+		if *tzifFlag {
+			if i > 0 {
+				fmt.Println()
+			}
+			if err := printTZif(arg); err != nil {
+				log.Print(err)
+				exit = 1
+			}
+			continue
+		}
+		loc, err := load(arg)
+		if err != nil {
+			log.Print(err)
+			exit = 1
+			continue
+		}
+		if i > 0 && !*svgFlag {
+			fmt.Println()
+		}
+		switch {
+		case *timelineFlag == "":
+			err = timedebug.Dump(os.Stdout, loc)
+		case *svgFlag:
+			err = timedebug.WriteTimelineSVG(os.Stdout, loc, from, to)
+		default:
+			err = timedebug.WriteTimelineText(os.Stdout, loc, from, to)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	os.Exit(exit)
+}
+
+// load returns the Location named by arg.
+func load(arg string) (*time.Location, error) {
+	if !*fileFlag {
+		return time.LoadLocation(arg)
+	}
+	data, err := ioutil.ReadFile(arg)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocationFromTZData(filepath.Base(arg), data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", arg, err)
+	}
+	return loc, nil
+}
+
+// parseYears parses a year range of the form "2018-2020" or "2018".
+func parseYears(s string) (from, to int, err error) {
+	fs, ts := s, s
+	if i := strings.Index(s[1:], "-"); i >= 0 {
+		fs, ts = s[:i+1], s[i+2:]
+	}
+	from, err = strconv.Atoi(fs)
+	if err == nil {
+		to, err = strconv.Atoi(ts)
+	}
+	if err != nil || from > to {
+		return 0, 0, fmt.Errorf("invalid year range %q", s)
+	}
+	return from, to, nil
+}
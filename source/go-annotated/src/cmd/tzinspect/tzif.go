@@ -0,0 +1,123 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// printTZif prints the header fields and the records of the TZif data
+// of the zone arg, block by block, as time.ParseTZif decodes them.
+func printTZif(arg string) error {
+	var data []byte
+	var err error
+	if *fileFlag {
+		data, err = ioutil.ReadFile(arg)
+	} else {
+		data, err = readZoneFile(arg)
+	}
+	if err != nil {
+		return err
+	}
+	f, err := time.ParseTZif(data)
+	if err != nil {
+		return fmt.Errorf("%s: %v", arg, err)
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	fmt.Fprintf(w, "%s: TZif version %d, %d bytes\n", arg, f.Version, len(data))
+	printBlock(w, "v1", &f.V1)
+	if f.V2 != nil {
+		printBlock(w, "v2", f.V2)
+		fmt.Fprintf(w, "footer: %q\n", f.Footer)
+	}
+	return w.Flush()
+}
+
+// readZoneFile returns the contents of the file time.LoadLocation read
+// the zone name from, as reported by Location.Source: a file in a
+// directory, or an entry of a zip file such as zoneinfo.zip.
+func readZoneFile(name string) ([]byte, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	src := loc.Source()
+	switch src.Kind {
+	case time.SourceZoneinfo, time.SourceSystem, time.SourceGOROOT:
+	default:
+		return nil, fmt.Errorf("%s: loaded from %s, not from a TZif file", name, src)
+	}
+	switch {
+	case src.File == "":
+		return ioutil.ReadFile(src.Dir)
+	case strings.HasSuffix(src.Dir, ".zip"):
+		return readZipEntry(src.Dir, src.File)
+	case strings.HasSuffix(src.Dir, "tzdata"):
+		return nil, fmt.Errorf("%s: loaded from the tzdata file %s, which holds no TZif files", name, src.Dir)
+	}
+	return ioutil.ReadFile(filepath.Join(src.Dir, src.File))
+}
+
+// readZipEntry returns the contents of the file name in the zip file
+// zipfile.
+func readZipEntry(zipfile, name string) ([]byte, error) {
+	z, err := zip.OpenReader(zipfile)
+	if err != nil {
+		return nil, err
+	}
+	defer z.Close()
+	for _, f := range z.File {
+		if f.Name != name {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	}
+	return nil, fmt.Errorf("cannot find %s in zip file %s", name, zipfile)
+}
+
+func printBlock(w *bufio.Writer, name string, b *time.TZifBlock) {
+	h := &b.Header
+	version := "1"
+	if h.Version != 0 {
+		version = string(h.Version)
+	}
+	fmt.Fprintf(w, "%s block: version %s isutcnt=%d isstdcnt=%d leapcnt=%d timecnt=%d typecnt=%d charcnt=%d\n",
+		name, version, h.IsUTCount, h.IsStdCount, h.LeapCount, h.TimeCount, h.TypeCount, h.CharCount)
+	for i, t := range b.Types {
+		fmt.Fprintf(w, "\ttype %d: %-6s utoff=%d isdst=%v", i, t.Abbrev, t.Offset, t.IsDST)
+		if i < len(b.IsStd) {
+			fmt.Fprintf(w, " isstd=%v", b.IsStd[i])
+		}
+		if i < len(b.IsUT) {
+			fmt.Fprintf(w, " isut=%v", b.IsUT[i])
+		}
+		fmt.Fprintln(w)
+	}
+	if len(b.Abbrevs) > 0 {
+		fmt.Fprintf(w, "\tabbrevs: %q\n", bytes.Split(bytes.TrimSuffix(b.Abbrevs, []byte{0}), []byte{0}))
+	}
+	for i, sec := range b.Transitions {
+		fmt.Fprintf(w, "\ttransition %d: %d %s -> type %d\n",
+			i, sec, time.Unix(sec, 0).UTC().Format(time.RFC3339), b.TransitionTypes[i])
+	}
+	for i, l := range b.Leaps {
+		fmt.Fprintf(w, "\tleap %d: %d %s correction=%d\n",
+			i, l.Occurrence, time.Unix(l.Occurrence, 0).UTC().Format(time.RFC3339), l.Correction)
+	}
+}
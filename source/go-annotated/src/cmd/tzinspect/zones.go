@@ -0,0 +1,148 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// list prints the available zones that begin with one of prefixes, or
+// all of them if there are no prefixes.
+func list(prefixes []string) int {
+	names, err := time.AvailableZones()
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+	for _, name := range names {
+		if len(prefixes) == 0 {
+			fmt.Println(name)
+			continue
+		}
+		for _, p := range prefixes {
+			if strings.HasPrefix(name, p) {
+				fmt.Println(name)
+				break
+			}
+		}
+	}
+	return 0
+}
+
+// abbrev prints, for each abbreviation, the zones that use it at the
+// time of -at:
+//
+//	IST: Asia/Calcutta Asia/Kolkata Eire Europe/Dublin
+func abbrev(abbrevs []string) int {
+	t, err := parseTime(*atFlag)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+	exit := 0
+	for _, a := range abbrevs {
+		locs, err := time.LocationsByAbbrev(a, t)
+		if err != nil {
+			log.Print(err)
+			return 1
+		}
+		if len(locs) == 0 {
+			log.Printf("%s: no zone uses it at %s", a, t.UTC().Format(time.RFC3339))
+			exit = 1
+			continue
+		}
+		names := make([]string, len(locs))
+		for i, l := range locs {
+			names[i] = l.String()
+		}
+		fmt.Printf("%s: %s\n", a, strings.Join(names, " "))
+	}
+	return exit
+}
+
+// convert prints the time of -at in each zone, one per line:
+//
+//	UTC         2019-11-03 05:30:00 UTC +00:00
+//	Asia/Tokyo  2019-11-03 14:30:00 JST +09:00
+func convert(zones []string) int {
+	t, err := parseTime(*atFlag)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+	width := 0
+	for _, z := range zones {
+		if len(z) > width {
+			width = len(z)
+		}
+	}
+	exit := 0
+	for _, z := range zones {
+		loc, err := load(z)
+		if err != nil {
+			log.Print(err)
+			exit = 1
+			continue
+		}
+		lt := t.In(loc)
+		s := lt.Format("2006-01-02 15:04:05.999999999 MST -07:00")
+		if lt.IsDST() {
+			s += " DST"
+		}
+		fmt.Printf("%-*s  %s\n", width, z, s)
+	}
+	return exit
+}
+
+// wallLayouts are the layouts of the wall clock times -at accepts,
+// without an offset. The fraction of a second is optional.
+var wallLayouts = []string{
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// parseTime returns the time s stands for, as described for -at.
+func parseTime(s string) (time.Time, error) {
+	if s == "now" {
+		return time.Now(), nil
+	}
+	if len(s) > 1 && s[0] == '@' {
+		sec, err := strconv.ParseInt(s[1:], 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid Unix time %q", s)
+		}
+		return time.Unix(sec, 0), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	loc, err := time.LoadLocation(*fromFlag)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, layout := range wallLayouts {
+		w, err := time.ParseInLocation(layout, s, time.UTC)
+		if err != nil {
+			continue
+		}
+		t, kind, err := time.ResolveLocal(w.Year(), w.Month(), w.Day(),
+			w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), loc, time.ResolveEarlier)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if kind != time.LocalTimeUnique {
+			log.Printf("%s is %s in %s; using %s", s, kind, *fromFlag, t.Format(time.RFC3339Nano))
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse time %q", s)
+}
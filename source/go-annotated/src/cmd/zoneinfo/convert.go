@@ -0,0 +1,104 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+)
+
+var (
+	convertFromFlag   string
+	convertLayoutFlag string
+)
+
+func convertFlags(fs *flag.FlagSet) {
+	fs.StringVar(&convertFromFlag, "from", "Local", "read wall clock times in `zone`")
+	fs.StringVar(&convertLayoutFlag, "layout", "2006-01-02 15:04:05 MST -07:00", "print times with `layout`, as time.Format")
+}
+
+// wallLayouts are the layouts of the wall clock times convert reads,
+// without an offset. The fraction of a second is optional.
+var wallLayouts = []string{
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// runConvert prints the time of its first argument in each zone of
+// the others, one per line:
+//
+//	UTC         2019-11-03 05:30:00 UTC +00:00
+//	Asia/Tokyo  2019-11-03 14:30:00 JST +09:00
+func runConvert(fs *flag.FlagSet) int {
+	if fs.NArg() < 2 {
+		fs.Usage()
+	}
+	t, err := parseTime(fs.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	zones := fs.Args()[1:]
+	width := 0
+	for _, z := range zones {
+		if len(z) > width {
+			width = len(z)
+		}
+	}
+	exit := 0
+	for _, z := range zones {
+		loc, err := time.LoadLocation(z)
+		if err != nil {
+			log.Print(err)
+			exit = 1
+			continue
+		}
+		fmt.Printf("%-*s  %s\n", width, z, t.In(loc).Format(convertLayoutFlag))
+	}
+	return exit
+}
+
+// parseTime returns the time s stands for, as described in the
+// documentation of the command.
+func parseTime(s string) (time.Time, error) {
+	if s == "now" {
+		return time.Now(), nil
+	}
+	if len(s) > 1 && s[0] == '@' {
+		sec, err := strconv.ParseInt(s[1:], 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid Unix time %q", s)
+		}
+		return time.Unix(sec, 0), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	loc, err := time.LoadLocation(convertFromFlag)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, layout := range wallLayouts {
+		w, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+		t, kind, err := time.ResolveLocal(w.Year(), w.Month(), w.Day(),
+			w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), loc, time.ResolveEarlier)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if kind != time.LocalTimeUnique {
+			log.Printf("%s is %s in %s; using %s", s, kind, convertFromFlag, t.Format(time.RFC3339Nano))
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse time %q", s)
+}
@@ -0,0 +1,211 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+	"time/timedebug"
+)
+
+var diffTablesFlag bool
+
+func diffFlags(fs *flag.FlagSet) {
+	addYearsFlag(fs)
+	fs.BoolVar(&diffTablesFlag, "tables", false, "also report zones whose tables differ but which agree in the years")
+}
+
+// runDiff compares the zones of two sources and prints one line for
+// each zone that is in only one of them or whose rules differ. For the
+// latter, the line gives the first time in the years at which the two
+// disagree, with the zone each has then:
+//
+//	America/Sao_Paulo: 2019-11-03T03:00:00Z: -02 -02:00 DST in old, -03 -03:00 in new
+//
+// Zones that agree throughout the years are not reported, even if
+// their tables differ, as between the "fat" files of many systems,
+// which list transitions up to 2037, and the "slim" ones of Go's
+// zoneinfo.zip, which leave them to the POSIX TZ rule; with -tables,
+// they are reported too. Differences in names and in the indicators
+// of the TZif files are ignored, as by Location.Equal.
+func runDiff(fs *flag.FlagSet) int {
+	if fs.NArg() != 2 {
+		fs.Usage()
+	}
+	from, to := years()
+	path1, path2 := fs.Arg(0), fs.Arg(1)
+	src1, err := readSource(path1)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src2, err := readSource(path2)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var names []string
+	for name := range src1 {
+		names = append(names, name)
+	}
+	for name := range src2 {
+		if _, ok := src1[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	exit := 0
+	for _, name := range names {
+		data1, ok1 := src1[name]
+		data2, ok2 := src2[name]
+		switch {
+		case !ok2:
+			fmt.Fprintf(w, "%s: only in %s\n", name, path1)
+			exit = 1
+			continue
+		case !ok1:
+			fmt.Fprintf(w, "%s: only in %s\n", name, path2)
+			exit = 1
+			continue
+		}
+		loc1, err1 := time.LoadLocationFromTZData(name, data1)
+		loc2, err2 := time.LoadLocationFromTZData(name, data2)
+		if err1 != nil || err2 != nil {
+			if err1 != nil {
+				fmt.Fprintf(w, "%s: %s: %v\n", name, path1, err1)
+			}
+			if err2 != nil {
+				fmt.Fprintf(w, "%s: %s: %v\n", name, path2, err2)
+			}
+			exit = 1
+			continue
+		}
+		if loc1.Equal(loc2) {
+			continue
+		}
+		at, ok := firstDifference(loc1, loc2, from, to)
+		if !ok {
+			if diffTablesFlag {
+				fmt.Fprintf(w, "%s: tables differ, same in %d-%d\n", name, from, to)
+				exit = 1
+			}
+			continue
+		}
+		exit = 1
+		fmt.Fprintf(w, "%s: %s: %s in %s, %s in %s\n", name, at.Format(time.RFC3339),
+			describe(at.In(loc1)), path1, describe(at.In(loc2)), path2)
+	}
+	return exit
+}
+
+// firstDifference returns the first time in the years from through to
+// at which loc1 and loc2 are in different zones, and false if there is
+// none.
+func firstDifference(loc1, loc2 *time.Location, from, to int) (time.Time, bool) {
+	p1, err := timedebug.Timeline(loc1, from, to)
+	if err != nil {
+		log.Fatal(err)
+	}
+	p2, err := timedebug.Timeline(loc2, from, to)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Both timelines cover the same range, and the periods before i
+	// are the same, so periods i start at the same time.
+	for i := 0; i < len(p1) && i < len(p2); i++ {
+		if p1[i].Zone != p2[i].Zone {
+			return p1[i].Start, true
+		}
+		if e1, e2 := p1[i].End, p2[i].End; !e1.Equal(e2) {
+			if e2.Before(e1) {
+				e1 = e2
+			}
+			return e1, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// describe returns the zone of t, as "CEST +02:00 DST".
+func describe(t time.Time) string {
+	s := t.Format("MST -07:00")
+	if t.IsDST() {
+		s += " DST"
+	}
+	return s
+}
+
+// readSource returns the contents of the TZif files of a copy of the
+// time zone database, a directory or a zip file, by zone name.
+func readSource(path string) (map[string][]byte, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	src := make(map[string][]byte)
+	if !fi.IsDir() {
+		z, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		defer z.Close()
+		for _, f := range z.File {
+			if f.FileInfo().IsDir() {
+				continue
+			}
+			r, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %v", path, f.Name, err)
+			}
+			data, err := ioutil.ReadAll(r)
+			r.Close()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %v", path, f.Name, err)
+			}
+			if isTZif(data) {
+				src[f.Name] = data
+			}
+		}
+		return src, nil
+	}
+	err = filepath.Walk(path, func(file string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			if fi.Mode()&os.ModeSymlink != 0 {
+				// A link to a directory, or a dangling link.
+				return nil
+			}
+			return err
+		}
+		if isTZif(data) {
+			name, err := filepath.Rel(path, file)
+			if err != nil {
+				return err
+			}
+			src[filepath.ToSlash(name)] = data
+		}
+		return nil
+	})
+	return src, err
+}
+
+// isTZif reports whether data looks like a TZif file, as opposed to
+// the other files of the database, such as zone.tab.
+func isTZif(data []byte) bool {
+	return len(data) >= 4 && string(data[:4]) == "TZif"
+}
@@ -0,0 +1,70 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+	"time/timedebug"
+)
+
+var dumpFileFlag bool
+
+func dumpFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&dumpFileFlag, "file", false, "treat arguments as TZif file paths")
+	addYearsFlag(fs)
+}
+
+// zdumpLayout is the layout of the times zdump prints.
+const zdumpLayout = "Mon Jan _2 15:04:05 2006"
+
+// runDump prints, for each transition of each zone in the years, the
+// last second before it and the first second after it, in UT and in
+// the zone, as zdump -v does:
+//
+//	Europe/Berlin  Sun Mar 31 00:59:59 2019 UT = Sun Mar 31 01:59:59 2019 CET isdst=0 gmtoff=3600
+//	Europe/Berlin  Sun Mar 31 01:00:00 2019 UT = Sun Mar 31 03:00:00 2019 CEST isdst=1 gmtoff=7200
+//
+// Only changes of the offset, abbreviation or daylight saving status
+// count as transitions.
+func runDump(fs *flag.FlagSet) int {
+	if fs.NArg() == 0 {
+		fs.Usage()
+	}
+	from, to := years()
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	exit := 0
+	for _, arg := range fs.Args() {
+		loc, err := load(arg, dumpFileFlag)
+		if err != nil {
+			w.Flush()
+			log.Print(err)
+			exit = 1
+			continue
+		}
+		periods, err := timedebug.Timeline(loc, from, to)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, p := range periods[1:] {
+			for _, t := range []time.Time{p.Start.Add(-time.Second), p.Start} {
+				lt := t.In(loc)
+				name, offset := lt.Zone()
+				isDST := 0
+				if lt.IsDST() {
+					isDST = 1
+				}
+				fmt.Fprintf(w, "%s  %s UT = %s %s isdst=%d gmtoff=%d\n",
+					arg, t.Format(zdumpLayout), lt.Format(zdumpLayout), name, isDST, offset)
+			}
+		}
+	}
+	return exit
+}
@@ -0,0 +1,157 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Zoneinfo inspects, compares, checks and uses time zone data, with
+// the TZif parser and the Location machinery of package time.
+// 时区数据的命令行工具：列出转换、比较两份 tzdata、校验文件、换算时间
+//
+// Usage:
+//
+//	zoneinfo command [flags] [arguments]
+//
+// The commands are:
+//
+//	dump [-file] [-years from-to] zone...
+//		print the transitions of each zone, as zdump -v does
+//	diff [-tables] [-years from-to] source1 source2
+//		compare two copies of the time zone database
+//	validate file...
+//		check TZif files against RFC 8536
+//	convert [-from zone] [-layout layout] time zone...
+//		print a time in each zone
+//
+// A zone is a name understood by time.LoadLocation, such as
+// "Europe/Berlin" or "Local"; with -file, it is instead the path of a
+// TZif file. A source of diff is a directory such as
+// /usr/share/zoneinfo, or a zip file such as $GOROOT/lib/time/zoneinfo.zip.
+// A file to validate may also be a directory, whose TZif files are all
+// checked. The exit status is 1 if a zone cannot be loaded, if the
+// sources differ, or if a file is invalid.
+//
+// The time to convert is "now", a Unix time such as "@1546300800",
+// an RFC 3339 time such as "2019-03-31T03:00:00+02:00", or a wall
+// clock time such as "2019-03-31 02:30" or "2019-03-31T02:30:00.5" in
+// the zone given by -from, Local by default. A wall clock time skipped
+// or repeated by a transition is reported, and resolved as
+// time.ResolveEarlier does.
+//
+// Examples:
+//
+//	zoneinfo dump -years 2019-2020 Europe/Berlin
+//	zoneinfo dump -file /etc/localtime
+//	zoneinfo diff /usr/share/zoneinfo $(go env GOROOT)/lib/time/zoneinfo.zip
+//	zoneinfo validate /usr/share/zoneinfo
+//	zoneinfo convert -from America/New_York "2019-11-03 01:30" UTC Asia/Tokyo
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A command is one of the commands of zoneinfo. run returns the exit
+// status.
+type command struct {
+	name  string
+	args  string
+	run   func(fs *flag.FlagSet) int
+	flags func(fs *flag.FlagSet)
+}
+
+var commands = []*command{
+	{"dump", "[-file] [-years from-to] zone...", runDump, dumpFlags},
+	{"diff", "[-tables] [-years from-to] source1 source2", runDiff, diffFlags},
+	{"validate", "file...", runValidate, nil},
+	{"convert", "[-from zone] [-layout layout] time zone...", runConvert, convertFlags},
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: zoneinfo command [flags] [arguments]\n\ncommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "\tzoneinfo %s %s\n", c.name, c.args)
+	}
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("zoneinfo: ")
+	if len(os.Args) < 2 {
+		usage()
+	}
+	for _, c := range commands {
+		if c.name != os.Args[1] {
+			continue
+		}
+		fs := flag.NewFlagSet(c.name, flag.ExitOnError)
+		fs.Usage = func() {
+			fmt.Fprintf(os.Stderr, "usage: zoneinfo %s %s\n", c.name, c.args)
+			fs.PrintDefaults()
+			os.Exit(2)
+		}
+		if c.flags != nil {
+			c.flags(fs)
+		}
+		fs.Parse(os.Args[2:])
+		os.Exit(c.run(fs))
+	}
+	log.Printf("unknown command %q", os.Args[1])
+	usage()
+}
+
+// yearsFlag is the -years flag of dump and diff.
+var yearsFlag string
+
+func addYearsFlag(fs *flag.FlagSet) {
+	fs.StringVar(&yearsFlag, "years", "1970-2037", "consider the `years` from-to")
+}
+
+// years returns the range of the -years flag.
+func years() (from, to int) {
+	from, to, err := parseYears(yearsFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return from, to
+}
+
+// parseYears parses a year range of the form "2018-2020" or "2018".
+func parseYears(s string) (from, to int, err error) {
+	fs, ts := s, s
+	if i := strings.Index(s[1:], "-"); i >= 0 {
+		fs, ts = s[:i+1], s[i+2:]
+	}
+	from, err = strconv.Atoi(fs)
+	if err == nil {
+		to, err = strconv.Atoi(ts)
+	}
+	if err != nil || from > to {
+		return 0, 0, fmt.Errorf("invalid year range %q", s)
+	}
+	return from, to, nil
+}
+
+// load returns the Location named by arg, or with file the Location
+// in the TZif file at the path arg.
+func load(arg string, file bool) (*time.Location, error) {
+	if !file {
+		return time.LoadLocation(arg)
+	}
+	data, err := ioutil.ReadFile(arg)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocationFromTZData(filepath.Base(arg), data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", arg, err)
+	}
+	return loc, nil
+}
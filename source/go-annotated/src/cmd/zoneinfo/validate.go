@@ -0,0 +1,92 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// runValidate checks each file with time.ValidateTZif and prints one
+// line for each problem found, and a line for each valid file:
+//
+//	bad/Zone: v2 transition 3: not after the previous transition
+//	Europe/Berlin: ok
+//
+// The files of a directory are checked in turn, skipping those that
+// are not TZif files, such as zone.tab.
+func runValidate(fs *flag.FlagSet) int {
+	if fs.NArg() == 0 {
+		fs.Usage()
+	}
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	exit := 0
+	for _, arg := range fs.Args() {
+		fi, err := os.Stat(arg)
+		if err != nil {
+			w.Flush()
+			log.Print(err)
+			exit = 1
+			continue
+		}
+		if !fi.IsDir() {
+			data, err := ioutil.ReadFile(arg)
+			if err != nil {
+				w.Flush()
+				log.Print(err)
+				exit = 1
+				continue
+			}
+			if !validate(w, arg, data) {
+				exit = 1
+			}
+			continue
+		}
+		err = filepath.Walk(arg, func(file string, fi os.FileInfo, err error) error {
+			if err != nil || !fi.Mode().IsRegular() {
+				return err
+			}
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			if isTZif(data) && !validate(w, file, data) {
+				exit = 1
+			}
+			return nil
+		})
+		if err != nil {
+			w.Flush()
+			log.Print(err)
+			exit = 1
+		}
+	}
+	return exit
+}
+
+// validate checks the TZif file data, named file, and reports whether
+// it is valid.
+func validate(w *bufio.Writer, file string, data []byte) bool {
+	err := time.ValidateTZif(data)
+	switch err := err.(type) {
+	case nil:
+		fmt.Fprintf(w, "%s: ok\n", file)
+		return true
+	case *time.TZifError:
+		for _, p := range err.Problems {
+			fmt.Fprintf(w, "%s: %s\n", file, p)
+		}
+	default:
+		fmt.Fprintf(w, "%s: %v\n", file, err)
+	}
+	return false
+}
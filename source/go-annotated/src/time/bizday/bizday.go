@@ -0,0 +1,207 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bizday does business-day arithmetic: which days are
+// working days under a set of weekend rules and holidays, and what
+// day is n working days from another.
+// 工作日计算：周末规则 + 节假日
+//
+//	cal := bizday.New(ny)
+//	cal.AddAnnualHoliday(time.December, 25, "Christmas Day")
+//	cal.AddHoliday(civil.Date{Year: 2024, Month: time.November, Day: 28}, "Thanksgiving")
+//	due := cal.AddBusinessDays(time.Now(), 5)
+//
+// Days are those of the Calendar's Location: an instant that is
+// Friday evening in New York is Saturday in Tokyo.
+package bizday
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"time/civil"
+)
+
+// A Calendar holds the weekend rules and holidays of a Location.
+// Its methods that only read it may be called concurrently, once
+// the holidays have been added.
+type Calendar struct {
+	loc     *time.Location
+	weekend [7]bool
+	dates   map[civil.Date]string // one-off holidays
+	annual  map[monthDay]string   // holidays on the same date every year
+}
+
+type monthDay struct {
+	month time.Month
+	day   int
+}
+
+// maxSearch bounds the days searched for a business day, so that a
+// Calendar with no business days does not loop forever.
+const maxSearch = 3660
+
+// New returns a Calendar for loc with the given weekend days, or
+// Saturday and Sunday if none are given.
+func New(loc *time.Location, weekend ...time.Weekday) *Calendar {
+	c := &Calendar{
+		loc:    loc,
+		dates:  make(map[civil.Date]string),
+		annual: make(map[monthDay]string),
+	}
+	if len(weekend) == 0 {
+		weekend = []time.Weekday{time.Saturday, time.Sunday}
+	}
+	for _, wd := range weekend {
+		c.weekend[wd%7] = true
+	}
+	return c
+}
+
+// Location returns the Location whose days c counts.
+func (c *Calendar) Location() *time.Location {
+	return c.loc
+}
+
+// AddHoliday makes d a holiday called name.
+func (c *Calendar) AddHoliday(d civil.Date, name string) {
+	c.dates[d] = name
+}
+
+// AddAnnualHoliday makes the given day of the given month a holiday
+// called name in every year, such as December 25.
+func (c *Calendar) AddAnnualHoliday(month time.Month, day int, name string) {
+	c.annual[monthDay{month, day}] = name
+}
+
+// LoadHolidays adds the holidays listed in r, one per line, each a
+// date followed by the holiday's name:
+//
+//	# US federal holidays, 2024
+//	2024-01-01 New Year's Day
+//	2024-11-28 Thanksgiving Day
+//	--12-25    Christmas Day
+//
+// A date written "--MM-DD", as in ISO 8601, is a holiday every year.
+// Blank lines and lines starting with '#' are ignored.
+func (c *Calendar) LoadHolidays(r io.Reader) error {
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		date, name := line, ""
+		if i := strings.IndexAny(line, " \t"); i >= 0 {
+			date, name = line[:i], strings.TrimSpace(line[i:])
+		}
+		if strings.HasPrefix(date, "--") {
+			d, err := civil.ParseDate("2000" + date[1:]) // a leap year, to allow --02-29
+			if err != nil {
+				return lineError(n, err)
+			}
+			c.AddAnnualHoliday(d.Month, d.Day, name)
+			continue
+		}
+		d, err := civil.ParseDate(date)
+		if err != nil {
+			return lineError(n, err)
+		}
+		c.AddHoliday(d, name)
+	}
+	return s.Err()
+}
+
+func lineError(n int, err error) error {
+	return errors.New("bizday: line " + strconv.Itoa(n) + ": " + err.Error())
+}
+
+// Holiday reports whether d is a holiday, and its name.
+func (c *Calendar) Holiday(d civil.Date) (name string, ok bool) {
+	if name, ok = c.dates[d]; ok {
+		return name, true
+	}
+	name, ok = c.annual[monthDay{d.Month, d.Day}]
+	return name, ok
+}
+
+// IsWeekend reports whether d falls on a weekend day of c.
+func (c *Calendar) IsWeekend(d civil.Date) bool {
+	return c.weekend[d.Weekday()]
+}
+
+// IsBusinessDate reports whether d is neither a weekend day nor a holiday.
+func (c *Calendar) IsBusinessDate(d civil.Date) bool {
+	if c.IsWeekend(d) {
+		return false
+	}
+	_, ok := c.Holiday(d)
+	return !ok
+}
+
+// IsBusinessDay reports whether t falls on a business day in c's Location.
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	return c.IsBusinessDate(civil.DateOf(t.In(c.loc)))
+}
+
+// NextBusinessDay returns the first instant of the first business
+// day after the day of t in c's Location.
+func (c *Calendar) NextBusinessDay(t time.Time) time.Time {
+	d := c.step(civil.DateOf(t.In(c.loc)), 1)
+	return d.In(c.loc)
+}
+
+// AddBusinessDays returns the time n business days after t, at the
+// same wall clock time in c's Location; n may be negative. The day
+// of t itself is not counted, so adding 1 on a Friday gives Monday,
+// and adding 0 returns t even on a holiday.
+func (c *Calendar) AddBusinessDays(t time.Time, n int) time.Time {
+	if n == 0 {
+		return t
+	}
+	t = t.In(c.loc)
+	d := civil.DateOf(t)
+	dir := 1
+	if n < 0 {
+		dir, n = -1, -n
+	}
+	for ; n > 0; n-- {
+		d = c.step(d, dir)
+	}
+	return d.At(civil.TimeOfDayOf(t), c.loc)
+}
+
+// BusinessDaysBetween returns the number of business days after the
+// day of t and up to and including the day of u, negative if u is
+// before t. It is the inverse of AddBusinessDays.
+func (c *Calendar) BusinessDaysBetween(t, u time.Time) int {
+	from := civil.DateOf(t.In(c.loc))
+	to := civil.DateOf(u.In(c.loc))
+	sign := 1
+	if to.Before(from) {
+		from, to, sign = to, from, -1
+	}
+	n := 0
+	for d := from.AddDays(1); !d.After(to); d = d.AddDays(1) {
+		if c.IsBusinessDate(d) {
+			n++
+		}
+	}
+	return sign * n
+}
+
+// step returns the nearest business day after d, or before it if
+// dir is -1.
+func (c *Calendar) step(d civil.Date, dir int) civil.Date {
+	for i := 0; i < maxSearch; i++ {
+		d = d.AddDays(dir)
+		if c.IsBusinessDate(d) {
+			return d
+		}
+	}
+	panic("bizday: calendar has no business days")
+}
@@ -0,0 +1,249 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package calendar
+
+import "math"
+
+// The positions of the Sun and the Moon that the Chinese calendar
+// follows, after Meeus, Astronomical Algorithms, and Reingold and
+// Dershowitz, Calendrical Calculations. Moments are fixed day
+// numbers with a fraction, in universal time.
+
+const (
+	meanSynodicMonth = 29.530588861
+	meanTropicalYear = 365.242189
+	j2000            = 730120.5 // 2000-01-01 12:00 as a moment
+)
+
+// deltaT returns the difference, in days, between dynamical time,
+// which the positions are computed in, and universal time at the
+// moment t, by the polynomials of Espenak and Meeus.
+func deltaT(t float64) float64 {
+	y := 2000 + (t-j2000)/365.2425
+	var s float64 // seconds
+	switch {
+	case y < 1860 || y >= 2150:
+		u := (y - 1820) / 100
+		s = -20 + 32*u*u
+	case y < 1900:
+		x := y - 1860
+		s = 7.62 + 0.5737*x - 0.251754*x*x + 0.01680668*x*x*x -
+			0.0004473624*x*x*x*x + x*x*x*x*x/233174
+	case y < 1920:
+		x := y - 1900
+		s = -2.79 + 1.494119*x - 0.0598939*x*x + 0.0061966*x*x*x - 0.000197*x*x*x*x
+	case y < 1941:
+		x := y - 1920
+		s = 21.20 + 0.84493*x - 0.076100*x*x + 0.0020936*x*x*x
+	case y < 1961:
+		x := y - 1950
+		s = 29.07 + 0.407*x - x*x/233 + x*x*x/2547
+	case y < 1986:
+		x := y - 1975
+		s = 45.45 + 1.067*x - x*x/260 - x*x*x/718
+	case y < 2005:
+		x := y - 2000
+		s = 63.86 + 0.3345*x - 0.060374*x*x + 0.0017275*x*x*x +
+			0.000651814*x*x*x*x + 0.00002373599*x*x*x*x*x
+	case y < 2050:
+		x := y - 2000
+		s = 62.92 + 0.32217*x + 0.005589*x*x
+	default:
+		u := (y - 1820) / 100
+		s = -20 + 32*u*u - 0.5628*(2150-y)
+	}
+	return s / (24 * 60 * 60)
+}
+
+// solarTerms are the periodic terms of the longitude of the Sun:
+// x sin(y + z c), with c in Julian centuries from J2000.
+var solarTerms = [...]struct{ x, y, z float64 }{
+	{403406, 270.54861, 0.9287892},
+	{195207, 340.19128, 35999.1376958},
+	{119433, 63.91854, 35999.4089666},
+	{112392, 331.26220, 35998.7287385},
+	{3891, 317.843, 71998.20261},
+	{2819, 86.631, 71998.4403},
+	{1721, 240.052, 36000.35726},
+	{660, 310.26, 71997.4812},
+	{350, 247.23, 32964.4678},
+	{334, 260.87, -19.4410},
+	{314, 297.82, 445267.1117},
+	{268, 343.14, 45036.8840},
+	{242, 166.79, 3.1008},
+	{234, 81.53, 22518.4434},
+	{158, 3.50, -19.9739},
+	{132, 132.75, 65928.9345},
+	{129, 182.95, 9038.0293},
+	{114, 162.03, 3034.7684},
+	{99, 29.8, 33718.148},
+	{93, 266.4, 3034.448},
+	{86, 249.2, -2280.773},
+	{78, 157.6, 29929.992},
+	{72, 257.8, 31556.493},
+	{68, 185.1, 149.588},
+	{64, 69.9, 9037.750},
+	{46, 8.0, 107997.405},
+	{38, 197.1, -4444.176},
+	{37, 250.4, 151.771},
+	{32, 65.3, 67555.316},
+	{29, 162.7, 31556.080},
+	{28, 341.5, -4561.540},
+	{27, 291.6, 107996.706},
+	{27, 98.5, 1221.655},
+	{25, 146.7, 62894.167},
+	{24, 110.0, 31437.369},
+	{21, 5.2, 14578.298},
+	{21, 342.6, -31931.757},
+	{20, 230.9, 34777.243},
+	{18, 256.1, 1221.999},
+	{17, 45.3, 62894.511},
+	{14, 242.9, -4442.039},
+	{13, 115.2, 107997.909},
+	{13, 151.8, 119.066},
+	{13, 285.3, 16859.071},
+	{12, 53.3, -4.578},
+	{10, 126.6, 26895.292},
+	{10, 205.7, -39.127},
+	{10, 85.9, 12297.536},
+	{10, 146.1, 90073.778},
+}
+
+// solarLongitude returns the apparent longitude of the Sun at the
+// moment t, in degrees in [0, 360).
+func solarLongitude(t float64) float64 {
+	c := (t + deltaT(t) - j2000) / 36525
+	var sum float64
+	for _, s := range solarTerms {
+		sum += s.x * sinDeg(s.y+s.z*c)
+	}
+	lambda := 282.7771834 + 36000.76953744*c + 0.000005729577951308232*sum
+	aberration := 0.0000974*cosDeg(177.63+35999.01848*c) - 0.005575
+	a := 124.90 - 1934.134*c + 0.002063*c*c
+	b := 201.11 + 72001.5377*c + 0.00057*c*c
+	nutation := -0.004778*sinDeg(a) - 0.0003667*sinDeg(b)
+	return modDeg(lambda + aberration + nutation)
+}
+
+// estimatePriorSolarLongitude returns a moment, before t and close
+// to it, when the Sun was last at longitude lambda.
+func estimatePriorSolarLongitude(lambda, t float64) float64 {
+	const rate = meanTropicalYear / 360 // days per degree
+	tau := t - rate*modDeg(solarLongitude(t)-lambda)
+	delta := modDeg(solarLongitude(tau)-lambda+180) - 180
+	return math.Min(t, tau-rate*delta)
+}
+
+// newMoonTerms are the periodic terms of the time of the new moon:
+// coefficient, power of the eccentricity factor, and multiples of the
+// mean anomalies of the Sun and the Moon and of the argument of
+// latitude of the Moon.
+var newMoonTerms = [...]struct {
+	v          float64
+	e, m, n, f int
+}{
+	{-0.40720, 0, 0, 1, 0},
+	{0.17241, 1, 1, 0, 0},
+	{0.01608, 0, 0, 2, 0},
+	{0.01039, 0, 0, 0, 2},
+	{0.00739, 1, -1, 1, 0},
+	{-0.00514, 1, 1, 1, 0},
+	{0.00208, 2, 2, 0, 0},
+	{-0.00111, 0, 0, 1, -2},
+	{-0.00057, 0, 0, 1, 2},
+	{0.00056, 1, 1, 2, 0},
+	{-0.00042, 0, 0, 3, 0},
+	{0.00042, 1, 1, 0, 2},
+	{0.00038, 1, 1, 0, -2},
+	{-0.00024, 1, -1, 2, 0},
+	{-0.00007, 0, 2, 1, 0},
+	{0.00004, 0, 0, 2, -2},
+	{0.00004, 0, 3, 0, 0},
+	{0.00003, 0, 1, 1, -2},
+	{0.00003, 0, 0, 2, 2},
+	{-0.00003, 0, 1, 1, 2},
+	{0.00003, 0, -1, 1, 2},
+	{-0.00002, 0, -1, 1, -2},
+	{-0.00002, 0, 1, 3, 0},
+	{0.00002, 0, 0, 4, 0},
+}
+
+// planetaryTerms are the additional corrections to the time of the
+// new moon: coefficient, and angle at k = 0 and its rate per lunation.
+var planetaryTerms = [...]struct{ v, a, k float64 }{
+	{0.000325, 299.77, 0.107408},
+	{0.000165, 251.88, 0.016321},
+	{0.000164, 251.83, 26.651886},
+	{0.000126, 349.42, 36.412478},
+	{0.000110, 84.66, 18.206239},
+	{0.000062, 141.74, 53.303771},
+	{0.000060, 207.14, 2.453732},
+	{0.000056, 154.84, 7.306860},
+	{0.000047, 34.52, 27.261239},
+	{0.000042, 207.19, 0.121824},
+	{0.000040, 291.34, 1.844379},
+	{0.000037, 161.72, 24.198154},
+	{0.000035, 239.56, 25.513099},
+	{0.000023, 331.55, 3.592518},
+}
+
+// nthNewMoon returns the moment of the k'th new moon after that of
+// 6 January 2000.
+func nthNewMoon(k int) float64 {
+	kf := float64(k)
+	c := kf / 1236.85
+	// The Julian ephemeris day, less the JD of fixed day 0.
+	t := 2451550.09766 - 1721424.5 + meanSynodicMonth*kf +
+		0.00015437*c*c - 0.000000150*c*c*c + 0.00000000073*c*c*c*c
+	e := 1 - 0.002516*c - 0.0000074*c*c
+	m := 2.5534 + 29.10535670*kf - 0.0000014*c*c - 0.00000011*c*c*c
+	n := 201.5643 + 385.81693528*kf + 0.0107582*c*c + 0.00001238*c*c*c - 0.000000058*c*c*c*c
+	f := 160.7108 + 390.67050284*kf - 0.0016118*c*c - 0.00000227*c*c*c + 0.000000011*c*c*c*c
+	omega := 124.7746 - 1.56375588*kf + 0.0020672*c*c + 0.00000215*c*c*c
+	for _, s := range newMoonTerms {
+		arg := float64(s.m)*m + float64(s.n)*n + float64(s.f)*f
+		t += s.v * math.Pow(e, float64(s.e)) * sinDeg(arg)
+	}
+	t += -0.00017 * sinDeg(omega)
+	for i, s := range planetaryTerms {
+		a := s.a + s.k*kf
+		if i == 0 {
+			a -= 0.009173 * c * c
+		}
+		t += s.v * sinDeg(a)
+	}
+	return t - deltaT(t)
+}
+
+// newMoonAtOrAfter returns the moment of the first new moon at or
+// after t.
+func newMoonAtOrAfter(t float64) float64 {
+	k := int(math.Floor((t-nthNewMoon(0))/meanSynodicMonth)) - 1
+	for nthNewMoon(k) < t {
+		k++
+	}
+	return nthNewMoon(k)
+}
+
+// newMoonBefore returns the moment of the last new moon before t.
+func newMoonBefore(t float64) float64 {
+	k := int(math.Floor((t-nthNewMoon(0))/meanSynodicMonth)) + 1
+	for nthNewMoon(k) >= t {
+		k--
+	}
+	return nthNewMoon(k)
+}
+
+func sinDeg(x float64) float64 { return math.Sin(x * math.Pi / 180) }
+func cosDeg(x float64) float64 { return math.Cos(x * math.Pi / 180) }
+
+// modDeg returns x modulo 360, in [0, 360).
+func modDeg(x float64) float64 {
+	x = math.Mod(x, 360)
+	if x < 0 {
+		x += 360
+	}
+	return x
+}
@@ -0,0 +1,239 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package calendar converts Times to and from dates of the Hebrew,
+// Islamic and Chinese calendars, for applications whose users keep
+// them alongside the Gregorian one.
+// 公历以外的历法：希伯来历、伊斯兰历（民用/天文纪元）和中国农历的日期换算与格式化
+//
+// A Date is a day of one calendar, with no time zone: a Calendar
+// gives the Date on which a Time falls in its Location, and the first
+// instant of a Date in a Location. Days begin at midnight, as in the
+// Gregorian calendar, not at sunset as the Hebrew and Islamic days
+// do in religious use.
+//
+//	t := time.Date(2024, time.October, 3, 12, 0, 0, 0, time.UTC)
+//	d := calendar.Hebrew.Date(t)                               // {5785 7 false 1}
+//	s := calendar.Format(calendar.Hebrew, t, "2 January 2006") // "1 Tishri 5785"
+package calendar
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"time/civil"
+)
+
+// A Date is a day of one of the calendars of this package. The
+// meaning of Month depends on the calendar; Leap marks an
+// intercalary month that repeats the number of the month before it,
+// as in the Chinese calendar.
+type Date struct {
+	Year  int
+	Month int
+	Leap  bool
+	Day   int
+}
+
+// A Calendar converts between Times and the Dates of one calendar.
+//
+// Format uses only these methods, so a Calendar may be wrapped to
+// change its month names, for another language:
+//
+//	type yiddish struct{ calendar.Calendar }
+//
+//	func (y yiddish) MonthName(d calendar.Date) string { ... }
+type Calendar interface {
+	// Date returns the date on which t falls in its Location.
+	Date(t time.Time) Date
+
+	// Time returns the first instant of d in loc, as civil.Date.In
+	// does, or an error if d is not a date of the calendar.
+	Time(d Date, loc *time.Location) (time.Time, error)
+
+	// MonthName returns the name of the month of d.
+	MonthName(d Date) string
+}
+
+// errInvalid is returned by Time for a Date that the calendar does
+// not have, such as the 30th day of a month of 29 days.
+var errInvalid = errors.New("calendar: invalid date")
+
+// Dates are converted through fixed day numbers, which count days
+// from 1 January of year 1 of the proleptic Gregorian calendar, day 1.
+
+// unixFixed is the fixed day number of 1 January 1970.
+const unixFixed = 719163
+
+// fixedOf returns the fixed day number of the day on which t falls
+// in its Location.
+func fixedOf(t time.Time) int {
+	d := civil.DateOf(t)
+	return int(time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC).Unix()/(24*60*60)) + unixFixed
+}
+
+// civilOf returns the Gregorian date of the fixed day number f.
+func civilOf(f int) civil.Date {
+	return civil.DateOf(time.Unix(int64(f-unixFixed)*24*60*60, 0).UTC())
+}
+
+// Format returns a textual representation of t in the layout, as
+// t.Format does, but with the year, month and day of t in c. The
+// year elements "2006" and "06", the month elements "January",
+// "Jan", "01" and "1" and the day elements "02", "_2" and "2" are
+// those of c; "January" and "Jan" both give c.MonthName. Other
+// elements, such as the weekday and the time of day, are unchanged.
+func Format(c Calendar, t time.Time, layout string) string {
+	d := c.Date(t)
+	var b []byte
+	for layout != "" {
+		prefix, elem, suffix := nextElem(layout)
+		b = append(b, prefix...)
+		switch elem {
+		case "":
+		case "2006":
+			b = appendInt(b, d.Year, 0)
+		case "06":
+			y := d.Year % 100
+			if y < 0 {
+				y = -y
+			}
+			b = appendInt(b, y, 2)
+		case "January", "Jan":
+			b = append(b, c.MonthName(d)...)
+		case "01":
+			b = appendInt(b, d.Month, 2)
+		case "1":
+			b = appendInt(b, d.Month, 0)
+		case "02":
+			b = appendInt(b, d.Day, 2)
+		case "_2":
+			if d.Day < 10 {
+				b = append(b, ' ')
+			}
+			b = appendInt(b, d.Day, 0)
+		case "2":
+			b = appendInt(b, d.Day, 0)
+		default:
+			b = append(b, t.Format(elem)...)
+		}
+		layout = suffix
+	}
+	return string(b)
+}
+
+// nextElem returns the text before the first element of layout, the
+// element and the text after it, with the elements recognized as by
+// time.Time.Format.
+func nextElem(layout string) (prefix, elem, suffix string) {
+	for i := 0; i < len(layout); i++ {
+		rest := layout[i:]
+		n := 0
+		switch c := layout[i]; c {
+		case 'J':
+			if strings.HasPrefix(rest, "January") {
+				n = 7
+			} else if strings.HasPrefix(rest, "Jan") && !startsWithLowerCase(rest[3:]) {
+				n = 3
+			}
+		case 'M':
+			if strings.HasPrefix(rest, "Monday") {
+				n = 6
+			} else if strings.HasPrefix(rest, "Mon") && !startsWithLowerCase(rest[3:]) {
+				n = 3
+			} else if strings.HasPrefix(rest, "MST") {
+				n = 3
+			}
+		case '0':
+			if len(rest) >= 2 && '1' <= rest[1] && rest[1] <= '6' {
+				n = 2
+			}
+		case '1':
+			n = 1
+			if strings.HasPrefix(rest, "15") {
+				n = 2
+			}
+		case '2':
+			n = 1
+			if strings.HasPrefix(rest, "2006") {
+				n = 4
+			}
+		case '_':
+			if strings.HasPrefix(rest, "_2006") {
+				// A literal _ before the year.
+				return layout[:i+1], "2006", layout[i+5:]
+			}
+			if strings.HasPrefix(rest, "_2") {
+				n = 2
+			}
+		case '3', '4', '5':
+			n = 1
+		case 'P', 'p':
+			if strings.HasPrefix(rest, "PM") || strings.HasPrefix(rest, "pm") {
+				n = 2
+			}
+		case '-', 'Z':
+			for _, tz := range [...]string{"070000", "07:00:00", "0700", "07:00", "07"} {
+				if strings.HasPrefix(rest[1:], tz) {
+					n = 1 + len(tz)
+					break
+				}
+			}
+		case '.':
+			if len(rest) >= 2 && (rest[1] == '0' || rest[1] == '9') {
+				j := 1
+				for j < len(rest) && rest[j] == rest[1] {
+					j++
+				}
+				// The digits must end here: only a fractional second
+				// is all digits.
+				if j == len(rest) || rest[j] < '0' || '9' < rest[j] {
+					n = j
+				}
+			}
+		}
+		if n > 0 {
+			return layout[:i], rest[:n], rest[n:]
+		}
+	}
+	return layout, "", ""
+}
+
+func startsWithLowerCase(s string) bool {
+	return s != "" && 'a' <= s[0] && s[0] <= 'z'
+}
+
+// appendInt appends the decimal form of x to b, with at least width
+// digits.
+func appendInt(b []byte, x, width int) []byte {
+	if x < 0 {
+		b = append(b, '-')
+		x = -x
+	}
+	var buf [20]byte
+	i := len(buf)
+	for x >= 10 || width > 1 {
+		i--
+		buf[i] = byte('0' + x%10)
+		x /= 10
+		width--
+	}
+	i--
+	buf[i] = byte('0' + x)
+	return append(b, buf[i:]...)
+}
+
+// floorDiv returns x/y rounded toward minus infinity, for y > 0.
+func floorDiv(x, y int) int {
+	q := x / y
+	if x%y < 0 {
+		q--
+	}
+	return q
+}
+
+// mod returns x modulo y, in [0, y), for y > 0.
+func mod(x, y int) int {
+	return x - y*floorDiv(x, y)
+}
@@ -0,0 +1,194 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package calendar
+
+import (
+	"math"
+	"time"
+)
+
+// Chinese is the Chinese lunisolar calendar, as it has been reckoned
+// since 1645: each month begins on the day of a new moon, and a year
+// with 13 months repeats, as a leap month, the first month after the
+// winter solstice that contains no major solar term. Days and the
+// moments of new moons and solar terms are those of Beijing, UTC+8,
+// or its mean solar time before 1929.
+// 中国农历：朔日为月首，冬至所在为十一月，无中气之月置闰
+//
+// The Year of a Date is that of the Gregorian calendar in which the
+// Chinese year begins: the first day of the first month of Year 2024,
+// the Spring Festival, was 10 February 2024. Month runs from 1 to 12, with
+// Leap set on a leap month, which follows the month of the same
+// number. Dates are computed from the positions of the Sun and the
+// Moon, not read from an almanac; where a new moon falls within a
+// minute or so of midnight they may differ from published ones by a
+// day.
+var Chinese Calendar = chinese{}
+
+type chinese struct{}
+
+// The names of the months of the Chinese calendar.
+var chineseMonths = [...]string{
+	"正月", "二月", "三月", "四月", "五月", "六月",
+	"七月", "八月", "九月", "十月", "冬月", "腊月",
+}
+
+func (chinese) MonthName(d Date) string {
+	if d.Month < 1 || d.Month > len(chineseMonths) {
+		return "%!Month(" + string(appendInt(nil, d.Month, 0)) + ")"
+	}
+	if d.Leap {
+		return "闰" + chineseMonths[d.Month-1]
+	}
+	return chineseMonths[d.Month-1]
+}
+
+func (chinese) Date(t time.Time) Date {
+	return chineseFromFixed(fixedOf(t))
+}
+
+func (chinese) Time(d Date, loc *time.Location) (time.Time, error) {
+	if d.Month < 1 || d.Month > 12 || d.Day < 1 || d.Day > 30 {
+		return time.Time{}, errInvalid
+	}
+	f := fixedFromChinese(d)
+	if chineseFromFixed(f) != d {
+		return time.Time{}, errInvalid
+	}
+	return civilOf(f).In(loc), nil
+}
+
+// The algorithms are those of Reingold and Dershowitz, Calendrical
+// Calculations. Days are fixed day numbers, and moments fixed day
+// numbers with a fraction, in universal time.
+
+// chineseEpoch is the fixed day number of the traditional start of
+// the first cycle of years, 15 February 2637 BC in the proleptic
+// Gregorian calendar.
+const chineseEpoch = -963099
+
+// chineseOffset returns the offset from UTC of Beijing, in days, at
+// the moment t.
+func chineseOffset(t float64) float64 {
+	if t < 704188 { // 1929-01-01
+		return 1397.0 / 180 / 24 // 116°25' east
+	}
+	return 8.0 / 24
+}
+
+// midnightInChina returns the moment at which day f begins in Beijing.
+func midnightInChina(f int) float64 {
+	t := float64(f)
+	return t - chineseOffset(t)
+}
+
+// dayInChina returns the day of the moment t in Beijing.
+func dayInChina(t float64) int {
+	return int(math.Floor(t + chineseOffset(t)))
+}
+
+// chineseWinterSolstice returns the day, on or before f, of the last
+// winter solstice in Beijing.
+func chineseWinterSolstice(f int) int {
+	approx := estimatePriorSolarLongitude(270, midnightInChina(f+1))
+	d := int(math.Floor(approx)) - 1
+	for solarLongitude(midnightInChina(d+1)) < 270 || solarLongitude(midnightInChina(d+1)) > 300 {
+		d++
+	}
+	return d
+}
+
+// chineseNewMoonOnOrAfter and chineseNewMoonBefore return the day of
+// the first new moon on or after day f, and of the last before it.
+func chineseNewMoonOnOrAfter(f int) int {
+	return dayInChina(newMoonAtOrAfter(midnightInChina(f)))
+}
+
+func chineseNewMoonBefore(f int) int {
+	return dayInChina(newMoonBefore(midnightInChina(f)))
+}
+
+// majorSolarTerm returns the number, 1 to 12, of the last major solar
+// term, the Sun entering a multiple of 30 degrees of longitude, at
+// the start of day f; term 11 is the winter solstice.
+func majorSolarTerm(f int) int {
+	s := solarLongitude(midnightInChina(f))
+	return mod(1+int(math.Floor(s/30)), 12) + 1
+}
+
+// noMajorSolarTerm reports whether the month that begins on day m
+// has no major solar term.
+func noMajorSolarTerm(m int) bool {
+	return majorSolarTerm(m) == majorSolarTerm(chineseNewMoonOnOrAfter(m+1))
+}
+
+// priorLeapMonth reports whether there is a leap month from the month
+// beginning on day m0 to the one beginning on day m, inclusive.
+func priorLeapMonth(m0, m int) bool {
+	for ; m >= m0; m = chineseNewMoonBefore(m) {
+		if noMajorSolarTerm(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// months returns the number of lunations between the new moons of
+// days m0 and m.
+func months(m0, m int) int {
+	return int(math.Floor(float64(m-m0)/meanSynodicMonth + 0.5))
+}
+
+func chineseFromFixed(f int) Date {
+	s1 := chineseWinterSolstice(f)
+	s2 := chineseWinterSolstice(s1 + 370)
+	m12 := chineseNewMoonOnOrAfter(s1 + 1)
+	nextM11 := chineseNewMoonBefore(s2 + 1)
+	m := chineseNewMoonBefore(f + 1)
+	leapYear := months(m12, nextM11) == 12
+
+	n := months(m12, m)
+	if leapYear && priorLeapMonth(m12, m) {
+		n--
+	}
+	month := mod(n-1, 12) + 1
+	leap := leapYear && noMajorSolarTerm(m) && !priorLeapMonth(m12, chineseNewMoonBefore(m))
+	elapsed := int(math.Floor(1.5 - float64(month)/12 + float64(f-chineseEpoch)/meanTropicalYear))
+	return Date{Year: elapsed - 2637, Month: month, Leap: leap, Day: f - m + 1}
+}
+
+// chineseNewYearInSui returns the day of the new year in the year
+// from the winter solstice on or before day f to the next.
+func chineseNewYearInSui(f int) int {
+	s1 := chineseWinterSolstice(f)
+	s2 := chineseWinterSolstice(s1 + 370)
+	m12 := chineseNewMoonOnOrAfter(s1 + 1)
+	m13 := chineseNewMoonOnOrAfter(m12 + 1)
+	nextM11 := chineseNewMoonBefore(s2 + 1)
+	if months(m12, nextM11) == 12 && (noMajorSolarTerm(m12) || noMajorSolarTerm(m13)) {
+		return chineseNewMoonOnOrAfter(m13 + 1)
+	}
+	return m13
+}
+
+// chineseNewYear returns the day of the last new year on or before
+// day f.
+func chineseNewYear(f int) int {
+	if ny := chineseNewYearInSui(f); f >= ny {
+		return ny
+	}
+	return chineseNewYearInSui(f - 180)
+}
+
+// fixedFromChinese returns the day of d, which need not be valid.
+func fixedFromChinese(d Date) int {
+	midYear := int(math.Floor(chineseEpoch + (float64(d.Year+2637)-0.5)*meanTropicalYear))
+	ny := chineseNewYear(midYear)
+	p := chineseNewMoonOnOrAfter(ny + (d.Month-1)*29)
+	if c := chineseFromFixed(p); c.Month != d.Month || c.Leap != d.Leap {
+		p = chineseNewMoonOnOrAfter(p + 1)
+	}
+	return p + d.Day - 1
+}
@@ -0,0 +1,157 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package calendar
+
+import "time"
+
+// Hebrew is the arithmetic Hebrew calendar, with years counted from
+// the creation (anno mundi). Months are numbered from Nisan, as in
+// the Bible: 1 Nisan, 2 Iyyar, 3 Sivan, 4 Tammuz, 5 Av, 6 Elul,
+// 7 Tishri, 8 Marheshvan, 9 Kislev, 10 Tevet, 11 Shevat, 12 Adar and,
+// in leap years, 13 Adar II, when 12 is Adar I. The year begins on
+// 1 Tishri, so that Tishri to Elul is one year: 1 Tishri 5785 was
+// 3 October 2024.
+// 希伯来历：年份自创世纪年起算，月份自尼散月起编号，新年在提斯利月一日
+var Hebrew Calendar = hebrew{}
+
+type hebrew struct{}
+
+// The names of the months of the Hebrew calendar, from Nisan.
+var hebrewMonths = [...]string{
+	"Nisan", "Iyyar", "Sivan", "Tammuz", "Av", "Elul",
+	"Tishri", "Marheshvan", "Kislev", "Tevet", "Shevat", "Adar", "Adar II",
+}
+
+func (hebrew) MonthName(d Date) string {
+	if d.Month < 1 || d.Month > len(hebrewMonths) {
+		return "%!Month(" + string(appendInt(nil, d.Month, 0)) + ")"
+	}
+	if d.Month == 12 && hebrewLeapYear(d.Year) {
+		return "Adar I"
+	}
+	return hebrewMonths[d.Month-1]
+}
+
+func (hebrew) Date(t time.Time) Date {
+	return hebrewFromFixed(fixedOf(t))
+}
+
+func (hebrew) Time(d Date, loc *time.Location) (time.Time, error) {
+	if d.Leap || d.Month < 1 || d.Month > hebrewLastMonth(d.Year) ||
+		d.Day < 1 || d.Day > hebrewMonthDays(d.Year, d.Month) {
+		return time.Time{}, errInvalid
+	}
+	return civilOf(fixedFromHebrew(d.Year, d.Month, d.Day)).In(loc), nil
+}
+
+// The algorithms are those of Reingold and Dershowitz, Calendrical
+// Calculations.
+
+// hebrewEpoch is the fixed day number of 1 Tishri of year 1, which
+// is 7 October 3761 BC in the proleptic Julian calendar.
+const hebrewEpoch = -1373427
+
+func hebrewLeapYear(y int) bool {
+	return mod(7*y+1, 19) < 7
+}
+
+// hebrewLastMonth returns the number of the last month of year y,
+// 13 in leap years and 12 in others.
+func hebrewLastMonth(y int) int {
+	if hebrewLeapYear(y) {
+		return 13
+	}
+	return 12
+}
+
+// hebrewElapsedDays returns the number of days from the epoch to
+// 1 Tishri of year y by the molad, the mean new moon, with the rule
+// that the year may not begin on a Sunday, Wednesday or Friday.
+func hebrewElapsedDays(y int) int {
+	months := floorDiv(235*y-234, 19)
+	parts := 12084 + 13753*months // of 25920 to the day
+	days := 29*months + floorDiv(parts, 25920)
+	if mod(3*(days+1), 7) < 3 {
+		days++
+	}
+	return days
+}
+
+// hebrewYearDelay returns the days by which year y is delayed so
+// that no year has an impossible length.
+func hebrewYearDelay(y int) int {
+	ny0, ny1, ny2 := hebrewElapsedDays(y-1), hebrewElapsedDays(y), hebrewElapsedDays(y+1)
+	switch {
+	case ny2-ny1 == 356:
+		return 2
+	case ny1-ny0 == 382:
+		return 1
+	}
+	return 0
+}
+
+// hebrewNewYear returns the fixed day number of 1 Tishri of year y.
+func hebrewNewYear(y int) int {
+	return hebrewEpoch + hebrewElapsedDays(y) + hebrewYearDelay(y)
+}
+
+// hebrewMonthDays returns the number of days in month m of year y.
+func hebrewMonthDays(y, m int) int {
+	switch m {
+	case 2, 4, 6, 10, 13:
+		return 29
+	case 12:
+		if !hebrewLeapYear(y) {
+			return 29
+		}
+	case 8, 9:
+		// Marheshvan is long in complete years, and Kislev short in
+		// deficient ones.
+		switch n := hebrewNewYear(y+1) - hebrewNewYear(y); {
+		case m == 8 && n != 355 && n != 385:
+			return 29
+		case m == 9 && (n == 353 || n == 383):
+			return 29
+		}
+	}
+	return 30
+}
+
+func fixedFromHebrew(y, m, d int) int {
+	f := hebrewNewYear(y) + d - 1
+	if m < 7 {
+		for i := 7; i <= hebrewLastMonth(y); i++ {
+			f += hebrewMonthDays(y, i)
+		}
+		for i := 1; i < m; i++ {
+			f += hebrewMonthDays(y, i)
+		}
+	} else {
+		for i := 7; i < m; i++ {
+			f += hebrewMonthDays(y, i)
+		}
+	}
+	return f
+}
+
+func hebrewFromFixed(f int) Date {
+	// The mean year is 35975351/98496 days; the estimate is at most
+	// a year late.
+	y := int(int64(f-hebrewEpoch)*98496/35975351) + 1
+	for hebrewNewYear(y) > f {
+		y--
+	}
+	for hebrewNewYear(y+1) <= f {
+		y++
+	}
+	m := 7
+	if f >= fixedFromHebrew(y, 1, 1) {
+		m = 1
+	}
+	for f > fixedFromHebrew(y, m, hebrewMonthDays(y, m)) {
+		m++
+	}
+	return Date{Year: y, Month: m, Day: f - fixedFromHebrew(y, m, 1) + 1}
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package calendar
+
+import "time"
+
+// IslamicCivil and IslamicAstronomical are the tabular Islamic
+// calendar, with years counted from the Hijra (anno Hegirae) and
+// months numbered from 1 Muharram to 12 Dhu al-Hijja. Months
+// alternate between 30 and 29 days, and 11 years in 30 have a 30th
+// day of Dhu al-Hijja. The civil calendar reckons from the civil
+// epoch, Friday 16 July 622 in the Julian calendar, and the
+// astronomical one from the day before.
+// 伊斯兰历（表格历），民用纪元与天文纪元相差一天
+//
+// Neither predicts the sighting of the crescent moon, which decides
+// the calendar in religious use, nor follows the Umm al-Qura
+// calendar of Saudi Arabia; they may differ from either by a day or
+// two.
+var (
+	IslamicCivil        Calendar = islamic{islamicEpoch}
+	IslamicAstronomical Calendar = islamic{islamicEpoch - 1}
+)
+
+type islamic struct {
+	epoch int // fixed day number of 1 Muharram 1
+}
+
+// islamicEpoch is the fixed day number of the civil epoch.
+const islamicEpoch = 227015
+
+// The names of the months of the Islamic calendar.
+var islamicMonths = [...]string{
+	"Muharram", "Safar", "Rabi' al-awwal", "Rabi' al-thani",
+	"Jumada al-awwal", "Jumada al-thani", "Rajab", "Sha'ban",
+	"Ramadan", "Shawwal", "Dhu al-Qa'da", "Dhu al-Hijja",
+}
+
+func (islamic) MonthName(d Date) string {
+	if d.Month < 1 || d.Month > len(islamicMonths) {
+		return "%!Month(" + string(appendInt(nil, d.Month, 0)) + ")"
+	}
+	return islamicMonths[d.Month-1]
+}
+
+func (c islamic) Date(t time.Time) Date {
+	f := fixedOf(t)
+	y := floorDiv(30*(f-c.epoch)+10646, 10631)
+	m := (11*(f-c.fixed(y, 1, 1)) + 330) / 325
+	return Date{Year: y, Month: m, Day: f - c.fixed(y, m, 1) + 1}
+}
+
+func (c islamic) Time(d Date, loc *time.Location) (time.Time, error) {
+	days := 30 - (d.Month+1)%2
+	if d.Month == 12 && mod(14+11*d.Year, 30) < 11 {
+		days = 30
+	}
+	if d.Leap || d.Month < 1 || d.Month > 12 || d.Day < 1 || d.Day > days {
+		return time.Time{}, errInvalid
+	}
+	return civilOf(c.fixed(d.Year, d.Month, d.Day)).In(loc), nil
+}
+
+// fixed returns the fixed day number of day d of month m of year y.
+func (c islamic) fixed(y, m, d int) int {
+	return c.epoch - 1 + d + 29*(m-1) + (6*m-1)/11 + 354*(y-1) + floorDiv(3+11*y, 30)
+}
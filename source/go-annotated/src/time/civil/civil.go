@@ -0,0 +1,262 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package civil provides dates and times of day that are not tied to
+// a time zone, such as a birthday or a store's opening hours.
+// 与时区无关的日期和一天中的时刻，例如生日、营业时间
+//
+// A time.Time is an instant; representing a birthday as midnight UTC
+// shows it on the wrong day in half the world. A Date or TimeOfDay
+// means the same wall calendar or clock reading everywhere, and
+// becomes an instant only when placed in a Location with Date.In or
+// Date.At.
+package civil
+
+import (
+	"errors"
+	"time"
+)
+
+// A Date is a day of the Gregorian calendar, such as 2006-01-02,
+// independent of any time zone.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the Date on which t occurs in its Location.
+func DateOf(t time.Time) Date {
+	var d Date
+	d.Year, d.Month, d.Day = t.Date()
+	return d
+}
+
+// ParseDate parses a date in the RFC 3339 full-date format, "2006-01-02".
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+// String returns d in the RFC 3339 full-date format, "2006-01-02".
+func (d Date) String() string {
+	return d.midnight().Format("2006-01-02")
+}
+
+// IsValid reports whether d is a real date, unlike 2006-02-30.
+func (d Date) IsValid() bool {
+	return DateOf(d.midnight()) == d
+}
+
+// In returns the first instant of d in loc. That is normally
+// midnight, but in zones whose clocks skip midnight for daylight
+// saving time it is the first instant after the skipped hour.
+func (d Date) In(loc *time.Location) time.Time {
+	return d.At(TimeOfDay{}, loc)
+}
+
+// At returns the instant at which the clocks in loc show d and tod.
+// A reading skipped by a zone transition is moved forward by the
+// length of the gap; a repeated one gives its first occurrence.
+// See time.ResolveLocal.
+func (d Date) At(tod TimeOfDay, loc *time.Location) time.Time {
+	t, _, _ := time.ResolveLocal(d.Year, d.Month, d.Day, tod.Hour, tod.Minute, tod.Second, tod.Nanosecond, loc, time.ResolveEarlier)
+	return t
+}
+
+// midnight returns d at midnight UTC, normalizing invalid dates.
+func (d Date) midnight() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// AddDays returns the date n days after d. n may be negative.
+func (d Date) AddDays(n int) Date {
+	return DateOf(time.Date(d.Year, d.Month, d.Day+n, 0, 0, 0, 0, time.UTC))
+}
+
+// AddDate returns the date years, months and days after d,
+// normalized as by time.Time.AddDate: October 31 plus one month
+// is December 1.
+func (d Date) AddDate(years, months, days int) Date {
+	return DateOf(time.Date(d.Year+years, d.Month+time.Month(months), d.Day+days, 0, 0, 0, 0, time.UTC))
+}
+
+// DaysSince returns the number of days from u to d,
+// negative if d is before u.
+func (d Date) DaysSince(u Date) int {
+	return int(d.midnight().Sub(u.midnight()) / (24 * time.Hour))
+}
+
+// Weekday returns the day of the week of d.
+func (d Date) Weekday() time.Weekday {
+	return d.midnight().Weekday()
+}
+
+// Before reports whether d is before u.
+func (d Date) Before(u Date) bool { return d.Compare(u) < 0 }
+
+// After reports whether d is after u.
+func (d Date) After(u Date) bool { return d.Compare(u) > 0 }
+
+// Compare returns -1 if d is before u, +1 if d is after u, and 0
+// if they are the same date.
+func (d Date) Compare(u Date) int {
+	switch {
+	case d.Year != u.Year:
+		return sign(d.Year - u.Year)
+	case d.Month != u.Month:
+		return sign(int(d.Month - u.Month))
+	}
+	return sign(d.Day - u.Day)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The output is that of String.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The input is parsed with ParseDate.
+func (d *Date) UnmarshalText(data []byte) error {
+	var err error
+	*d, err = ParseDate(string(data))
+	return err
+}
+
+// A TimeOfDay is a reading of a 24-hour clock, such as 15:04:05,
+// independent of any date or time zone.
+type TimeOfDay struct {
+	Hour       int // 0 to 23
+	Minute     int // 0 to 59
+	Second     int // 0 to 59
+	Nanosecond int // 0 to 999999999
+}
+
+// TimeOfDayOf returns the clock reading of t in its Location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	var tod TimeOfDay
+	tod.Hour, tod.Minute, tod.Second = t.Clock()
+	tod.Nanosecond = t.Nanosecond()
+	return tod
+}
+
+var errTimeOfDay = errors.New("civil: invalid time of day")
+
+// ParseTimeOfDay parses a time of day in the RFC 3339 partial-time
+// format, "15:04:05" with an optional fraction of a second as in
+// "15:04:05.999999999". The seconds may be omitted, as in "15:04".
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	layout := "15:04:05"
+	if len(s) == len("15:04") {
+		layout = "15:04"
+	}
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+	return TimeOfDayOf(t), nil
+}
+
+// String returns tod in the format "15:04:05", followed by the
+// fraction of a second, if any, without trailing zeros, as in
+// "15:04:05.5".
+func (tod TimeOfDay) String() string {
+	return time.Date(2000, 1, 1, tod.Hour, tod.Minute, tod.Second, tod.Nanosecond, time.UTC).Format("15:04:05.999999999")
+}
+
+// IsValid reports whether each field of tod is within its range.
+func (tod TimeOfDay) IsValid() bool {
+	return 0 <= tod.Hour && tod.Hour < 24 &&
+		0 <= tod.Minute && tod.Minute < 60 &&
+		0 <= tod.Second && tod.Second < 60 &&
+		0 <= tod.Nanosecond && tod.Nanosecond < 1e9
+}
+
+// SinceMidnight returns the time elapsed on a 24-hour day from
+// midnight to tod.
+func (tod TimeOfDay) SinceMidnight() time.Duration {
+	return time.Duration(tod.Hour)*time.Hour +
+		time.Duration(tod.Minute)*time.Minute +
+		time.Duration(tod.Second)*time.Second +
+		time.Duration(tod.Nanosecond)
+}
+
+// TimeOfDayAt returns the clock reading d after midnight, wrapping
+// around every 24 hours: 25*time.Hour gives 01:00:00.
+func TimeOfDayAt(d time.Duration) TimeOfDay {
+	const day = 24 * time.Hour
+	d %= day
+	if d < 0 {
+		d += day
+	}
+	return TimeOfDay{
+		Hour:       int(d / time.Hour),
+		Minute:     int(d % time.Hour / time.Minute),
+		Second:     int(d % time.Minute / time.Second),
+		Nanosecond: int(d % time.Second),
+	}
+}
+
+// Add returns the clock reading d after tod, wrapping around
+// midnight. It is clock arithmetic: it ignores time zones and
+// daylight saving time.
+func (tod TimeOfDay) Add(d time.Duration) TimeOfDay {
+	return TimeOfDayAt(tod.SinceMidnight() + d%(24*time.Hour))
+}
+
+// Sub returns the time from u to tod on the same 24-hour day,
+// negative if tod is earlier.
+func (tod TimeOfDay) Sub(u TimeOfDay) time.Duration {
+	return tod.SinceMidnight() - u.SinceMidnight()
+}
+
+// Before reports whether tod is earlier in the day than u.
+func (tod TimeOfDay) Before(u TimeOfDay) bool { return tod.Compare(u) < 0 }
+
+// After reports whether tod is later in the day than u.
+func (tod TimeOfDay) After(u TimeOfDay) bool { return tod.Compare(u) > 0 }
+
+// Compare returns -1 if tod is earlier in the day than u, +1 if it
+// is later, and 0 if they are the same.
+func (tod TimeOfDay) Compare(u TimeOfDay) int {
+	d := tod.Sub(u)
+	switch {
+	case d < 0:
+		return -1
+	case d > 0:
+		return +1
+	}
+	return 0
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The output is that of String.
+func (tod TimeOfDay) MarshalText() ([]byte, error) {
+	if !tod.IsValid() {
+		return nil, errTimeOfDay
+	}
+	return []byte(tod.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The input is parsed with ParseTimeOfDay.
+func (tod *TimeOfDay) UnmarshalText(data []byte) error {
+	var err error
+	*tod, err = ParseTimeOfDay(string(data))
+	return err
+}
+
+func sign(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return +1
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// A Clock provides the current time and timers. Code that takes a
+// Clock instead of calling Now, NewTimer and the like directly can be
+// tested with a fake clock that the test advances by hand, such as
+// the one in package time/clocktest, instead of waiting in real time.
+// 时钟接口：依赖它而不是直接调用包函数的代码可以在测试中注入假时钟
+//
+// SystemClock is the Clock of the package functions.
+type Clock interface {
+	// Now returns the current time, as the function Now.
+	Now() Time
+
+	// Sleep pauses the current goroutine for at least d, as the
+	// function Sleep.
+	Sleep(d Duration)
+
+	// After waits for d to elapse and then sends the current time
+	// on the returned channel, as the function After.
+	After(d Duration) <-chan Time
+
+	// NewTimer returns a timer that sends the current time on its
+	// channel after at least d, as the function NewTimer.
+	NewTimer(d Duration) ClockTimer
+
+	// NewTicker returns a ticker that sends the current time on its
+	// channel every d, as the function NewTicker. It panics if d is
+	// not positive.
+	NewTicker(d Duration) ClockTicker
+}
+
+// A ClockTimer is a timer created by a Clock. The methods are those
+// of Timer, with the channel given by Chan instead of the field C.
+type ClockTimer interface {
+	Chan() <-chan Time
+	Stop() bool
+	Reset(d Duration) bool
+}
+
+// A ClockTicker is a ticker created by a Clock. The methods are
+// those of Ticker, with the channel given by Chan instead of the
+// field C.
+type ClockTicker interface {
+	Chan() <-chan Time
+	Stop()
+	Reset(d Duration)
+}
+
+// SystemClock is the Clock that reads the system clock and uses the
+// timers of this package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() Time                    { return Now() }
+func (systemClock) Sleep(d Duration)             { Sleep(d) }
+func (systemClock) After(d Duration) <-chan Time { return After(d) }
+
+func (systemClock) NewTimer(d Duration) ClockTimer {
+	return systemTimer{NewTimer(d)}
+}
+
+func (systemClock) NewTicker(d Duration) ClockTicker {
+	return systemTicker{NewTicker(d)}
+}
+
+type systemTimer struct{ *Timer }
+
+func (t systemTimer) Chan() <-chan Time { return t.C }
+
+type systemTicker struct{ *Ticker }
+
+func (t systemTicker) Chan() <-chan Time { return t.C }
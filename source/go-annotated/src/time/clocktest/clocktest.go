@@ -0,0 +1,281 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package clocktest provides a fake time.Clock for tests, whose time
+// only moves when the test says so. Timers, tickers and sleeps that
+// come due as the clock is advanced fire at once, in order, so code
+// full of timeouts can be tested in milliseconds.
+// 测试用假时钟：时间只在测试调用 Advance 时前进，并按顺序触发到期的计时器
+//
+// A typical test starts the code under test with the fake clock,
+// waits for it to set its timers, and advances past them:
+//
+//	clk := clocktest.Freeze(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+//	done := make(chan error)
+//	go func() { done <- retryWithBackoff(clk, op) }()
+//	clk.BlockUntil(1)           // the first backoff timer is set
+//	clk.Advance(time.Second)    // it fires
+package clocktest
+
+import (
+	"sync"
+	"time"
+)
+
+// A Clock is a fake time.Clock. Its time stands still except when
+// moved by Advance, Set or Step. It is safe for concurrent use.
+//
+// Like the system clock, a Clock has a wall clock and a monotonic
+// clock: the times it returns carry a monotonic clock reading, which
+// Advance moves along with the wall clock and Step leaves alone.
+type Clock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond // signaled when a timer is added
+	now     time.Time  // the wall clock, without a monotonic reading
+	mono    int64      // the monotonic clock reading
+	timers  []*timer   // active timers, in no particular order
+	nextSeq uint64
+}
+
+// Freeze returns a fake Clock frozen at t.
+func Freeze(t time.Time) *Clock {
+	c := &Clock{now: t.Round(0)}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+var _ time.Clock = (*Clock)(nil)
+
+// Now returns the current time of the fake clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.time()
+}
+
+// time returns the current time, with its monotonic clock reading.
+// c.mu must be held.
+func (c *Clock) time() time.Time {
+	return c.now.WithMonotonic(c.mono)
+}
+
+// Advance moves the clock forward by d, firing the timers and
+// tickers that come due on the way in the order of their deadlines,
+// and those with the same deadline in the order they were set. While
+// each fires, Now returns its deadline, and that is the time sent on
+// its channel. A ticker fires as many times as its period fits.
+//
+// Advance panics if d is negative.
+func (c *Clock) Advance(d time.Duration) {
+	if d < 0 {
+		panic("clocktest: negative duration in call to Advance")
+	}
+	c.Set(c.Now().Add(d))
+}
+
+// Set moves the clock to t, firing timers as Advance does. Setting
+// the clock back is allowed; it moves only the wall clock, no timer
+// fires then, and the ones already set keep their deadlines.
+func (c *Clock) Set(t time.Time) {
+	t = t.Round(0)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for {
+		tm := c.earliest()
+		if tm == nil || tm.when.After(t) {
+			break
+		}
+		if tm.when.After(c.now) {
+			c.move(tm.when)
+		}
+		c.fire(tm)
+	}
+	if t.After(c.now) {
+		c.move(t)
+	} else {
+		c.now = t
+	}
+}
+
+// Step moves the wall clock by d, forward or back, without moving
+// the monotonic clock, as when the system clock is set or the machine
+// wakes from suspend. No timer fires: timers, like those of package
+// time, measure the monotonic clock, so each comes due as late as
+// before, by the wall clock moved by d.
+func (c *Clock) Step(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, t := range c.timers {
+		t.when = t.when.Add(d)
+	}
+}
+
+// move moves both clocks forward to t. c.mu must be held.
+func (c *Clock) move(t time.Time) {
+	c.mono += int64(t.Sub(c.now))
+	c.now = t
+}
+
+// BlockUntil waits until at least n timers, tickers or sleeps are
+// pending on the clock, so that a test can advance the clock only
+// after the code under test has set them.
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+// Pending returns the number of timers, tickers and sleeps pending
+// on the clock.
+func (c *Clock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// Sleep blocks until the clock has been advanced by at least d.
+func (c *Clock) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	<-c.After(d)
+}
+
+// After returns a channel on which the time is sent once the clock
+// has been advanced by at least d.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).Chan()
+}
+
+// NewTimer returns a timer that fires once the clock has been
+// advanced by at least d. If d is not positive it fires at once.
+func (c *Clock) NewTimer(d time.Duration) time.ClockTimer {
+	t := &timer{c: c, ch: make(chan time.Time, 1)}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.add(t, d)
+	return t
+}
+
+// NewTicker returns a ticker that fires each time the clock has
+// been advanced by another d. It panics if d is not positive.
+func (c *Clock) NewTicker(d time.Duration) time.ClockTicker {
+	if d <= 0 {
+		panic("clocktest: non-positive interval for NewTicker")
+	}
+	t := &timer{c: c, ch: make(chan time.Time, 1), period: d}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.add(t, d)
+	return (*ticker)(t)
+}
+
+// A timer is a pending timer or ticker of a Clock.
+type timer struct {
+	c      *Clock
+	ch     chan time.Time
+	when   time.Time
+	period time.Duration // zero for a timer
+	seq    uint64        // order of setting, for ties
+	active bool
+}
+
+// add sets t to fire d from now. c.mu must be held.
+func (c *Clock) add(t *timer, d time.Duration) {
+	t.when = c.now.Add(d)
+	t.seq = c.nextSeq
+	c.nextSeq++
+	if t.period == 0 && d <= 0 {
+		c.send(t)
+		return
+	}
+	t.active = true
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+}
+
+// remove unsets t and reports whether it was pending. c.mu must be held.
+func (c *Clock) remove(t *timer) bool {
+	if !t.active {
+		return false
+	}
+	t.active = false
+	for i, u := range c.timers {
+		if u == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// earliest returns the next timer to fire, or nil. c.mu must be held.
+func (c *Clock) earliest() *timer {
+	var min *timer
+	for _, t := range c.timers {
+		if min == nil || t.when.Before(min.when) || t.when.Equal(min.when) && t.seq < min.seq {
+			min = t
+		}
+	}
+	return min
+}
+
+// fire fires t, rescheduling it if it is a ticker. c.mu must be held.
+func (c *Clock) fire(t *timer) {
+	c.send(t)
+	if t.period == 0 {
+		c.remove(t)
+		return
+	}
+	t.when = t.when.Add(t.period)
+	t.seq = c.nextSeq
+	c.nextSeq++
+}
+
+// send sends the current time on t's channel without blocking,
+// dropping it if the last one has not been received, as the timers
+// of package time do.
+func (c *Clock) send(t *timer) {
+	select {
+	case t.ch <- c.time():
+	default:
+	}
+}
+
+func (t *timer) Chan() <-chan time.Time { return t.ch }
+
+func (t *timer) Stop() bool {
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+	return t.c.remove(t)
+}
+
+func (t *timer) Reset(d time.Duration) bool {
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+	active := t.c.remove(t)
+	t.c.add(t, d)
+	return active
+}
+
+// A ticker is a timer with a period, with the methods of a time.ClockTicker.
+type ticker timer
+
+func (t *ticker) Chan() <-chan time.Time { return t.ch }
+
+func (t *ticker) Stop() { (*timer)(t).Stop() }
+
+func (t *ticker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("clocktest: non-positive interval for Ticker.Reset")
+	}
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+	t.c.remove((*timer)(t))
+	t.period = d
+	t.c.add((*timer)(t), d)
+}
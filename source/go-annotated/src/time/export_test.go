@@ -0,0 +1,51 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// CheckLocation returns a description of the first internal
+// inconsistency of l, or "" if there is none.
+func CheckLocation(l *Location) string {
+	l = l.get()
+	txs := l.transitions()
+	if len(txs) == 0 {
+		return "Location without transitions"
+	}
+	for i, tx := range txs {
+		if int(tx.index) >= len(l.zone) {
+			return "transition refers to missing zone"
+		}
+		if i > 0 && tx.when < txs[i-1].when {
+			// Accepted, but lookup's search assumes order; nothing
+			// below holds.
+			return ""
+		}
+	}
+	if c := l.loadCache(); c != nil && c.now.start > c.now.end {
+		return "inverted cache range"
+	}
+	// Every transition must be found by lookup, except the last one
+	// when the extend string takes over from it.
+	for i, tx := range txs {
+		if tx.when == alpha || i+1 < len(txs) && txs[i+1].when == tx.when {
+			continue
+		}
+		if i == len(txs)-1 && l.extend != "" {
+			break
+		}
+		name, offset, isDST, start, end := l.lookup(tx.when)
+		z := &l.zone[tx.index]
+		if name != z.name || offset != z.offset || isDST != z.isDST {
+			return "lookup disagrees with transition table"
+		}
+		if start > tx.when || tx.when >= end {
+			return "lookup range does not contain the transition"
+		}
+	}
+	return ""
+}
+
+var LoadTzinfoFromZip = loadTzinfoFromZip
+
+var DebugLookup = debugLookup
@@ -499,6 +499,15 @@ func (t Time) Format(layout string) string {
 
 // 使用 abs时间 转换为日期 （根据当前 Time 的 Locaiotn 转换为当地时间）
 func (t Time) AppendFormat(b []byte, layout string) []byte {
+	if fb, ok := t.appendFormatFast(b, layout); ok {
+		return fb
+	}
+	return t.appendFormat(b, layout, nil)
+}
+
+// appendFormat implements AppendFormat, taking month and weekday
+// names from lc. A nil lc means English.
+func (t Time) appendFormat(b []byte, layout string, lc *Locale) []byte {
 	var (
 		name, offset, abs = t.locabs()
 
@@ -540,19 +549,17 @@ func (t Time) AppendFormat(b []byte, layout string) []byte {
 		case stdLongYear:
 			b = appendInt(b, year, 4)
 		case stdMonth:
-			b = append(b, month.String()[:3]...)
+			b = append(b, lc.shortMonth(month)...)
 		case stdLongMonth:
-			m := month.String()
-			b = append(b, m...)
+			b = append(b, lc.longMonth(month)...)
 		case stdNumMonth:
 			b = appendInt(b, int(month), 0)
 		case stdZeroMonth:
 			b = appendInt(b, int(month), 2)
 		case stdWeekDay:
-			b = append(b, absWeekday(abs).String()[:3]...)
+			b = append(b, lc.shortDay(absWeekday(abs))...)
 		case stdLongWeekDay:
-			s := absWeekday(abs).String()
-			b = append(b, s...)
+			b = append(b, lc.longDay(absWeekday(abs))...)
 		case stdDay:
 			b = appendInt(b, day, 0)
 		case stdUnderDay:
@@ -588,15 +595,15 @@ func (t Time) AppendFormat(b []byte, layout string) []byte {
 			b = appendInt(b, sec, 2)
 		case stdPM:
 			if hour >= 12 {
-				b = append(b, "PM"...)
+				b = append(b, lc.pm("PM")...)
 			} else {
-				b = append(b, "AM"...)
+				b = append(b, lc.am("AM")...)
 			}
 		case stdpm:
 			if hour >= 12 {
-				b = append(b, "pm"...)
+				b = append(b, lc.pm("pm")...)
 			} else {
-				b = append(b, "am"...)
+				b = append(b, lc.am("am")...)
 			}
 		case stdISO8601TZ, stdISO8601ColonTZ, stdISO8601SecondsTZ, stdISO8601ShortTZ, stdISO8601ColonSecondsTZ, stdNumTZ, stdNumColonTZ, stdNumSecondsTz, stdNumShortTZ, stdNumColonSecondsTZ:
 			// Ugly special case. We cheat and take the "Z" variants
@@ -662,6 +669,11 @@ type ParseError struct {
 	LayoutElem string
 	ValueElem  string
 	Message    string
+
+	// Offset is the byte offset in Value at which LayoutElem was
+	// being matched, or -1 if the problem is not at one place, as
+	// for a day that does not exist in its month.
+	Offset int
 }
 
 func quote(s string) string {
@@ -681,6 +693,70 @@ func (e *ParseError) Error() string {
 		quote(e.Value) + e.Message
 }
 
+// Explain describes the problem for the user who typed Value, without
+// repeating it, as in
+//	unexpected "T" at position 10, expected " "
+//	unexpected end of text at position 8, expected day "02"
+//	month out of range at position 5
+// Positions are byte offsets from 0.
+// 给最终用户看的错误说明：在哪个位置遇到了什么，期望的是什么
+func (e *ParseError) Explain() string {
+	at := ""
+	if e.Offset >= 0 && e.Offset <= len(e.Value) {
+		at = " at position " + string(appendInt(nil, e.Offset, 0))
+	}
+	if e.Message != "" {
+		msg := e.Message
+		if len(msg) >= 2 && msg[:2] == ": " {
+			msg = msg[2:]
+		}
+		return msg + at
+	}
+	found := "end of text"
+	if e.Offset >= 0 && e.Offset < len(e.Value) {
+		for _, r := range e.Value[e.Offset:] {
+			found = quote(string(r))
+			break
+		}
+	}
+	return "unexpected " + found + at + ", expected " + layoutElemName(e.LayoutElem)
+}
+
+// layoutElemName describes a LayoutElem of a ParseError: the kind of
+// value for a standard element such as "2006", else the text itself.
+func layoutElemName(elem string) string {
+	prefix, std, suffix := nextStdChunk(elem)
+	if std == 0 || prefix != "" || suffix != "" {
+		return quote(elem)
+	}
+	var kind string
+	switch std & stdMask {
+	case stdLongYear, stdYear:
+		kind = "year"
+	case stdLongMonth, stdMonth, stdNumMonth, stdZeroMonth:
+		kind = "month"
+	case stdLongWeekDay, stdWeekDay:
+		kind = "day of the week"
+	case stdDay, stdUnderDay, stdZeroDay:
+		kind = "day"
+	case stdHour, stdHour12, stdZeroHour12:
+		kind = "hour"
+	case stdMinute, stdZeroMinute:
+		kind = "minute"
+	case stdSecond, stdZeroSecond:
+		kind = "second"
+	case stdPM, stdpm:
+		kind = "AM or PM"
+	case stdTZ:
+		kind = "time zone"
+	case stdFracSecond0, stdFracSecond9:
+		kind = "fraction of a second"
+	default:
+		kind = "zone offset"
+	}
+	return kind + " " + quote(elem)
+}
+
 // isDigit reports whether s[i] is in range and is a decimal digit.
 func isDigit(s string, i int) bool {
 	if len(s) <= i {
@@ -756,7 +832,8 @@ func skip(value, prefix string) (string, error) {
 // Years must be in the range 0000..9999. The day of the week is checked
 // for syntax but it is otherwise ignored.
 //
-// In the absence of a time zone indicator, Parse returns a time in UTC.
+// In the absence of a time zone indicator, Parse returns a time in UTC,
+// or in the Location set by SetDefaultLocation.
 //
 // When parsing a time with a zone offset like -0700, if the offset corresponds
 // to a time zone used by the current location (Local), then Parse uses that
@@ -765,7 +842,8 @@ func skip(value, prefix string) (string, error) {
 //
 // When parsing a time with a zone abbreviation like MST, if the zone abbreviation
 // has a defined offset in the current location, then that offset is used.
-// The zone abbreviation "UTC" is recognized as UTC regardless of location.
+// The zone abbreviation "UTC" is recognized as UTC regardless of location,
+// and a single letter is taken as a military time zone, as MilitaryZone does.
 // If the zone abbreviation is unknown, Parse records the time as being
 // in a fabricated location with the given zone abbreviation and a zero offset.
 // This choice means that such a time can be parsed and reformatted with the
@@ -773,7 +851,14 @@ func skip(value, prefix string) (string, error) {
 // differ by the actual zone offset. To avoid such problems, prefer time layouts
 // that use a numeric zone offset, or use ParseInLocation.
 func Parse(layout, value string) (Time, error) {
-	return parse(layout, value, UTC, Local)
+	return parse(layout, value, DefaultLocation(), localZones(), nil, false)
+}
+
+// localZones returns the zone list against which Parse resolves
+// zones. It is built on each call, as a program may set Local after
+// the package is initialized.
+func localZones() []*Location {
+	return []*Location{Local}
 }
 
 // ParseInLocation is like Parse but differs in two important ways.
@@ -782,11 +867,53 @@ func Parse(layout, value string) (Time, error) {
 // Second, when given a zone offset or abbreviation, Parse tries to match it
 // against the Local location; ParseInLocation uses the given location.
 func ParseInLocation(layout, value string, loc *Location) (Time, error) {
-	return parse(layout, value, loc, loc)
+	return parse(layout, value, loc, []*Location{loc}, nil, false)
+}
+
+// ParseInZones is like ParseInLocation, but matches a zone abbreviation
+// or offset in value against each of zones, rather than against loc
+// alone. Abbreviations are ambiguous: "IST" is used in India, Ireland
+// and Israel, and "EST" in America and, historically, Australia. Given
+// the zones the application deals with, ParseInZones gives the time the
+// first of them that used the abbreviation at that time, or failing
+// that, the first that ever used it. An offset is matched as for Parse,
+// with the first zone that had it at that time.
+// 用调用方给出的一组时区解析时区缩写，避免 "EST"、"IST" 解析错误
+//
+// As with ParseInLocation, a time without zone information is in loc,
+// and an unknown abbreviation gives a fabricated location with a zero
+// offset.
+func ParseInZones(layout, value string, loc *Location, zones ...*Location) (Time, error) {
+	return parse(layout, value, loc, zones, nil, false)
+}
+
+// ParseStrict is like Parse, but rejects values that Parse accepts by
+// adjusting them. Parse already rejects fields out of range, such as
+// "2023-02-30" or "25:00"; ParseStrict also rejects
+//	- a day of the week that does not match the date, as in
+//	  "Mon, 02 Jan 2024", a Tuesday, which Parse ignores;
+//	- hour 0 with a 12-hour clock, as in "0:30PM", which Parse
+//	  takes as 12:30PM;
+//	- a time that does not exist on the clocks of its zone, such as
+//	  a time skipped when daylight saving time starts, or one given
+//	  with an abbreviation of the zone not in effect at that time,
+//	  as "10:00 EST" in New York in July, which Parse moves to another
+//	  time that does exist.
+// 严格解析：拒绝 Parse 会悄悄调整的输入
+func ParseStrict(layout, value string) (Time, error) {
+	return parse(layout, value, DefaultLocation(), localZones(), nil, true)
+}
+
+// ParseInLocationStrict is like ParseInLocation, but rejects values
+// as ParseStrict does.
+func ParseInLocationStrict(layout, value string, loc *Location) (Time, error) {
+	return parse(layout, value, loc, []*Location{loc}, nil, true)
 }
 
 // 解析字符串 提取出 字符串中 时间 时区（FixTimezone） 使用 Date 创建 Time 类型
-func parse(layout, value string, defaultLocation, local *Location) (Time, error) {
+// lc 为 nil 时使用英文的月份和星期名称
+// strict 为 true 时拒绝需要调整的输入，见 ParseStrict
+func parse(layout, value string, defaultLocation *Location, zones []*Location, lc *Locale, strict bool) (Time, error) {
 	alayout, avalue := layout, value
 	rangeErrString := "" // set if a value is out of range
 	amSet := false       // do we need to subtract 12 from the hour for midnight?
@@ -804,6 +931,7 @@ func parse(layout, value string, defaultLocation, local *Location) (Time, error)
 		z          *Location
 		zoneOffset int = -1
 		zoneName   string
+		wday       int = -1
 	)
 
 	// Each iteration processes one std value.
@@ -813,15 +941,16 @@ func parse(layout, value string, defaultLocation, local *Location) (Time, error)
 		stdstr := layout[len(prefix) : len(layout)-len(suffix)]
 		value, err = skip(value, prefix)
 		if err != nil {
-			return Time{}, &ParseError{alayout, avalue, prefix, value, ""}
+			return Time{}, &ParseError{alayout, avalue, prefix, value, "", len(avalue) - len(value)}
 		}
 		if std == 0 {
 			if len(value) != 0 {
-				return Time{}, &ParseError{alayout, avalue, "", value, ": extra text: " + value}
+				return Time{}, &ParseError{alayout, avalue, "", value, ": extra text: " + value, len(avalue) - len(value)}
 			}
 			break
 		}
 		layout = suffix
+		offset := len(avalue) - len(value)
 		var p string
 		switch std & stdMask {
 		case stdYear:
@@ -844,10 +973,10 @@ func parse(layout, value string, defaultLocation, local *Location) (Time, error)
 			p, value = value[0:4], value[4:]
 			year, err = atoi(p)
 		case stdMonth:
-			month, value, err = lookup(shortMonthNames, value)
+			month, value, err = lc.lookup(lc.shortMonthNames(), value)
 			month++
 		case stdLongMonth:
-			month, value, err = lookup(longMonthNames, value)
+			month, value, err = lc.lookup(lc.longMonthNames(), value)
 			month++
 		case stdNumMonth, stdZeroMonth:
 			month, value, err = getnum(value, std == stdZeroMonth)
@@ -855,10 +984,10 @@ func parse(layout, value string, defaultLocation, local *Location) (Time, error)
 				rangeErrString = "month"
 			}
 		case stdWeekDay:
-			// Ignore weekday except for error checking.
-			_, value, err = lookup(shortDayNames, value)
+			// Parse checks the weekday only in strict mode.
+			wday, value, err = lc.lookup(lc.shortDayNames(), value)
 		case stdLongWeekDay:
-			_, value, err = lookup(longDayNames, value)
+			wday, value, err = lc.lookup(lc.longDayNames(), value)
 		case stdDay, stdUnderDay, stdZeroDay:
 			if std == stdUnderDay && len(value) > 0 && value[0] == ' ' {
 				value = value[1:]
@@ -875,7 +1004,7 @@ func parse(layout, value string, defaultLocation, local *Location) (Time, error)
 			}
 		case stdHour12, stdZeroHour12:
 			hour, value, err = getnum(value, std == stdZeroHour12)
-			if hour < 0 || 12 < hour {
+			if hour < 0 || 12 < hour || strict && hour == 0 {
 				rangeErrString = "hour"
 			}
 		case stdMinute, stdZeroMinute:
@@ -885,6 +1014,9 @@ func parse(layout, value string, defaultLocation, local *Location) (Time, error)
 			}
 		case stdSecond, stdZeroSecond:
 			sec, value, err = getnum(value, std == stdZeroSecond)
+			if err != nil {
+				break
+			}
 			if sec < 0 || 60 <= sec {
 				rangeErrString = "second"
 				break
@@ -906,6 +1038,10 @@ func parse(layout, value string, defaultLocation, local *Location) (Time, error)
 				value = value[n:]
 			}
 		case stdPM:
+			if lc != nil && (lc.AM != "" || lc.PM != "") {
+				pmSet, amSet, value, err = lc.lookupAMPM(value)
+				break
+			}
 			if len(value) < 2 {
 				err = errBad
 				break
@@ -920,6 +1056,10 @@ func parse(layout, value string, defaultLocation, local *Location) (Time, error)
 				err = errBad
 			}
 		case stdpm:
+			if lc != nil && (lc.AM != "" || lc.PM != "") {
+				pmSet, amSet, value, err = lc.lookupAMPM(value)
+				break
+			}
 			if len(value) < 2 {
 				err = errBad
 				break
@@ -934,7 +1074,8 @@ func parse(layout, value string, defaultLocation, local *Location) (Time, error)
 				err = errBad
 			}
 		case stdISO8601TZ, stdISO8601ColonTZ, stdISO8601SecondsTZ, stdISO8601ShortTZ, stdISO8601ColonSecondsTZ, stdNumTZ, stdNumShortTZ, stdNumColonTZ, stdNumSecondsTz, stdNumColonSecondsTZ:
-			if (std == stdISO8601TZ || std == stdISO8601ShortTZ || std == stdISO8601ColonTZ) && len(value) >= 1 && value[0] == 'Z' {
+			if (std == stdISO8601TZ || std == stdISO8601ShortTZ || std == stdISO8601ColonTZ ||
+				std == stdISO8601SecondsTZ || std == stdISO8601ColonSecondsTZ) && len(value) >= 1 && value[0] == 'Z' {
 				value = value[1:]
 				z = UTC
 				break
@@ -980,13 +1121,27 @@ func parse(layout, value string, defaultLocation, local *Location) (Time, error)
 				sign, hour, min, seconds, value = value[0:1], value[1:3], value[3:5], "00", value[5:]
 			}
 			var hr, mm, ss int
-			hr, err = atoi(hour)
+			hr, _, err = getnum(hour, true)
 			if err == nil {
-				mm, err = atoi(min)
+				mm, _, err = getnum(min, true)
 			}
 			if err == nil {
-				ss, err = atoi(seconds)
+				ss, _, err = getnum(seconds, true)
 			}
+
+			// The range test use > rather than >=,
+			// as some people do write offsets of 24 hours
+			// or 60 minutes or 60 seconds.
+			if hr > 24 {
+				rangeErrString = "time zone offset hour"
+			}
+			if mm > 60 {
+				rangeErrString = "time zone offset minute"
+			}
+			if ss > 60 {
+				rangeErrString = "time zone offset second"
+			}
+
 			zoneOffset = (hr*60+mm)*60 + ss // offset is in seconds
 			switch sign[0] {
 			case '+':
@@ -1002,6 +1157,11 @@ func parse(layout, value string, defaultLocation, local *Location) (Time, error)
 				value = value[3:]
 				break
 			}
+			if loc, ok := parseMilitaryZone(value); ok {
+				z = loc
+				value = value[1:]
+				break
+			}
 			n, ok := parseTimeZone(value)
 			if !ok {
 				err = errBad
@@ -1035,10 +1195,10 @@ func parse(layout, value string, defaultLocation, local *Location) (Time, error)
 			value = value[1+i:]
 		}
 		if rangeErrString != "" {
-			return Time{}, &ParseError{alayout, avalue, stdstr, value, ": " + rangeErrString + " out of range"}
+			return Time{}, &ParseError{alayout, avalue, stdstr, value, ": " + rangeErrString + " out of range", offset}
 		}
 		if err != nil {
-			return Time{}, &ParseError{alayout, avalue, stdstr, value, ""}
+			return Time{}, &ParseError{alayout, avalue, stdstr, value, "", offset}
 		}
 	}
 	if pmSet && hour < 12 {
@@ -1049,11 +1209,31 @@ func parse(layout, value string, defaultLocation, local *Location) (Time, error)
 
 	// Validate the day of the month.
 	if day < 1 || day > daysIn(Month(month), year) {
-		return Time{}, &ParseError{alayout, avalue, "", value, ": day out of range"}
+		return Time{}, &ParseError{alayout, avalue, "", value, ": day out of range", -1}
+	}
+
+	t := timeFromFields(year, month, day, hour, min, sec, nsec, z, zoneOffset, zoneName, defaultLocation, zones)
+	if strict {
+		if wday >= 0 && Weekday(wday) != Date(year, Month(month), day, 0, 0, 0, 0, UTC).Weekday() {
+			return Time{}, &ParseError{alayout, avalue, "", value, ": day of the week does not match date", -1}
+		}
+		y, m, d := t.Date()
+		hh, mm, ss := t.Clock()
+		if y != year || int(m) != month || d != day || hh != hour || mm != min || ss != sec {
+			return Time{}, &ParseError{alayout, avalue, "", value, ": time does not exist in " + t.Location().String(), -1}
+		}
 	}
+	return t, nil
+}
 
+// timeFromFields returns the Time for the parsed fields. The zone is
+// z if not nil, else zoneOffset if not -1, else zoneName if not "",
+// each resolved against zones as documented for Parse; without any
+// of them the time is in defaultLocation.
+// 根据解析出的字段和时区信息构造 Time，供 parse 和 Strptime 共用
+func timeFromFields(year, month, day, hour, min, sec, nsec int, z *Location, zoneOffset int, zoneName string, defaultLocation *Location, zones []*Location) Time {
 	if z != nil {
-		return Date(year, Month(month), day, hour, min, sec, nsec, z), nil
+		return Date(year, Month(month), day, hour, min, sec, nsec, z)
 	}
 
 	if zoneOffset != -1 {
@@ -1062,39 +1242,45 @@ func parse(layout, value string, defaultLocation, local *Location) (Time, error)
 
 		// Look for local zone with the given offset.
 		// If that zone was in effect at the given time, use it.
-		name, offset, _, _, _ := local.lookup(t.unixSec())
-		if offset == zoneOffset && (zoneName == "" || name == zoneName) {
-			t.setLoc(local)
-			return t, nil
+		for _, local := range zones {
+			name, offset, _, _, _ := local.lookup(t.unixSec())
+			if offset == zoneOffset && (zoneName == "" || name == zoneName) {
+				t.setLoc(local)
+				return t
+			}
 		}
 
 		// Otherwise create fake zone to record offset.
 		t.setLoc(FixedZone(zoneName, zoneOffset))
-		return t, nil
+		return t
 	}
 
 	if zoneName != "" {
 		t := Date(year, Month(month), day, hour, min, sec, nsec, UTC)
-		// Look for local zone with the given offset.
-		// If that zone was in effect at the given time, use it.
-		offset, ok := local.lookupName(zoneName, t.unixSec())
-		if ok {
-			t.addSec(-int64(offset))
-			t.setLoc(local)
-			return t, nil
+		// Look for local zone with the given name.
+		// Prefer one in which it was in effect at the given time.
+		for _, inEffect := range [...]bool{true, false} {
+			for _, local := range zones {
+				if offset, ok := local.lookupName(zoneName, t.unixSec(), inEffect); ok {
+					t.addSec(-int64(offset))
+					t.setLoc(local)
+					return t
+				}
+			}
 		}
 
 		// Otherwise, create fake zone with unknown offset.
+		var offset int
 		if len(zoneName) > 3 && zoneName[:3] == "GMT" {
 			offset, _ = atoi(zoneName[3:]) // Guaranteed OK by parseGMT.
 			offset *= 3600
 		}
 		t.setLoc(FixedZone(zoneName, offset))
-		return t, nil
+		return t
 	}
 
 	// Otherwise, fall back to default.
-	return Date(year, Month(month), day, hour, min, sec, nsec, defaultLocation), nil
+	return Date(year, Month(month), day, hour, min, sec, nsec, defaultLocation)
 }
 
 // parseTimeZone parses a time zone string and returns its length. Time zones
@@ -0,0 +1,46 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// defaultLoc is the *Location set by SetDefaultLocation, or nil for
+// UTC. It is accessed atomically.
+var defaultLoc unsafe.Pointer
+
+// SetDefaultLocation sets the Location in which Parse, ParseStrict,
+// Locale.Parse and Strptime place values that have no zone, in place
+// of UTC, and in which FormatDefault renders times. It lets a server
+// keep Local, and its logs, in UTC while reading and showing times
+// in the zone its users are in. SetDefaultLocation(nil) restores UTC.
+// 设置默认的解析、显示时区，与 Local 无关
+//
+// It is safe to call at any time and from any goroutine, but a
+// program would usually call it once, at startup. Abbreviations and
+// offsets in values are still resolved against Local, as before.
+func SetDefaultLocation(loc *Location) {
+	if loc == UTC {
+		loc = nil
+	}
+	atomic.StorePointer(&defaultLoc, unsafe.Pointer(loc))
+}
+
+// DefaultLocation returns the Location set by SetDefaultLocation,
+// UTC if none.
+func DefaultLocation() *Location {
+	if p := atomic.LoadPointer(&defaultLoc); p != nil {
+		return (*Location)(p)
+	}
+	return UTC
+}
+
+// FormatDefault is like Format, but formats t in the Location set by
+// SetDefaultLocation: it is t.In(DefaultLocation()).Format(layout).
+func (t Time) FormatDefault(layout string) string {
+	return t.In(DefaultLocation()).Format(layout)
+}
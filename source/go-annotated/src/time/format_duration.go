@@ -0,0 +1,159 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// A DurationFormat describes how to write a Duration for people to
+// read, as in "2 days 3 hours" or, in compact form, "2d3h", rather
+// than the "51h23m9.456s" of Duration.String.
+// 面向用户的时长格式，例如 "2 days 3 hours" 或 "2d3h"
+//
+// The zero DurationFormat writes the long form using days, hours,
+// minutes and seconds, rounded to the nearest second. A day is
+// always 24 hours here, whatever the daylight saving time rules.
+type DurationFormat struct {
+	// Largest and Smallest are the largest and smallest units used,
+	// one of 24*Hour, Hour, Minute, Second, Millisecond, Microsecond
+	// and Nanosecond. Zero means 24*Hour and Second.
+	Largest, Smallest Duration
+
+	// MaxUnits is the number of units written at most, counting from
+	// the largest non-zero one: with MaxUnits 2, 50h23m9s is written
+	// as "2 days 2 hours". Zero means no limit.
+	MaxUnits int
+
+	// Compact selects the short form, "2d2h", instead of the long
+	// form, "2 days 2 hours".
+	Compact bool
+
+	// Truncate makes the value be rounded toward zero to the smallest
+	// unit written, instead of to the nearest.
+	Truncate bool
+}
+
+// durationUnits lists the units of a DurationFormat, largest first.
+var durationUnits = [...]struct {
+	d           Duration
+	short, long string
+}{
+	{24 * Hour, "d", "day"},
+	{Hour, "h", "hour"},
+	{Minute, "m", "minute"},
+	{Second, "s", "second"},
+	{Millisecond, "ms", "millisecond"},
+	{Microsecond, "µs", "microsecond"},
+	{Nanosecond, "ns", "nanosecond"},
+}
+
+// unitIndex returns the index in durationUnits of unit d,
+// or def if d is zero or not a unit.
+func unitIndex(d Duration, def int) int {
+	for i, u := range durationUnits {
+		if u.d == d {
+			return i
+		}
+	}
+	return def
+}
+
+// Format returns d formatted as described by f, such as
+// "2 days 3 hours" or "2d3h". Units whose count is zero are left
+// out; a duration that rounds to zero is written in the smallest
+// unit, as "0 seconds" or "0s". Negative durations start with '-'.
+func (f DurationFormat) Format(d Duration) string {
+	return string(f.AppendFormat(nil, d))
+}
+
+// AppendFormat is like Format but appends the result to b
+// and returns the extended buffer.
+func (f DurationFormat) AppendFormat(b []byte, d Duration) []byte {
+	largest := unitIndex(f.Largest, 0)
+	smallest := unitIndex(f.Smallest, 3)
+	if smallest < largest {
+		smallest = largest
+	}
+
+	// Work on the magnitude, which fits in a uint64 even for the
+	// minimum Duration.
+	neg := d < 0
+	u := uint64(d)
+	if neg {
+		u = -u
+	}
+
+	// The smallest unit written is the finer of Smallest and the
+	// last of MaxUnits from the largest non-zero unit. Rounding may
+	// carry into a larger unit, as 59.6s to 1m; the second pass
+	// takes that into account.
+	last := smallest
+	for pass := 0; pass < 2; pass++ {
+		if f.MaxUnits > 0 {
+			first := largest
+			for first < smallest && u < uint64(durationUnits[first].d) {
+				first++
+			}
+			if l := first + f.MaxUnits - 1; l < last {
+				last = l
+			}
+		}
+		m := uint64(durationUnits[last].d)
+		if r := u % m; f.Truncate || r+r < m {
+			u -= r
+		} else {
+			u += m - r
+		}
+	}
+
+	if neg && u != 0 {
+		b = append(b, '-')
+	}
+	start := len(b)
+	for i := largest; i <= last; i++ {
+		m := uint64(durationUnits[i].d)
+		n := u
+		if i > largest {
+			n %= uint64(durationUnits[i-1].d)
+		}
+		n /= m
+		if n == 0 && !(i == last && len(b) == start) {
+			continue
+		}
+		b = appendDurationUnit(b, n, i, f.Compact, len(b) > start)
+	}
+	return b
+}
+
+// appendDurationUnit appends n of unit durationUnits[i], such as
+// "3h" or "3 hours", preceded by a space in the long form if sep.
+func appendDurationUnit(b []byte, n uint64, i int, compact, sep bool) []byte {
+	unit := durationUnits[i]
+	if compact {
+		b = appendUint64(b, n)
+		return append(b, unit.short...)
+	}
+	if sep {
+		b = append(b, ' ')
+	}
+	b = appendUint64(b, n)
+	b = append(b, ' ')
+	b = append(b, unit.long...)
+	if n != 1 {
+		b = append(b, 's')
+	}
+	return b
+}
+
+// appendUint64 appends the decimal form of x to b.
+func appendUint64(b []byte, x uint64) []byte {
+	var buf [20]byte
+	i := len(buf)
+	for x >= 10 {
+		i--
+		buf[i] = byte('0' + x%10)
+		x /= 10
+	}
+	i--
+	buf[i] = byte('0' + x)
+	return append(b, buf[i:]...)
+}
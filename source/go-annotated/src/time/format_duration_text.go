@@ -0,0 +1,81 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "errors"
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The output is that of String, such as "1h30m0s".
+// 以 "1h30m0s" 这样的文本编码 Duration，而不是纳秒整数
+func (d Duration) MarshalText() ([]byte, error) {
+	return d.AppendText(nil)
+}
+
+// AppendText appends the encoding of MarshalText to b and returns the
+// extended buffer. Unlike String, it does not allocate if b has room.
+func (d Duration) AppendText(b []byte) ([]byte, error) {
+	var buf [32]byte
+	w := d.format(&buf)
+	return append(b, buf[w:]...), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The input is parsed with ParseDuration, so that "90m" and "1.5h"
+// are accepted as well as what MarshalText writes.
+func (d *Duration) UnmarshalText(data []byte) error {
+	v, err := ParseDuration(string(data))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The Duration is encoded as a quoted string, as by MarshalText.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 0, 34)
+	b = append(b, '"')
+	b, _ = d.AppendText(b)
+	return append(b, '"'), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The Duration is expected to be a quoted string accepted by
+// ParseDuration, or, as encoding/json wrote Durations before
+// MarshalJSON, an integer number of nanoseconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	// Ignore null, like in the main JSON package.
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		s, ok := unquote(data)
+		if !ok {
+			return errors.New("Duration.UnmarshalJSON: input is not a JSON string")
+		}
+		v, err := ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		*d = v
+		return nil
+	}
+
+	s := string(data)
+	neg := s != "" && s[0] == '-'
+	if neg {
+		s = s[1:]
+	}
+	v, rem, err := leadingInt(s)
+	if err != nil || s == "" || rem != "" {
+		return errors.New("Duration.UnmarshalJSON: input is not a JSON string or integer")
+	}
+	if neg {
+		v = -v
+	}
+	*d = Duration(v)
+	return nil
+}
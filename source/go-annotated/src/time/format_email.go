@@ -0,0 +1,220 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// Dates of email headers, as defined by RFC 5322 section 3.3 and
+// before it RFC 2822 and RFC 822. Real headers often stray from the
+// RFC1123 and RFC1123Z layouts: they omit the day of the week or the
+// seconds, carry comments such as "(PST)", or use the obsolete zone
+// names and two-digit years that the RFCs still require readers to
+// accept.
+// 邮件 Date 头的宽松解析
+
+// emailZones holds the obsolete zone names of RFC 5322 section 4.3.
+var emailZones = []struct {
+	name   string
+	offset int // hours east of UTC
+}{
+	{"UT", 0}, {"GMT", 0}, {"UTC", 0}, {"Z", 0},
+	{"EST", -5}, {"EDT", -4},
+	{"CST", -6}, {"CDT", -5},
+	{"MST", -7}, {"MDT", -6},
+	{"PST", -8}, {"PDT", -7},
+}
+
+// ParseEmailDate parses the date of an email Date header, such as
+//	Mon, 2 Jan 2006 15:04:05 -0700
+// It accepts what RFC 5322 requires of readers, including the obsolete
+// syntax, and common deviations from it:
+//	- comments in parentheses, and folding white space, anywhere;
+//	- no day of the week, or one that does not match the date, which
+//	  is ignored, and full day and month names in any case;
+//	- no seconds, and one-digit days and hours;
+//	- two-digit years, 50 and above in the 1900s, and three-digit
+//	  years, counted from 1900;
+//	- the zone names UT, GMT, EST, EDT, CST, CDT, MST, MDT, PST and
+//	  PDT, and UTC; other letters, like the military zones, and a
+//	  missing zone mean an unknown zone and give UTC, as does -0000.
+// Times with a numeric zone other than zero are in a fixed Location
+// with no name; those with a zone name, in one with that name.
+func ParseEmailDate(value string) (Time, error) {
+	const layout = "RFC 5322 date"
+	s := value
+	perr := func(msg string) error {
+		return &ParseError{layout, value, "", s, msg, len(value) - len(s)}
+	}
+
+	s = skipCFWS(s)
+	if w, rest := emailWord(s); w != "" {
+		// Optional day of the week.
+		if _, r, err := lookup(longDayNames, s); err == nil && len(r) == len(rest) {
+			s = r
+		} else if _, r, err := lookup(shortDayNames, s); err == nil && len(r) == len(rest) {
+			s = r
+		} else {
+			return Time{}, perr(": unknown day of the week " + quote(w))
+		}
+		s = skipCFWS(s)
+		if s != "" && s[0] == ',' {
+			s = skipCFWS(s[1:])
+		}
+	}
+
+	day, rest, ok := emailNum(s, 1, 2)
+	if !ok {
+		return Time{}, perr(": missing day")
+	}
+	s = emailSep(rest)
+
+	w, rest := emailWord(s)
+	month := -1
+	if len(w) >= 3 {
+		for i, m := range longMonthNames {
+			if len(w) == len(m) && match(w, m) || len(w) == 3 && match(w, m[:3]) {
+				month = i + 1
+				break
+			}
+		}
+	}
+	if month < 0 {
+		return Time{}, perr(": unknown month")
+	}
+	s = rest
+	if s != "" && s[0] == '.' {
+		s = s[1:] // "Jan."
+	}
+	s = emailSep(s)
+
+	year, rest, ok := emailNum(s, 2, 4)
+	if !ok {
+		return Time{}, perr(": missing year")
+	}
+	switch n := len(s) - len(rest); {
+	case n == 2 && year < 50:
+		year += 2000
+	case n == 2 || n == 3:
+		year += 1900
+	}
+	s = skipCFWS(rest)
+
+	hour, rest, ok := emailNum(s, 1, 2)
+	if !ok || hour > 23 {
+		return Time{}, perr(": missing or invalid hour")
+	}
+	s = skipCFWS(rest)
+	if s == "" || s[0] != ':' {
+		return Time{}, perr(": missing minute")
+	}
+	s = skipCFWS(s[1:])
+	min, rest, ok := emailNum(s, 2, 2)
+	if !ok || min > 59 {
+		return Time{}, perr(": missing or invalid minute")
+	}
+	s = skipCFWS(rest)
+	sec := 0
+	if s != "" && s[0] == ':' {
+		s = skipCFWS(s[1:])
+		if sec, rest, ok = emailNum(s, 2, 2); !ok || sec > 60 {
+			return Time{}, perr(": missing or invalid second")
+		}
+		s = skipCFWS(rest)
+	}
+	if day < 1 || day > daysIn(Month(month), year) {
+		return Time{}, perr(": day out of range")
+	}
+
+	loc := UTC
+	switch {
+	case s == "":
+		// No zone: unknown.
+	case s[0] == '+' || s[0] == '-':
+		hhmm, rest, ok := emailNum(s[1:], 4, 4)
+		if !ok || hhmm%100 > 59 {
+			return Time{}, perr(": invalid zone offset")
+		}
+		if offset := (hhmm/100*60 + hhmm%100) * 60; offset != 0 {
+			if s[0] == '-' {
+				offset = -offset
+			}
+			loc = FixedZone("", offset)
+		}
+		s = rest
+	default:
+		w, rest := emailWord(s)
+		if w == "" {
+			return Time{}, perr(": invalid zone")
+		}
+		for _, z := range emailZones {
+			if len(w) == len(z.name) && match(w, z.name) {
+				if z.offset != 0 {
+					loc = FixedZone(z.name, z.offset*3600)
+				}
+				break
+			}
+		}
+		s = rest
+	}
+	if s = skipCFWS(s); s != "" {
+		return Time{}, perr(": extra text: " + s)
+	}
+
+	// A leap second, allowed by the RFCs, is taken as the next second.
+	return Date(year, Month(month), day, hour, min, sec, 0, loc), nil
+}
+
+// skipCFWS skips white space and comments, which may nest and contain
+// quoted characters, at the start of s. An unterminated comment runs
+// to the end of s.
+func skipCFWS(s string) string {
+	depth := 0
+	for s != "" {
+		switch c := s[0]; {
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case c == '\\' && depth > 0 && len(s) > 1:
+			s = s[1:]
+		case depth == 0 && c != ' ' && c != '\t' && c != '\r' && c != '\n':
+			return s
+		}
+		s = s[1:]
+	}
+	return s
+}
+
+// emailSep skips the white space, comments or dash between the parts
+// of a date, as in "2 Jan 2006" and the common "2-Jan-2006".
+func emailSep(s string) string {
+	s = skipCFWS(s)
+	if s != "" && s[0] == '-' {
+		s = skipCFWS(s[1:])
+	}
+	return s
+}
+
+// emailWord returns the letters at the start of s and the rest of s.
+func emailWord(s string) (word, rest string) {
+	i := 0
+	for i < len(s) && ('a' <= s[i] && s[i] <= 'z' || 'A' <= s[i] && s[i] <= 'Z') {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// emailNum returns the number made of the min to max digits at the
+// start of s, and the rest of s. ok is false if s starts with fewer
+// than min digits or more than max.
+func emailNum(s string, min, max int) (n int, rest string, ok bool) {
+	i := 0
+	for i < len(s) && isDigit(s, i) {
+		n = n*10 + int(s[i]-'0')
+		i++
+	}
+	if i < min || i > max {
+		return 0, s, false
+	}
+	return n, s[i:], true
+}
@@ -0,0 +1,164 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// Specialized formatters for the most used layouts. Log pipelines,
+// HTTP servers and key-value stores format timestamps with RFC3339,
+// RFC3339Nano, RFC1123, RFC1123Z and SortableNano far more often than
+// with anything else; for those, AppendFormat writes the fields
+// directly instead of interpreting the layout chunk by chunk. The
+// output is the same.
+// 常用布局的专用格式化，跳过通用的布局解释
+
+// appendFormatFast appends t formatted with layout to b, if layout
+// is one of those with a specialized formatter. ok reports whether
+// it was.
+func (t Time) appendFormatFast(b []byte, layout string) (_ []byte, ok bool) {
+	switch layout {
+	case RFC3339:
+		return t.appendRFC3339(b, false)
+	case RFC3339Nano:
+		return t.appendRFC3339(b, true)
+	case RFC1123:
+		return t.appendRFC1123(b, false)
+	case RFC1123Z:
+		return t.appendRFC1123(b, true)
+	case Kitchen:
+		return t.appendKitchen(b), true
+	case SortableNano:
+		if t.loc == nil || t.loc == &utcLoc {
+			return t.appendSortable(b)
+		}
+	}
+	return b, false
+}
+
+// AppendRFC3339 is like AppendFormat with the RFC3339 layout, for
+// encoders and loggers that write timestamps into their own buffers:
+// it does not allocate if b has room for the result.
+// 以 RFC3339 格式追加到调用方缓冲区，无内存分配
+func (t Time) AppendRFC3339(b []byte) []byte {
+	if b, ok := t.appendRFC3339(b, false); ok {
+		return b
+	}
+	return t.AppendFormat(b, RFC3339)
+}
+
+// AppendRFC3339Nano is like AppendRFC3339 with the RFC3339Nano layout.
+func (t Time) AppendRFC3339Nano(b []byte) []byte {
+	if b, ok := t.appendRFC3339(b, true); ok {
+		return b
+	}
+	return t.AppendFormat(b, RFC3339Nano)
+}
+
+// appendRFC3339 appends t in the RFC3339 layout, or RFC3339Nano if
+// nano is set. Years outside [0,9999] are left to appendFormat.
+func (t Time) appendRFC3339(b []byte, nano bool) ([]byte, bool) {
+	_, offset, abs := t.locabs()
+	year, month, day, _ := absDate(abs, true)
+	if year < 0 || year > 9999 {
+		return b, false
+	}
+	hour, min, sec := absClock(abs)
+
+	b = append4(b, year)
+	b = append(b, '-')
+	b = append2(b, int(month))
+	b = append(b, '-')
+	b = append2(b, day)
+	b = append(b, 'T')
+	b = append2(b, hour)
+	b = append(b, ':')
+	b = append2(b, min)
+	b = append(b, ':')
+	b = append2(b, sec)
+	if nano {
+		b = formatNano(b, uint(t.Nanosecond()), 9, true)
+	}
+
+	if offset == 0 {
+		return append(b, 'Z'), true
+	}
+	return appendOffset(b, offset, true), true
+}
+
+// appendRFC1123 appends t in the RFC1123 layout, or RFC1123Z if
+// numeric is set. Years outside [0,9999] are left to appendFormat.
+func (t Time) appendRFC1123(b []byte, numeric bool) ([]byte, bool) {
+	name, offset, abs := t.locabs()
+	year, month, day, _ := absDate(abs, true)
+	if year < 0 || year > 9999 {
+		return b, false
+	}
+	hour, min, sec := absClock(abs)
+
+	b = append(b, shortDayNames[absWeekday(abs)]...)
+	b = append(b, ',', ' ')
+	b = append2(b, day)
+	b = append(b, ' ')
+	b = append(b, shortMonthNames[month-1]...)
+	b = append(b, ' ')
+	b = append4(b, year)
+	b = append(b, ' ')
+	b = append2(b, hour)
+	b = append(b, ':')
+	b = append2(b, min)
+	b = append(b, ':')
+	b = append2(b, sec)
+	b = append(b, ' ')
+	if numeric || name == "" {
+		// Like stdTZ, print the -0700 form if the zone has no name.
+		return appendOffset(b, offset, false), true
+	}
+	return append(b, name...), true
+}
+
+// appendKitchen appends t in the Kitchen layout.
+func (t Time) appendKitchen(b []byte) []byte {
+	_, _, abs := t.locabs()
+	hour, min, _ := absClock(abs)
+	// Noon is 12PM, midnight is 12AM.
+	hr := hour % 12
+	if hr == 0 {
+		hr = 12
+	}
+	if hr >= 10 {
+		b = append(b, '1')
+	}
+	b = append(b, byte('0'+hr%10), ':')
+	b = append2(b, min)
+	if hour >= 12 {
+		return append(b, 'P', 'M')
+	}
+	return append(b, 'A', 'M')
+}
+
+// append2 appends x, in [0,99], as two digits.
+func append2(b []byte, x int) []byte {
+	return append(b, byte('0'+x/10), byte('0'+x%10))
+}
+
+// append4 appends x, in [0,9999], as four digits.
+func append4(b []byte, x int) []byte {
+	return append(b, byte('0'+x/1000), byte('0'+x/100%10), byte('0'+x/10%10), byte('0'+x%10))
+}
+
+// appendOffset appends the zone offset, in seconds east of UTC, as
+// -07:00 if colon is set and as -0700 otherwise. Seconds are dropped.
+func appendOffset(b []byte, offset int, colon bool) []byte {
+	zone := offset / 60 // convert to minutes
+	if zone < 0 {
+		b = append(b, '-')
+		zone = -zone
+	} else {
+		b = append(b, '+')
+	}
+	b = appendInt(b, zone/60, 2)
+	if colon {
+		b = append(b, ':')
+	}
+	return appendInt(b, zone%60, 2)
+}
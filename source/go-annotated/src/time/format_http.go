@@ -0,0 +1,51 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// The formats of dates in HTTP headers such as Date, Last-Modified and
+// Expires, from RFC 7231 section 7.1.1.1. Senders use only the first;
+// recipients accept all three.
+// HTTP 日期的三种格式，发送时只用第一种
+const (
+	httpIMFFixdate = "Mon, 02 Jan 2006 15:04:05 GMT"
+	httpRFC850     = "Monday, 02-Jan-06 15:04:05 GMT"
+	httpAsctime    = "Mon Jan _2 15:04:05 2006"
+)
+
+// FormatHTTPDate returns t, in UTC, in the IMF-fixdate format that
+// RFC 7231 requires of HTTP senders, such as
+//	Sun, 06 Nov 1994 08:49:37 GMT
+func FormatHTTPDate(t Time) string {
+	return t.UTC().Format(httpIMFFixdate)
+}
+
+// ParseHTTPDate parses an HTTP date in any of the three formats that
+// RFC 7231 requires recipients to accept:
+//	Sun, 06 Nov 1994 08:49:37 GMT  ; IMF-fixdate
+//	Sunday, 06-Nov-94 08:49:37 GMT ; obsolete RFC 850 format
+//	Sun Nov  6 08:49:37 1994       ; ANSI C's asctime() format
+// The zone must be GMT, which asctime dates leave out. As RFC 7231
+// asks, a two-digit year of the RFC 850 format is taken as the latest
+// year with those digits that is not more than 50 years in the future.
+// The result is in UTC.
+func ParseHTTPDate(value string) (Time, error) {
+	t, err := ParseInLocation(httpIMFFixdate, value, UTC)
+	if err == nil {
+		return t, nil
+	}
+	if t, err := ParseInLocation(httpRFC850, value, UTC); err == nil {
+		if year := Now().UTC().Year(); t.Year() > year+50 {
+			t = t.AddDate(-100, 0, 0)
+		} else if t.Year()+100 <= year+50 {
+			t = t.AddDate(100, 0, 0)
+		}
+		return t, nil
+	}
+	if t, err := ParseInLocation(httpAsctime, value, UTC); err == nil {
+		return t, nil
+	}
+	// Report how the value failed as the format senders use.
+	return Time{}, err
+}
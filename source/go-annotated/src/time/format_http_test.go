@@ -0,0 +1,32 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time_test
+
+import (
+	"testing"
+	. "time"
+)
+
+// TestParseHTTPDateDefaultLocation checks that HTTP dates, which are
+// always in GMT, are read as UTC whatever SetDefaultLocation says.
+func TestParseHTTPDateDefaultLocation(t *testing.T) {
+	defer SetDefaultLocation(DefaultLocation())
+	SetDefaultLocation(FixedZone("UTC+8", 8*60*60))
+	want := Date(1994, November, 6, 8, 49, 37, 0, UTC)
+	for _, s := range []string{
+		"Sun, 06 Nov 1994 08:49:37 GMT",
+		"Sunday, 06-Nov-94 08:49:37 GMT",
+		"Sun Nov  6 08:49:37 1994",
+	} {
+		tm, err := ParseHTTPDate(s)
+		if err != nil {
+			t.Errorf("ParseHTTPDate(%q): %v", s, err)
+			continue
+		}
+		if !tm.Equal(want) || tm.Location() != UTC {
+			t.Errorf("ParseHTTPDate(%q) = %v, want %v", s, tm, want)
+		}
+	}
+}
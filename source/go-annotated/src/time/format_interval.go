@@ -0,0 +1,344 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "errors"
+
+// ISO 8601 time intervals, as exchanged by APIs that take time ranges:
+//	2024-03-01T13:00:00Z/2024-05-11T15:30:00Z   start and end
+//	2024-03-01T13:00:00Z/P1Y2M10DT2H30M         start and duration
+//	P1Y2M10DT2H30M/2025-05-11T15:30:00Z         duration and end
+//	R5/2024-03-01T13:00:00Z/P1M                 five repetitions
+// ISO 8601 时间区间：起止时间、起始加时长、时长加结束，以及重复区间
+
+// An Interval is an ISO 8601 time interval: the time from Start up to
+// but not including End, possibly repeated.
+type Interval struct {
+	Start, End Time
+
+	// Repeat is the number of times n an interval written "Rn/..."
+	// occurs, -1 for one written "R/...", which repeats without end,
+	// and 0 for one that does not repeat.
+	Repeat int
+
+	// period is the duration the interval was written with, if any.
+	// Repetitions follow it, so that those of "P1M" last a month
+	// each, whatever its length.
+	period    isoPeriod
+	hasPeriod bool
+}
+
+// An isoPeriod is an ISO 8601 duration, such as "P1Y2M10DT2H30M":
+// years, months and days, which depend on the calendar, and a time.
+// Weeks count as 7 days.
+type isoPeriod struct {
+	years, months, days int
+	d                   Duration
+}
+
+// addTo returns t plus k times p, the calendar part first.
+func (p *isoPeriod) addTo(t Time, k int) Time {
+	return t.AddDate(k*p.years, k*p.months, k*p.days).Add(Duration(k) * p.d)
+}
+
+// ParseInterval parses an ISO 8601 time interval of one of the forms
+// "start/end", "start/duration" and "duration/end", optionally after
+// "Rn/" or "R/" for a repeating interval. Times are in RFC 3339 form,
+// with or without the zone offset, the seconds or the time: a time
+// without a zone is in the location of SetDefaultLocation, UTC
+// unless set, as for Parse. Durations are of the form "PnYnMnWnDTnHnMnS",
+// where any element may be left out and the last may have a fraction.
+// It is an error for the end to come before the start.
+func ParseInterval(value string) (Interval, error) {
+	const layout = "start/end"
+	perr := func(elem, msg string) error {
+		return &ParseError{layout, value, "", elem, msg, -1}
+	}
+
+	var iv Interval
+	s := value
+	if s != "" && s[0] == 'R' {
+		i := 1
+		for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+			i++
+		}
+		if i >= len(s) || s[i] != '/' {
+			return Interval{}, perr(s, ": bad repetition count")
+		}
+		iv.Repeat = -1
+		if i > 1 {
+			n, err := atoi(s[1:i])
+			if err != nil {
+				return Interval{}, perr(s[:i], ": bad repetition count")
+			}
+			iv.Repeat = n
+		}
+		s = s[i+1:]
+	}
+
+	i := 0
+	for i < len(s) && s[i] != '/' {
+		i++
+	}
+	if i == len(s) {
+		return Interval{}, perr(s, ": missing /")
+	}
+	a, b := s[:i], s[i+1:]
+
+	var err error
+	switch {
+	case a != "" && a[0] == 'P' && b != "" && b[0] == 'P':
+		return Interval{}, perr(s, ": no start or end time")
+	case a != "" && a[0] == 'P':
+		if iv.period, err = parseISOPeriod(a); err != nil {
+			return Interval{}, perr(a, err.Error())
+		}
+		if iv.End, err = parseIntervalTime(b); err != nil {
+			return Interval{}, perr(b, ": bad end time")
+		}
+		iv.Start = iv.period.addTo(iv.End, -1)
+		iv.hasPeriod = true
+	case b != "" && b[0] == 'P':
+		if iv.Start, err = parseIntervalTime(a); err != nil {
+			return Interval{}, perr(a, ": bad start time")
+		}
+		if iv.period, err = parseISOPeriod(b); err != nil {
+			return Interval{}, perr(b, err.Error())
+		}
+		iv.End = iv.period.addTo(iv.Start, 1)
+		iv.hasPeriod = true
+	default:
+		if iv.Start, err = parseIntervalTime(a); err != nil {
+			return Interval{}, perr(a, ": bad start time")
+		}
+		if iv.End, err = parseIntervalTime(b); err != nil {
+			return Interval{}, perr(b, ": bad end time")
+		}
+	}
+	if iv.End.Before(iv.Start) {
+		return Interval{}, perr(s, ": end before start")
+	}
+	return iv, nil
+}
+
+// intervalLayouts are the forms of the times of an interval, with
+// fractional seconds accepted after the seconds as by Parse.
+var intervalLayouts = [...]string{
+	RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+func parseIntervalTime(s string) (Time, error) {
+	var err error
+	for _, layout := range intervalLayouts {
+		var t Time
+		if t, err = Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return Time{}, err
+}
+
+var (
+	errISOPeriod      = errors.New(": bad duration")
+	errISOPeriodRange = errors.New(": duration out of range")
+)
+
+// parseISOPeriod parses an ISO 8601 duration, such as "P1Y2M10DT2H30M".
+func parseISOPeriod(s string) (isoPeriod, error) {
+	var p isoPeriod
+	if len(s) < 2 || s[0] != 'P' {
+		return p, errISOPeriod
+	}
+	s = s[1:]
+	const dateUnits, timeUnits = "YMWD", "HMS"
+	units, next := dateUnits, 0
+	frac := false
+	for s != "" {
+		if s[0] == 'T' {
+			if units == timeUnits || len(s) == 1 {
+				return p, errISOPeriod
+			}
+			units, next = timeUnits, 0
+			s = s[1:]
+			continue
+		}
+		if frac {
+			// Only the last element may have a fraction.
+			return p, errISOPeriod
+		}
+
+		i := 0
+		for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+			i++
+		}
+		if i == 0 {
+			return p, errISOPeriod
+		}
+		if i > 9 {
+			return p, errISOPeriodRange
+		}
+		n, _ := atoi(s[:i])
+		// The fraction, as num/den.
+		num, den := 0, 1
+		if i < len(s) && (s[i] == '.' || s[i] == ',') {
+			j := i + 1
+			for j < len(s) && '0' <= s[j] && s[j] <= '9' {
+				if j-i <= 9 {
+					num, den = num*10+int(s[j]-'0'), den*10
+				}
+				j++
+			}
+			if j == i+1 {
+				return p, errISOPeriod
+			}
+			i, frac = j, true
+		}
+		if i == len(s) {
+			return p, errISOPeriod
+		}
+
+		u := next
+		for u < len(units) && units[u] != s[i] {
+			u++
+		}
+		if u == len(units) {
+			// Unknown, repeated or out of order.
+			return p, errISOPeriod
+		}
+		next = u + 1
+		s = s[i+1:]
+
+		if units == dateUnits {
+			if frac {
+				return p, errISOPeriod
+			}
+			switch units[u] {
+			case 'Y':
+				p.years = n
+			case 'M':
+				p.months = n
+			case 'W':
+				p.days += 7 * n
+			case 'D':
+				p.days += n
+			}
+			continue
+		}
+		unit := [...]Duration{Hour, Minute, Second}[u]
+		if Duration(n) > (1<<63-1-p.d)/unit {
+			return p, errISOPeriodRange
+		}
+		// den divides unit, since den <= 1e9, so that this is exact
+		// and less than unit.
+		p.d += Duration(n)*unit + Duration(num)*(unit/Duration(den))
+		if p.d < 0 {
+			return p, errISOPeriodRange
+		}
+	}
+	return p, nil
+}
+
+// appendISOPeriod appends p in the form parseISOPeriod accepts.
+func appendISOPeriod(b []byte, p isoPeriod) []byte {
+	b = append(b, 'P')
+	if p.years != 0 {
+		b = appendInt(b, p.years, 0)
+		b = append(b, 'Y')
+	}
+	if p.months != 0 {
+		b = appendInt(b, p.months, 0)
+		b = append(b, 'M')
+	}
+	if p.days != 0 || p.years == 0 && p.months == 0 && p.d == 0 {
+		b = appendInt(b, p.days, 0)
+		b = append(b, 'D')
+	}
+	if p.d == 0 {
+		return b
+	}
+	b = append(b, 'T')
+	d := p.d
+	if h := d / Hour; h != 0 {
+		b = appendInt(b, int(h), 0)
+		b = append(b, 'H')
+		d -= h * Hour
+	}
+	if m := d / Minute; m != 0 {
+		b = appendInt(b, int(m), 0)
+		b = append(b, 'M')
+		d -= m * Minute
+	}
+	if d != 0 {
+		b = appendInt(b, int(d/Second), 0)
+		b = formatNano(b, uint(d%Second), 9, true)
+		b = append(b, 'S')
+	}
+	return b
+}
+
+// String returns iv in the form ParseInterval accepts, with times in
+// the RFC3339Nano layout: "start/duration" if iv was written with
+// a duration, and "start/end" otherwise.
+func (iv Interval) String() string {
+	var b []byte
+	switch {
+	case iv.Repeat < 0:
+		b = append(b, "R/"...)
+	case iv.Repeat > 0:
+		b = append(b, 'R')
+		b = appendInt(b, iv.Repeat, 0)
+		b = append(b, '/')
+	}
+	b = iv.Start.AppendFormat(b, RFC3339Nano)
+	b = append(b, '/')
+	if iv.hasPeriod {
+		return string(appendISOPeriod(b, iv.period))
+	}
+	return string(iv.End.AppendFormat(b, RFC3339Nano))
+}
+
+// Duration returns the length of iv, End minus Start.
+func (iv Interval) Duration() Duration {
+	return iv.End.Sub(iv.Start)
+}
+
+// Contains reports whether t is in iv: not before Start and before
+// End. Repetitions do not count.
+func (iv Interval) Contains(t Time) bool {
+	return !t.Before(iv.Start) && t.Before(iv.End)
+}
+
+// Overlaps reports whether iv and other have a time in common.
+// Intervals that only touch, one ending when the other starts, do not
+// overlap. Repetitions do not count.
+func (iv Interval) Overlaps(other Interval) bool {
+	return iv.Start.Before(other.End) && other.Start.Before(iv.End)
+}
+
+// Nth returns the n'th occurrence of a repeating interval, counting
+// from 0 for iv itself, and whether there is one. Occurrences follow
+// one another: those of an interval written with a duration last that
+// duration, such as a calendar month for "P1M"; others last as long as
+// iv. An interval that does not repeat has only occurrence 0.
+func (iv Interval) Nth(n int) (Interval, bool) {
+	if n < 0 || iv.Repeat >= 0 && n >= iv.Repeat && n > 0 {
+		return Interval{}, false
+	}
+	occ := iv
+	occ.Repeat = 0
+	if iv.hasPeriod {
+		occ.Start = iv.period.addTo(iv.Start, n)
+		occ.End = iv.period.addTo(iv.Start, n+1)
+	} else {
+		d := iv.Duration()
+		occ.Start = iv.Start.Add(Duration(n) * d)
+		occ.End = occ.Start.Add(d)
+	}
+	return occ, true
+}
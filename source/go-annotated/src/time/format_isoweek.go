@@ -0,0 +1,100 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// ISO 8601 week dates, such as 2023-W37-4 for Thursday of the 37th
+// week of 2023. Weeks start on Monday and week 1 of a year is the
+// week containing its first Thursday; see Time.ISOWeek.
+// ISO 8601 周日期：ISOWeek 的逆运算，以及对应的格式化和解析
+
+// ISOWeekDate returns midnight in loc at the start of the given day
+// of the given ISO 8601 week, such as Thursday of week 37 of 2023.
+// It is the inverse of Time.ISOWeek.
+//
+// Like Date, ISOWeekDate normalizes week values outside their usual
+// range: week 0 of 2024 is the last week of 2023.
+func ISOWeekDate(year, week int, day Weekday, loc *Location) Time {
+	// Week 1 contains January 4. Find its Monday.
+	jan4 := Date(year, January, 4, 0, 0, 0, 0, UTC)
+	mon := 4 - int(jan4.Weekday()+6)%7
+	d := int(day+6) % 7 // Monday = 0
+	return Date(year, January, mon+(week-1)*7+d, 0, 0, 0, 0, loc)
+}
+
+// FormatISOWeek returns the ISO 8601 week date of t, such as
+// "2023-W37-4", with the weekday numbered from Monday=1 to Sunday=7.
+func (t Time) FormatISOWeek() string {
+	return string(t.appendISOWeek(make([]byte, 0, 10)))
+}
+
+func (t Time) appendISOWeek(b []byte) []byte {
+	year, week := t.ISOWeek()
+	b = appendInt(b, year, 4)
+	b = append(b, "-W"...)
+	b = appendInt(b, week, 2)
+	b = append(b, '-')
+	return appendInt(b, int(t.Weekday()+6)%7+1, 0)
+}
+
+// ParseISOWeek parses an ISO 8601 week date and returns midnight in
+// loc at the start of that day. It accepts the extended form
+// "2023-W37-4", the basic form "2023W374", and the same forms
+// without the weekday, "2023-W37" and "2023W37", meaning Monday.
+//
+// The week must exist in the year: week 53 is an error in years
+// with 52 weeks.
+func ParseISOWeek(value string, loc *Location) (Time, error) {
+	const layout = "YYYY-Www-D"
+	s := value
+	perr := func(msg string) error {
+		return &ParseError{layout, value, "", s, msg, len(value) - len(s)}
+	}
+
+	if len(s) < 4 || !isDigit(s, 0) {
+		return Time{}, perr(": not an ISO 8601 week date")
+	}
+	year, err := atoi(s[:4])
+	if err != nil {
+		return Time{}, perr(": not an ISO 8601 week date")
+	}
+	s = s[4:]
+	extended := s != "" && s[0] == '-'
+	if extended {
+		s = s[1:]
+	}
+	if s == "" || s[0] != 'W' {
+		return Time{}, perr(": not an ISO 8601 week date")
+	}
+	s = s[1:]
+	if len(s) < 2 || !isDigit(s, 0) || !isDigit(s, 1) {
+		return Time{}, perr(": not an ISO 8601 week date")
+	}
+	week, _ := atoi(s[:2])
+	s = s[2:]
+	day := Monday
+	if s != "" {
+		if extended {
+			if s[0] != '-' {
+				return Time{}, perr(": not an ISO 8601 week date")
+			}
+			s = s[1:]
+		}
+		if len(s) != 1 || s[0] < '1' || '7' < s[0] {
+			return Time{}, perr(": not an ISO 8601 week date")
+		}
+		day = Weekday(s[0]-'0') % 7
+	}
+	if week < 1 || isoWeeksIn(year) < week {
+		return Time{}, perr(": week out of range")
+	}
+	return ISOWeekDate(year, week, day, loc), nil
+}
+
+// isoWeeksIn returns the number of ISO weeks in year, 52 or 53.
+func isoWeeksIn(year int) int {
+	// December 28 is always in the last week of its year.
+	_, w := Date(year, December, 28, 0, 0, 0, 0, UTC).ISOWeek()
+	return w
+}
@@ -0,0 +1,53 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time_test
+
+import (
+	"testing"
+	. "time"
+)
+
+// TestParseAfterSettingLocal checks that the parsing functions resolve
+// zones against Local as it is when they are called, not as it was
+// when the package was initialized.
+func TestParseAfterSettingLocal(t *testing.T) {
+	defer func(l *Location) { Local = l }(Local)
+	Local = FixedZone("MST", -7*60*60)
+	want := Date(2020, January, 2, 22, 4, 0, 0, UTC)
+
+	tests := []struct {
+		name  string
+		parse func() (Time, error)
+	}{
+		{"Parse abbreviation", func() (Time, error) {
+			return Parse("2006-01-02 15:04 MST", "2020-01-02 15:04 MST")
+		}},
+		{"Parse offset", func() (Time, error) {
+			return Parse("2006-01-02 15:04 -0700", "2020-01-02 15:04 -0700")
+		}},
+		{"ParseStrict", func() (Time, error) {
+			return ParseStrict("2006-01-02 15:04 MST", "2020-01-02 15:04 MST")
+		}},
+		{"Locale.Parse", func() (Time, error) {
+			return new(Locale).Parse("2006-01-02 15:04 MST", "2020-01-02 15:04 MST")
+		}},
+		{"ParseRFC3339", func() (Time, error) {
+			return ParseRFC3339("2020-01-02T15:04:00-07:00")
+		}},
+		{"Strptime", func() (Time, error) {
+			return Strptime("%Y-%m-%d %H:%M %Z", "2020-01-02 15:04 MST")
+		}},
+	}
+	for _, tt := range tests {
+		tm, err := tt.parse()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !tm.Equal(want) || tm.Location() != Local {
+			t.Errorf("%s = %v in %v, want %v in Local", tt.name, tm, tm.Location(), want.In(Local))
+		}
+	}
+}
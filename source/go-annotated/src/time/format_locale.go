@@ -0,0 +1,149 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// A Locale holds the month and weekday names, and the AM/PM markers,
+// used by its Format and Parse methods in place of the English ones.
+// Layouts are unchanged: "January" in a layout still means the long
+// month name, but it is written and read in the Locale's language.
+// 本地化的月份、星期名称，布局字符串本身不变
+//
+// Fields left empty use the English names, so a Locale may translate
+// only the names its layouts need. Names may be any UTF-8 text, such
+// as the Russian genitive "января" used in dates. Parse matches names
+// ignoring ASCII case; other letters must match exactly.
+//
+// For example:
+//
+//	es := &time.Locale{
+//		LongMonths: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio",
+//			"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+//	}
+//	s := es.Format(t, "2 January 2006") // "7 enero 2024"
+type Locale struct {
+	LongMonths  [12]string // "January", "February", ...
+	ShortMonths [12]string // "Jan", "Feb", ...
+	LongDays    [7]string  // "Sunday", "Monday", ...
+	ShortDays   [7]string  // "Sun", "Mon", ...
+
+	// AM and PM replace the markers written for the "PM" and "pm"
+	// layout elements, which otherwise give "AM"/"PM" or "am"/"pm".
+	AM, PM string
+}
+
+// Format is like t.Format but uses the names of l.
+func (l *Locale) Format(t Time, layout string) string {
+	return string(t.appendFormat(make([]byte, 0, len(layout)+10), layout, l))
+}
+
+// AppendFormat is like t.AppendFormat but uses the names of l.
+func (l *Locale) AppendFormat(b []byte, t Time, layout string) []byte {
+	return t.appendFormat(b, layout, l)
+}
+
+// Parse is like the Parse function but reads the names of l.
+func (l *Locale) Parse(layout, value string) (Time, error) {
+	return parse(layout, value, DefaultLocation(), localZones(), l, false)
+}
+
+// ParseInLocation is like the ParseInLocation function
+// but reads the names of l.
+func (l *Locale) ParseInLocation(layout, value string, loc *Location) (Time, error) {
+	return parse(layout, value, loc, []*Location{loc}, l, false)
+}
+
+// The name tables of l, or the English ones if l has none.
+
+func (l *Locale) longMonthNames() []string {
+	if l == nil || l.LongMonths == [12]string{} {
+		return longMonthNames
+	}
+	return l.LongMonths[:]
+}
+
+func (l *Locale) shortMonthNames() []string {
+	if l == nil || l.ShortMonths == [12]string{} {
+		return shortMonthNames
+	}
+	return l.ShortMonths[:]
+}
+
+func (l *Locale) longDayNames() []string {
+	if l == nil || l.LongDays == [7]string{} {
+		return longDayNames
+	}
+	return l.LongDays[:]
+}
+
+func (l *Locale) shortDayNames() []string {
+	if l == nil || l.ShortDays == [7]string{} {
+		return shortDayNames
+	}
+	return l.ShortDays[:]
+}
+
+func (l *Locale) longMonth(m Month) string {
+	if January <= m && m <= December {
+		return l.longMonthNames()[m-1]
+	}
+	return m.String()
+}
+
+func (l *Locale) shortMonth(m Month) string {
+	if January <= m && m <= December {
+		return l.shortMonthNames()[m-1]
+	}
+	return m.String()[:3]
+}
+
+func (l *Locale) longDay(d Weekday) string {
+	return l.longDayNames()[d]
+}
+
+func (l *Locale) shortDay(d Weekday) string {
+	return l.shortDayNames()[d]
+}
+
+// am and pm return the markers of l, or def if l has none.
+
+func (l *Locale) am(def string) string {
+	if l == nil || l.AM == "" {
+		return def
+	}
+	return l.AM
+}
+
+func (l *Locale) pm(def string) string {
+	if l == nil || l.PM == "" {
+		return def
+	}
+	return l.PM
+}
+
+// lookup is like the lookup function, but for a Locale it takes the
+// longest matching name, since translated names need not be of equal
+// length and one may be a prefix of another, as "juin" and "juillet".
+func (l *Locale) lookup(tab []string, val string) (int, string, error) {
+	if l == nil {
+		return lookup(tab, val)
+	}
+	best := -1
+	for i, v := range tab {
+		if v != "" && len(val) >= len(v) && match(val[0:len(v)], v) &&
+			(best < 0 || len(v) > len(tab[best])) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return -1, val, errBad
+	}
+	return best, val[len(tab[best]):], nil
+}
+
+// lookupAMPM reads the AM or PM marker of l from the start of val.
+func (l *Locale) lookupAMPM(val string) (pmSet, amSet bool, rest string, err error) {
+	i, rest, err := l.lookup([]string{l.am("AM"), l.pm("PM")}, val)
+	return i == 1, i == 0, rest, err
+}
@@ -0,0 +1,164 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "errors"
+
+// Military time zones are named by single letters: A to M, skipping
+// J, for UTC+1 to UTC+12, N to Y for UTC-1 to UTC-12, Z for UTC, and
+// J for the local time of the observer. Aviation and defense message
+// formats write them after the time, as in "1430Z".
+// 军用时区字母，A-M（跳过 J）为东一到东十二区，N-Y 为西一到西十二区，Z 为 UTC，J 为当地时间
+
+// militaryOffset returns the offset in hours of the military zone
+// letter c. ok is false for J, which has none, and other bytes.
+func militaryOffset(c byte) (hours int, ok bool) {
+	switch {
+	case 'A' <= c && c <= 'I':
+		return int(c-'A') + 1, true
+	case 'K' <= c && c <= 'M':
+		return int(c-'K') + 10, true
+	case 'N' <= c && c <= 'Y':
+		return -(int(c-'N') + 1), true
+	case c == 'Z':
+		return 0, true
+	}
+	return 0, false
+}
+
+// MilitaryZone returns the Location of a military time zone letter,
+// "A" to "Z". It is a fixed zone whose name and abbreviation are the
+// letter, so that the layout element "MST" formats it, as in "1430Z".
+// "J" gives Local.
+func MilitaryZone(letter string) (*Location, error) {
+	if letter == "J" {
+		return Local, nil
+	}
+	if len(letter) == 1 {
+		if h, ok := militaryOffset(letter[0]); ok {
+			return FixedZone(letter, h*secondsPerHour), nil
+		}
+	}
+	return nil, errors.New("time: unknown military time zone " + quote(letter))
+}
+
+// MilitaryLetter returns the letter of the military time zone with
+// the offset of t, "Z" for UTC. ok is false if the offset is not a
+// whole number of hours from -12 to +12. UTC+12 is "M"; UTC-12 is
+// "Y".
+func (t Time) MilitaryLetter() (letter string, ok bool) {
+	_, offset := t.Zone()
+	if offset%secondsPerHour != 0 {
+		return "", false
+	}
+	switch h := offset / secondsPerHour; {
+	case h == 0:
+		return "Z", true
+	case 1 <= h && h <= 9:
+		return string('A' + byte(h-1)), true
+	case 10 <= h && h <= 12:
+		return string('K' + byte(h-10)), true
+	case -12 <= h && h <= -1:
+		return string('N' + byte(-h-1)), true
+	}
+	return "", false
+}
+
+// FormatDTG returns t as a military date-time group, such as
+//	281430Z JAN 24
+// the day, the hour and minute, the zone letter, the month and the
+// year. Times whose offset has no letter, see MilitaryLetter, are
+// given in UTC, with "Z".
+func FormatDTG(t Time) string {
+	letter, ok := t.MilitaryLetter()
+	if !ok {
+		t, letter = t.UTC(), "Z"
+	}
+	year, month, day := t.Date()
+	b := make([]byte, 0, len("281430Z JAN 24"))
+	b = append2(b, day)
+	b = append2(b, t.Hour())
+	b = append2(b, t.Minute())
+	b = append(b, letter...)
+	b = append(b, ' ')
+	for _, c := range []byte(shortMonthNames[month-1]) {
+		b = append(b, c&^0x20) // upper case
+	}
+	b = append(b, ' ')
+	return string(append2(b, (year%100+100)%100))
+}
+
+// ParseDTG parses a military date-time group, as written by
+// FormatDTG: "DDHHMML MON YY", with the month in any case and the
+// spaces optional. J gives a time in Local. A two-digit year is in
+// the 2000s if below 69 and in the 1900s otherwise, as with the "06"
+// layout element.
+func ParseDTG(value string) (Time, error) {
+	const layout = "DDHHMML MON YY"
+	s := value
+	perr := func(msg string) error {
+		return &ParseError{layout, value, "", s, msg, len(value) - len(s)}
+	}
+	var f [3]int
+	for i := range f {
+		if !isDigit(s, 0) || !isDigit(s, 1) {
+			return Time{}, perr(": missing " + [...]string{"day", "hour", "minute"}[i])
+		}
+		f[i] = int(s[0]-'0')*10 + int(s[1]-'0')
+		s = s[2:]
+	}
+	day, hour, min := f[0], f[1], f[2]
+	if s == "" {
+		return Time{}, perr(": missing zone letter")
+	}
+	loc, err := MilitaryZone(s[:1])
+	if err != nil {
+		return Time{}, perr(": unknown zone letter")
+	}
+	s = s[1:]
+	if s != "" && s[0] == ' ' {
+		s = s[1:]
+	}
+	month, rest, err := lookup(shortMonthNames, s)
+	if err != nil {
+		return Time{}, perr(": unknown month")
+	}
+	s = rest
+	if s != "" && s[0] == ' ' {
+		s = s[1:]
+	}
+	if len(s) != 2 || !isDigit(s, 0) || !isDigit(s, 1) {
+		return Time{}, perr(": missing year")
+	}
+	year := int(s[0]-'0')*10 + int(s[1]-'0')
+	if year >= 69 {
+		year += 1900
+	} else {
+		year += 2000
+	}
+	switch {
+	case hour > 23:
+		s = value[2:]
+		return Time{}, perr(": hour out of range")
+	case min > 59:
+		s = value[4:]
+		return Time{}, perr(": minute out of range")
+	case day < 1 || day > daysIn(Month(month+1), year):
+		s = value
+		return Time{}, perr(": day out of range")
+	}
+	return Date(year, Month(month+1), day, hour, min, 0, 0, loc), nil
+}
+
+// parseMilitaryZone reports whether value starts with a military zone
+// letter standing alone, as in "1430Z", for the "MST" layout element,
+// and returns its Location.
+func parseMilitaryZone(value string) (*Location, bool) {
+	if value == "" || len(value) > 1 && ('A' <= value[1] && value[1] <= 'Z' || 'a' <= value[1] && value[1] <= 'z') {
+		return nil, false
+	}
+	loc, err := MilitaryZone(value[:1])
+	return loc, err == nil
+}
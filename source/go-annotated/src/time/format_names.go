@@ -0,0 +1,81 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "errors"
+
+// ParseWeekday returns the Weekday named by s: its English name, such
+// as "Tuesday", or an abbreviation of it, such as "Tue" or "Tues",
+// ignoring case. Abbreviations are the first three letters or more,
+// optionally followed by a period.
+// 从字符串解析星期和月份名，忽略大小写，接受缩写
+func ParseWeekday(s string) (Weekday, error) {
+	return (*Locale)(nil).ParseWeekday(s)
+}
+
+// ParseMonth returns the Month named by s: its English name, such as
+// "September", or an abbreviation of it, such as "Sep" or "Sept",
+// ignoring case, as for ParseWeekday.
+func ParseMonth(s string) (Month, error) {
+	return (*Locale)(nil).ParseMonth(s)
+}
+
+// ParseWeekday is like the ParseWeekday function but reads the names
+// of l. Abbreviations are its short names, or the start of a long name
+// at least as long as the short one.
+func (l *Locale) ParseWeekday(s string) (Weekday, error) {
+	i := matchName(l.longDayNames(), l.shortDayNames(), s)
+	if i < 0 {
+		return 0, errors.New("time: unknown weekday " + quote(s))
+	}
+	return Weekday(i), nil
+}
+
+// ParseMonth is like the ParseMonth function but reads the names of
+// l, as Locale.ParseWeekday does.
+func (l *Locale) ParseMonth(s string) (Month, error) {
+	i := matchName(l.longMonthNames(), l.shortMonthNames(), s)
+	if i < 0 {
+		return 0, errors.New("time: unknown month " + quote(s))
+	}
+	return Month(i + 1), nil
+}
+
+// matchName returns the index of the name s stands for in long and
+// short, or -1 if it stands for none or for more than one: s matches a
+// short name, or the start of a long name at least as long as the
+// short one, ignoring ASCII case and final periods.
+func matchName(long, short []string, s string) int {
+	s = trimPeriod(s)
+	if s == "" {
+		return -1
+	}
+	// A whole name wins over the start of another.
+	for i := range long {
+		if abbr := trimPeriod(short[i]); len(s) == len(abbr) && match(s, abbr) ||
+			len(s) == len(long[i]) && match(s, long[i]) {
+			return i
+		}
+	}
+	found := -1
+	for i := range long {
+		abbr := trimPeriod(short[i])
+		if len(s) < len(abbr) || len(s) > len(long[i]) || !match(s, long[i][:len(s)]) {
+			continue
+		}
+		if found >= 0 {
+			return -1
+		}
+		found = i
+	}
+	return found
+}
+
+func trimPeriod(s string) string {
+	if s != "" && s[len(s)-1] == '.' {
+		return s[:len(s)-1]
+	}
+	return s
+}
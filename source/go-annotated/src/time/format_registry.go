@@ -0,0 +1,99 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"errors"
+	"sync"
+)
+
+// Named layouts let a program keep its layouts in one place, under
+// names such as "db" or "log", instead of copying reference strings
+// from file to file:
+//	time.RegisterLayout("db", "2006-01-02 15:04:05")
+//	s := t.FormatNamed("db")
+//	t, err := time.ParseNamed("db", s)
+// The layout constants of this package, such as RFC3339 and Kitchen,
+// are registered under their own names.
+// 具名布局注册表：集中定义布局并按名称用于格式化和解析
+
+// layouts maps the registered names to their layouts.
+var layouts = struct {
+	sync.RWMutex
+	m map[string]string
+}{m: map[string]string{
+	"ANSIC":       ANSIC,
+	"UnixDate":    UnixDate,
+	"RubyDate":    RubyDate,
+	"RFC822":      RFC822,
+	"RFC822Z":     RFC822Z,
+	"RFC850":      RFC850,
+	"RFC1123":     RFC1123,
+	"RFC1123Z":    RFC1123Z,
+	"RFC3339":     RFC3339,
+	"RFC3339Nano": RFC3339Nano,
+	"Kitchen":     Kitchen,
+	"Stamp":       Stamp,
+	"StampMilli":  StampMilli,
+	"StampMicro":  StampMicro,
+	"StampNano":   StampNano,
+}}
+
+// RegisterLayout makes layout available under name to FormatNamed,
+// ParseNamed and NamedLayout. Registering the same layout twice under
+// a name does nothing; it panics if name is empty or already has
+// another layout, so that two packages cannot silently disagree on
+// what a name means. It is meant to be called from init functions.
+func RegisterLayout(name, layout string) {
+	if name == "" {
+		panic("time: RegisterLayout with empty name")
+	}
+	layouts.Lock()
+	defer layouts.Unlock()
+	if old, ok := layouts.m[name]; ok && old != layout {
+		panic("time: RegisterLayout called twice for layout name " + quote(name))
+	}
+	layouts.m[name] = layout
+}
+
+// NamedLayout returns the layout registered under name, and whether
+// there is one.
+func NamedLayout(name string) (layout string, ok bool) {
+	layouts.RLock()
+	defer layouts.RUnlock()
+	layout, ok = layouts.m[name]
+	return layout, ok
+}
+
+// FormatNamed is like Format with the layout registered under name.
+// It panics if there is none: a misspelled name is a mistake in the
+// program, as a misspelled layout constant would be.
+func (t Time) FormatNamed(name string) string {
+	layout, ok := NamedLayout(name)
+	if !ok {
+		panic("time: no layout registered under name " + quote(name))
+	}
+	return t.Format(layout)
+}
+
+// ParseNamed is like Parse with the layout registered under name.
+// It returns an error if there is none.
+func ParseNamed(name, value string) (Time, error) {
+	layout, ok := NamedLayout(name)
+	if !ok {
+		return Time{}, errors.New("time: no layout registered under name " + quote(name))
+	}
+	return Parse(layout, value)
+}
+
+// ParseNamedInLocation is like ParseInLocation with the layout
+// registered under name.
+func ParseNamedInLocation(name, value string, loc *Location) (Time, error) {
+	layout, ok := NamedLayout(name)
+	if !ok {
+		return Time{}, errors.New("time: no layout registered under name " + quote(name))
+	}
+	return ParseInLocation(layout, value, loc)
+}
@@ -0,0 +1,169 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "errors"
+
+// Parse(RFC3339, value) is lenient in ways that matter to protocols
+// that must reject what they cannot round trip: it accepts hours of
+// one digit, as "T3:04:05", and offsets out of range, as "+99:99",
+// and Format writes years beyond 9999 and drops the seconds of an
+// offset such as +0:19:32. ParseRFC3339 and FormatRFC3339 follow the
+// grammar of RFC 3339 section 5.6 exactly, reading and writing the
+// fields directly:
+//	date-time = full-date "T" full-time
+//	          = 2006-01-02T15:04:05[.999999999](Z|-07:00)
+// 严格遵循 RFC 3339 语法的解析和格式化，不经过通用布局解释
+
+// An RFC3339Parser parses timestamps as ParseRFC3339 does, optionally
+// accepting the variants RFC 3339 allows by agreement.
+type RFC3339Parser struct {
+	// Lowercase accepts "t" and "z" for "T" and "Z", as the note in
+	// RFC 3339 section 5.6 allows.
+	Lowercase bool
+
+	// Space accepts a space between the date and the time instead of
+	// "T", as the same note allows for readability.
+	Space bool
+}
+
+// ParseRFC3339 parses value as an RFC 3339 date-time, such as
+// "2006-01-02T15:04:05.999999999-07:00", accepting nothing outside the
+// grammar: "T" and "Z" must be upper case, each field must have its
+// full number of digits, a fraction must have at least one digit, and
+// the offset must be "Z" or have the form ±hh:mm. A leap second, 60,
+// is rejected, as Time cannot represent it.
+//
+// A time with offset "Z" or "-00:00", which RFC 3339 uses for a UTC
+// time whose local offset is unknown, is in UTC. Another offset is
+// matched against Local as Parse does. Digits of the fraction beyond
+// the ninth are dropped.
+func ParseRFC3339(value string) (Time, error) {
+	return RFC3339Parser{}.Parse(value)
+}
+
+// Parse parses value as ParseRFC3339 does, also accepting the
+// variants p allows.
+func (p RFC3339Parser) Parse(value string) (Time, error) {
+	perr := func(i int, msg string) error {
+		return &ParseError{RFC3339, value, "", value[i:], msg, i}
+	}
+	const syntax = ": not an RFC 3339 date-time"
+	num := func(i, n int) (int, bool) {
+		if i+n > len(value) {
+			return 0, false
+		}
+		x := 0
+		for _, c := range []byte(value[i : i+n]) {
+			if c < '0' || c > '9' {
+				return 0, false
+			}
+			x = x*10 + int(c-'0')
+		}
+		return x, true
+	}
+	at := func(i int, c byte) bool {
+		return i < len(value) && value[i] == c
+	}
+
+	// 2006-01-02T15:04:05
+	var f [6]int
+	for i, pos := range [...]int{0, 5, 8, 11, 14, 17} {
+		n := 2
+		if i == 0 {
+			n = 4
+		}
+		x, ok := num(pos, n)
+		if !ok {
+			return Time{}, perr(pos, syntax)
+		}
+		f[i] = x
+		if pos == 17 {
+			break
+		}
+		sep := [...]byte{'-', '-', 'T', ':', ':'}[i]
+		if at(pos+n, sep) ||
+			sep == 'T' && (p.Lowercase && at(pos+n, 't') || p.Space && at(pos+n, ' ')) {
+			continue
+		}
+		return Time{}, perr(pos+n, syntax)
+	}
+	year, month, day, hour, min, sec := f[0], f[1], f[2], f[3], f[4], f[5]
+	switch {
+	case month < 1 || month > 12:
+		return Time{}, perr(5, ": month out of range")
+	case day < 1 || day > daysIn(Month(month), year):
+		return Time{}, perr(8, ": day out of range")
+	case hour > 23:
+		return Time{}, perr(11, ": hour out of range")
+	case min > 59:
+		return Time{}, perr(14, ": minute out of range")
+	case sec > 59:
+		return Time{}, perr(17, ": second out of range")
+	}
+
+	i, nsec := 19, 0
+	if at(i, '.') {
+		i++
+		start := i
+		for ; i < len(value) && '0' <= value[i] && value[i] <= '9'; i++ {
+			if i-start < 9 {
+				nsec = nsec*10 + int(value[i]-'0')
+			}
+		}
+		if i == start {
+			return Time{}, perr(i, syntax)
+		}
+		for n := i - start; n < 9; n++ {
+			nsec *= 10
+		}
+	}
+
+	var z *Location
+	offset := -1
+	switch {
+	case at(i, 'Z') || p.Lowercase && at(i, 'z'):
+		z, i = UTC, i+1
+	case at(i, '+') || at(i, '-'):
+		hh, ok1 := num(i+1, 2)
+		mm, ok2 := num(i+4, 2)
+		if !ok1 || !at(i+3, ':') || !ok2 {
+			return Time{}, perr(i, ": zone offset not of the form ±hh:mm")
+		}
+		if hh > 23 || mm > 59 {
+			return Time{}, perr(i, ": zone offset out of range")
+		}
+		offset = (hh*60 + mm) * 60
+		if value[i] == '-' {
+			offset = -offset
+			if offset == 0 {
+				// Unknown local offset.
+				z = UTC
+			}
+		}
+		i += 6
+	default:
+		return Time{}, perr(i, syntax)
+	}
+	if i != len(value) {
+		return Time{}, perr(i, ": extra text after RFC 3339 date-time")
+	}
+	return timeFromFields(year, month, day, hour, min, sec, nsec, z, offset, "", UTC, localZones()), nil
+}
+
+// FormatRFC3339 returns t as an RFC 3339 date-time, with the fraction
+// of RFC3339Nano. It returns an error if t cannot be written exactly:
+// if its year is outside [0,9999], or its zone offset has seconds.
+func FormatRFC3339(t Time) (string, error) {
+	if y := t.Year(); y < 0 || y > 9999 {
+		return "", errors.New("time: FormatRFC3339: year outside of range [0,9999]")
+	}
+	if _, offset := t.Zone(); offset%60 != 0 {
+		return "", errors.New("time: FormatRFC3339: zone offset has fractional minute")
+	}
+	var buf [len(RFC3339Nano)]byte
+	b, _ := t.appendRFC3339(buf[:0], true)
+	return string(b), nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// SortableNano is a layout for timestamps used as keys in databases
+// and object stores, which order keys as strings. It is RFC3339 with
+// all nine digits of the fraction and always the zone "Z": formatted
+// in UTC, all times from year 0 to 9999 have the same width, 30 bytes,
+// and sort as strings in chronological order, which neither RFC3339
+// nor RFC3339Nano do.
+// 定长、UTC、纳秒精度的时间戳，按字符串排序即按时间排序，适合作为键
+//
+// Use FormatSortable or AppendSortable, which convert to UTC first,
+// and ParseSortable, which accepts only this exact form.
+const SortableNano = "2006-01-02T15:04:05.000000000Z"
+
+// FormatSortable returns t in UTC in the SortableNano layout.
+// Times before year 0 or after year 9999 have other widths, and do
+// not sort with the rest.
+func (t Time) FormatSortable() string {
+	var buf [len(SortableNano)]byte
+	return string(t.AppendSortable(buf[:0]))
+}
+
+// AppendSortable is like FormatSortable but appends the textual
+// representation to b and returns the extended buffer.
+func (t Time) AppendSortable(b []byte) []byte {
+	t = t.UTC()
+	if fb, ok := t.appendSortable(b); ok {
+		return fb
+	}
+	return t.appendFormat(b, SortableNano, nil)
+}
+
+// appendSortable appends t, in UTC, in the SortableNano layout.
+// Years outside [0,9999] are left to appendFormat.
+func (t Time) appendSortable(b []byte) ([]byte, bool) {
+	_, _, abs := t.locabs()
+	year, month, day, _ := absDate(abs, true)
+	if year < 0 || year > 9999 {
+		return b, false
+	}
+	hour, min, sec := absClock(abs)
+
+	b = append4(b, year)
+	b = append(b, '-')
+	b = append2(b, int(month))
+	b = append(b, '-')
+	b = append2(b, day)
+	b = append(b, 'T')
+	b = append2(b, hour)
+	b = append(b, ':')
+	b = append2(b, min)
+	b = append(b, ':')
+	b = append2(b, sec)
+	b = formatNano(b, uint(t.Nanosecond()), 9, false)
+	return append(b, 'Z'), true
+}
+
+// ParseSortable parses a timestamp in the SortableNano layout, as
+// produced by FormatSortable. Unlike Parse, it accepts nothing else:
+// not fewer fraction digits, nor another zone. The result is in UTC.
+func ParseSortable(value string) (Time, error) {
+	perr := func(i int, msg string) error {
+		return &ParseError{SortableNano, value, "", value[i:], msg, i}
+	}
+	if len(value) != len(SortableNano) {
+		n := len(value)
+		if n > len(SortableNano) {
+			n = len(SortableNano)
+		}
+		return Time{}, perr(n, ": not a SortableNano timestamp")
+	}
+	// Check the layout byte by byte: a digit in the layout asks for
+	// a digit in value, anything else for itself.
+	for i := 0; i < len(SortableNano); i++ {
+		if c := SortableNano[i]; '0' <= c && c <= '9' {
+			if !isDigit(value, i) {
+				return Time{}, perr(i, ": not a SortableNano timestamp")
+			}
+		} else if value[i] != c {
+			return Time{}, perr(i, ": not a SortableNano timestamp")
+		}
+	}
+
+	num := func(i, n int) int {
+		x := 0
+		for _, c := range []byte(value[i : i+n]) {
+			x = x*10 + int(c-'0')
+		}
+		return x
+	}
+	year, month, day := num(0, 4), num(5, 2), num(8, 2)
+	hour, min, sec, nsec := num(11, 2), num(14, 2), num(17, 2), num(20, 9)
+	switch {
+	case month < 1 || month > 12:
+		return Time{}, perr(5, ": month out of range")
+	case day < 1 || day > daysIn(Month(month), year):
+		return Time{}, perr(8, ": day out of range")
+	case hour > 23:
+		return Time{}, perr(11, ": hour out of range")
+	case min > 59:
+		return Time{}, perr(14, ": minute out of range")
+	case sec > 59:
+		return Time{}, perr(17, ": second out of range")
+	}
+	return Date(year, Month(month), day, hour, min, sec, nsec, UTC), nil
+}
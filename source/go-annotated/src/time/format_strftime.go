@@ -0,0 +1,175 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// Strftime returns t formatted according to layout, which uses the
+// %-directives of C's strftime, as in "%Y-%m-%d %H:%M:%S %Z", rather
+// than a reference time. It is meant for code ported from C, Python
+// or Ruby; new Go code should prefer Format.
+// 兼容 C 语言 strftime 的格式化，便于从其他语言迁移代码
+//
+// The directives are those of C99 and POSIX in the C locale:
+//
+//	%a  Mon          %A  Monday      %b, %h  Jan      %B  January
+//	%c  Mon Jan  2 15:04:05 2006     %C  20 (century)
+//	%d  02           %D  01/02/06    %e  " 2"         %F  2006-01-02
+//	%g  06 (ISO year)  %G  2006 (ISO year)            %H  15
+//	%I  03           %j  002 (day of year)            %k  "15"
+//	%l  " 3"         %m  01          %M  04           %n  newline
+//	%p  PM           %r  03:04:05 PM %R  15:04        %s  1136239445
+//	%S  05           %t  tab         %T  15:04:05     %u  1 (Monday=1)
+//	%U  00 (week, Sunday first)      %V  01 (ISO week)
+//	%w  1 (Sunday=0) %W  01 (week, Monday first)      %x  01/02/06
+//	%X  15:04:05     %y  06          %Y  2006         %z  -0700
+//	%Z  MST          %%  %
+//
+// In addition, %f gives the microseconds as six digits, as in Python,
+// and %N gives the nanoseconds as nine digits, as in Ruby and GNU date.
+// Unknown directives are copied to the result unchanged.
+func Strftime(layout string, t Time) string {
+	return string(appendStrftime(make([]byte, 0, len(layout)+10), layout, t))
+}
+
+// appendStrftime appends t formatted according to layout to b.
+func appendStrftime(b []byte, layout string, t Time) []byte {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	for i := 0; i < len(layout); i++ {
+		c := layout[i]
+		if c != '%' || i+1 == len(layout) {
+			b = append(b, c)
+			continue
+		}
+		i++
+		switch layout[i] {
+		case 'a':
+			b = append(b, shortDayNames[t.Weekday()]...)
+		case 'A':
+			b = append(b, longDayNames[t.Weekday()]...)
+		case 'b', 'h':
+			b = append(b, shortMonthNames[month-1]...)
+		case 'B':
+			b = append(b, longMonthNames[month-1]...)
+		case 'c':
+			b = appendStrftime(b, "%a %b %e %H:%M:%S %Y", t)
+		case 'C':
+			b = appendInt(b, year/100, 2)
+		case 'd':
+			b = appendInt(b, day, 2)
+		case 'D', 'x':
+			b = appendStrftime(b, "%m/%d/%y", t)
+		case 'e':
+			b = appendSpacePadded(b, day)
+		case 'f':
+			b = appendInt(b, t.Nanosecond()/1e3, 6)
+		case 'F':
+			b = appendStrftime(b, "%Y-%m-%d", t)
+		case 'g':
+			y, _ := t.ISOWeek()
+			b = appendInt(b, y%100, 2)
+		case 'G':
+			y, _ := t.ISOWeek()
+			b = appendInt(b, y, 4)
+		case 'H':
+			b = appendInt(b, hour, 2)
+		case 'I':
+			b = appendInt(b, hour12(hour), 2)
+		case 'j':
+			b = appendInt(b, t.YearDay(), 3)
+		case 'k':
+			b = appendSpacePadded(b, hour)
+		case 'l':
+			b = appendSpacePadded(b, hour12(hour))
+		case 'm':
+			b = appendInt(b, int(month), 2)
+		case 'M':
+			b = appendInt(b, min, 2)
+		case 'n':
+			b = append(b, '\n')
+		case 'N':
+			b = appendInt(b, t.Nanosecond(), 9)
+		case 'p':
+			if hour >= 12 {
+				b = append(b, "PM"...)
+			} else {
+				b = append(b, "AM"...)
+			}
+		case 'r':
+			b = appendStrftime(b, "%I:%M:%S %p", t)
+		case 'R':
+			b = appendStrftime(b, "%H:%M", t)
+		case 's':
+			b = appendInt64(b, t.Unix())
+		case 'S':
+			b = appendInt(b, sec, 2)
+		case 't':
+			b = append(b, '\t')
+		case 'T', 'X':
+			b = appendStrftime(b, "%H:%M:%S", t)
+		case 'u':
+			wd := int(t.Weekday())
+			if wd == 0 {
+				wd = 7
+			}
+			b = appendInt(b, wd, 0)
+		case 'U':
+			yday := t.YearDay() - 1
+			b = appendInt(b, (yday+7-int(t.Weekday()))/7, 2)
+		case 'V':
+			_, w := t.ISOWeek()
+			b = appendInt(b, w, 2)
+		case 'w':
+			b = appendInt(b, int(t.Weekday()), 0)
+		case 'W':
+			yday := t.YearDay() - 1
+			b = appendInt(b, (yday+7-(int(t.Weekday())+6)%7)/7, 2)
+		case 'y':
+			y := year % 100
+			if y < 0 {
+				y = -y
+			}
+			b = appendInt(b, y, 2)
+		case 'Y':
+			b = appendInt(b, year, 4)
+		case 'z':
+			b = t.AppendFormat(b, "-0700")
+		case 'Z':
+			b = t.AppendFormat(b, "MST")
+		case '%':
+			b = append(b, '%')
+		default:
+			b = append(b, '%', layout[i])
+		}
+	}
+	return b
+}
+
+// hour12 converts hour to the 12-hour clock, where noon is 12PM
+// and midnight is 12AM.
+func hour12(hour int) int {
+	if hr := hour % 12; hr != 0 {
+		return hr
+	}
+	return 12
+}
+
+// appendSpacePadded appends x padded to two characters with a space.
+func appendSpacePadded(b []byte, x int) []byte {
+	if 0 <= x && x < 10 {
+		b = append(b, ' ')
+	}
+	return appendInt(b, x, 0)
+}
+
+// appendInt64 is like appendInt with no padding, for values that
+// may not fit in an int.
+func appendInt64(b []byte, x int64) []byte {
+	u := uint64(x)
+	if x < 0 {
+		b = append(b, '-')
+		u = -u
+	}
+	return appendUint64(b, u)
+}
@@ -0,0 +1,355 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// Strptime parses value according to layout, which uses the
+// %-directives of C's strptime, as in "%Y-%m-%d %H:%M:%S %z", and
+// returns the time it represents. It is the inverse of Strftime.
+// 兼容 C 语言 strptime 的解析，与 Strftime 对应
+//
+// The supported directives are %a, %A, %b, %B, %c, %C, %d, %D, %e,
+// %f, %F, %G, %h, %H, %I, %j, %k, %l, %m, %M, %n, %N, %p, %r, %R, %s,
+// %S, %t, %T, %u, %U, %V, %w, %W, %x, %X, %y, %Y, %z, %Z and %%, with the
+// meanings given for Strftime. As in C, numbers may omit leading
+// zeros, names are matched ignoring case and both their short and
+// long forms are accepted, and white space in the layout, %n and %t
+// match any amount of white space in the value, including none.
+// An ISO 8601 year and week from %G and %V, as in "%G-W%V-%u", give
+// the date if the value has no month or day, with the weekday from
+// %u or %w, or Monday. Otherwise the weekday and week number
+// directives %a, %A, %u, %U, %w and %W are checked but do not
+// affect the result. Other directives are an error.
+//
+// %z accepts offsets like -0700, -07:00, -07 and Z. %Z accepts zone
+// abbreviations like MST, which are resolved against the Local
+// location as for Parse; unknown abbreviations give a fabricated
+// location with a zero offset, as with Parse. %s gives the instant
+// in UTC and overrides the other fields.
+//
+// Elements omitted from the value are assumed to be zero or, when
+// that is impossible, one, as for Parse. A value with neither %z nor
+// %Z is in UTC, or in the Location set by SetDefaultLocation.
+func Strptime(layout, value string) (Time, error) {
+	p := strptime{layout: layout, value: value, month: 1, day: 1, wday: Monday, zoneOffset: -1}
+	rest, err := p.parse(layout, value)
+	if err != nil {
+		return Time{}, err
+	}
+	if rest != "" {
+		return Time{}, &ParseError{layout, value, "", rest, ": extra text: " + rest, len(value) - len(rest)}
+	}
+	return p.time()
+}
+
+// strptime holds the state of a Strptime call.
+type strptime struct {
+	layout, value string // the arguments, for errors
+
+	year, month, day, hour, min, sec, nsec int
+
+	century    int // from %C, plus one; 0 if not set
+	yday       int // day of the year from %j; 0 if not set
+	isoYear    int
+	isoWeek    int     // from %V; 0 if not set
+	wday       Weekday // from %u or %w, for %V
+	dateSet    bool
+	amSet      bool
+	pmSet      bool
+	unix       int64
+	unixSet    bool
+	z          *Location
+	zoneOffset int
+	zoneName   string
+}
+
+// parse parses value according to layout, recursing for composite
+// directives such as %T, and returns the unparsed rest of value.
+func (p *strptime) parse(layout, value string) (string, error) {
+	for i := 0; i < len(layout); i++ {
+		c := layout[i]
+		if isSpace(c) {
+			value = trimSpaceLeft(value)
+			continue
+		}
+		if c != '%' || i+1 == len(layout) {
+			if value == "" || value[0] != c {
+				return value, &ParseError{p.layout, p.value, layout[i : i+1], value, "", len(p.value) - len(value)}
+			}
+			value = value[1:]
+			continue
+		}
+		i++
+		elem := layout[i-1 : i+1]
+		offset := len(p.value) - len(value)
+		var (
+			err      error
+			rangeErr string
+			n        int
+		)
+		switch layout[i] {
+		case 'a', 'A':
+			_, value, err = lookupLongShort(longDayNames, shortDayNames, value)
+		case 'b', 'B', 'h':
+			n, value, err = lookupLongShort(longMonthNames, shortMonthNames, value)
+			p.month = n + 1
+			p.dateSet = true
+		case 'c':
+			value, err = p.parse("%a %b %e %H:%M:%S %Y", value)
+		case 'C':
+			n, value, err = getdigits(value, 2)
+			p.century = n + 1
+		case 'd', 'e':
+			value = trimSpaceLeft(value)
+			p.day, value, err = getdigits(value, 2)
+			if p.day < 1 || 31 < p.day {
+				rangeErr = "day"
+			}
+			p.dateSet = true
+		case 'D', 'x':
+			value, err = p.parse("%m/%d/%y", value)
+		case 'f', 'N':
+			max := 9
+			if layout[i] == 'f' {
+				max = 6
+			}
+			k := 0
+			for k < max && isDigit(value, k) {
+				k++
+			}
+			if k == 0 {
+				err = errBad
+				break
+			}
+			p.nsec, rangeErr, err = parseNanoseconds("."+value[:k], k+1)
+			value = value[k:]
+		case 'F':
+			value, err = p.parse("%Y-%m-%d", value)
+		case 'H', 'k':
+			value = trimSpaceLeft(value)
+			p.hour, value, err = getdigits(value, 2)
+			if 24 <= p.hour {
+				rangeErr = "hour"
+			}
+		case 'I', 'l':
+			value = trimSpaceLeft(value)
+			p.hour, value, err = getdigits(value, 2)
+			if p.hour < 1 || 12 < p.hour {
+				rangeErr = "hour"
+			}
+		case 'j':
+			p.yday, value, err = getdigits(value, 3)
+			if p.yday < 1 || 366 < p.yday {
+				rangeErr = "day of year"
+			}
+		case 'm':
+			p.month, value, err = getdigits(value, 2)
+			if p.month < 1 || 12 < p.month {
+				rangeErr = "month"
+			}
+			p.dateSet = true
+		case 'M':
+			p.min, value, err = getdigits(value, 2)
+			if 60 <= p.min {
+				rangeErr = "minute"
+			}
+		case 'n', 't':
+			value = trimSpaceLeft(value)
+		case 'p':
+			n, value, err = lookup([]string{"AM", "PM"}, value)
+			p.amSet, p.pmSet = n == 0, n == 1
+		case 'r':
+			value, err = p.parse("%I:%M:%S %p", value)
+		case 'R':
+			value, err = p.parse("%H:%M", value)
+		case 's':
+			neg := value != "" && value[0] == '-'
+			if neg {
+				value = value[1:]
+			}
+			var x int64
+			x, value, err = leadingInt(value)
+			if err == nil && !isDigit(value, 0) {
+				if neg {
+					x = -x
+				}
+				p.unix, p.unixSet = x, true
+			} else {
+				err = errBad
+			}
+		case 'S':
+			p.sec, value, err = getdigits(value, 2)
+			if 60 <= p.sec {
+				rangeErr = "second"
+			}
+		case 'T', 'X':
+			value, err = p.parse("%H:%M:%S", value)
+		case 'G':
+			p.isoYear, value, err = getdigits(value, 4)
+		case 'u':
+			n, value, err = getdigits(value, 1)
+			if n < 1 || 7 < n {
+				rangeErr = "weekday"
+			}
+			p.wday = Weekday(n % 7)
+		case 'V':
+			p.isoWeek, value, err = getdigits(value, 2)
+			if p.isoWeek < 1 || 53 < p.isoWeek {
+				rangeErr = "week"
+			}
+		case 'w':
+			n, value, err = getdigits(value, 1)
+			if 6 < n {
+				rangeErr = "weekday"
+			}
+			p.wday = Weekday(n)
+		case 'U', 'W':
+			n, value, err = getdigits(value, 2)
+			if 53 < n {
+				rangeErr = "week"
+			}
+		case 'y':
+			p.year, value, err = getdigits(value, 2)
+			if p.century == 0 {
+				// As in POSIX: 69-99 are 1969-1999, 00-68 are 2000-2068.
+				if p.year >= 69 {
+					p.year += 1900
+				} else {
+					p.year += 2000
+				}
+			}
+		case 'Y':
+			p.year, value, err = getdigits(value, 4)
+		case 'z':
+			value, err = p.parseOffset(value)
+		case 'Z':
+			if len(value) >= 3 && value[0:3] == "UTC" {
+				p.z = UTC
+				value = value[3:]
+				break
+			}
+			n, ok := parseTimeZone(value)
+			if !ok {
+				err = errBad
+				break
+			}
+			p.zoneName, value = value[:n], value[n:]
+		case '%':
+			if value == "" || value[0] != '%' {
+				err = errBad
+				break
+			}
+			value = value[1:]
+		default:
+			return value, &ParseError{p.layout, p.value, elem, value, ": unknown directive " + elem, offset}
+		}
+		if rangeErr != "" {
+			return value, &ParseError{p.layout, p.value, elem, value, ": " + rangeErr + " out of range", offset}
+		}
+		if err != nil {
+			if _, ok := err.(*ParseError); ok {
+				return value, err
+			}
+			return value, &ParseError{p.layout, p.value, elem, value, "", offset}
+		}
+	}
+	return value, nil
+}
+
+// parseOffset parses a %z offset at the start of value.
+func (p *strptime) parseOffset(value string) (string, error) {
+	if value != "" && value[0] == 'Z' {
+		p.z = UTC
+		return value[1:], nil
+	}
+	if len(value) < 3 || (value[0] != '+' && value[0] != '-') || !isDigit(value, 1) || !isDigit(value, 2) {
+		return value, errBad
+	}
+	sign, hh, mm := value[0], value[1:3], "00"
+	value = value[3:]
+	if len(value) >= 3 && value[0] == ':' && isDigit(value, 1) && isDigit(value, 2) {
+		mm, value = value[1:3], value[3:]
+	} else if len(value) >= 2 && isDigit(value, 0) && isDigit(value, 1) {
+		mm, value = value[0:2], value[2:]
+	}
+	hr, _ := atoi(hh)
+	min, _ := atoi(mm)
+	if 24 <= hr || 60 <= min {
+		return value, errBad
+	}
+	p.zoneOffset = (hr*60 + min) * 60
+	if sign == '-' {
+		p.zoneOffset = -p.zoneOffset
+	}
+	return value, nil
+}
+
+// time returns the Time for the parsed fields.
+func (p *strptime) time() (Time, error) {
+	if p.unixSet {
+		return Unix(p.unix, int64(p.nsec)).In(UTC), nil
+	}
+	if p.century != 0 {
+		p.year = (p.century-1)*100 + p.year%100
+	}
+	if p.pmSet && p.hour < 12 {
+		p.hour += 12
+	} else if p.amSet && p.hour == 12 {
+		p.hour = 0
+	}
+	if p.isoWeek != 0 && p.yday == 0 && !p.dateSet {
+		if p.isoWeek > isoWeeksIn(p.isoYear) {
+			return Time{}, &ParseError{p.layout, p.value, "", "", ": week out of range", -1}
+		}
+		t := ISOWeekDate(p.isoYear, p.isoWeek, p.wday, UTC)
+		p.year, p.month, p.day = t.Year(), int(t.Month()), t.Day()
+	}
+	if p.yday != 0 && !p.dateSet {
+		if p.yday > 365 && !isLeap(p.year) {
+			return Time{}, &ParseError{p.layout, p.value, "", "", ": day of year out of range", -1}
+		}
+		t := Date(p.year, January, p.yday, 0, 0, 0, 0, UTC)
+		p.month, p.day = int(t.Month()), t.Day()
+	}
+	if p.day > daysIn(Month(p.month), p.year) {
+		return Time{}, &ParseError{p.layout, p.value, "", "", ": day out of range", -1}
+	}
+	return timeFromFields(p.year, p.month, p.day, p.hour, p.min, p.sec, p.nsec,
+		p.z, p.zoneOffset, p.zoneName, DefaultLocation(), localZones()), nil
+}
+
+// getdigits parses a decimal number of one to max digits
+// at the start of s.
+func getdigits(s string, max int) (int, string, error) {
+	n := 0
+	for n < max && isDigit(s, n) {
+		n++
+	}
+	if n == 0 {
+		return 0, s, errBad
+	}
+	x, err := atoi(s[:n])
+	return x, s[n:], err
+}
+
+// lookupLongShort is like lookup but tries the long names first,
+// so that "March" is not taken as "Mar" followed by "ch".
+func lookupLongShort(long, short []string, val string) (int, string, error) {
+	if i, rest, err := lookup(long, val); err == nil {
+		return i, rest, nil
+	}
+	return lookup(short, val)
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// trimSpaceLeft returns s without leading white space.
+func trimSpaceLeft(s string) string {
+	for len(s) > 0 && isSpace(s[0]) {
+		s = s[1:]
+	}
+	return s
+}
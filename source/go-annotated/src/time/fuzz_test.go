@@ -0,0 +1,199 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	. "time"
+)
+
+// addZoneSeeds adds the TZif files under testdata/zoneinfo to the seed
+// corpus of f.
+func addZoneSeeds(f *testing.F) {
+	err := filepath.Walk("testdata/zoneinfo", func(path string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		f.Add(data)
+		return nil
+	})
+	if err != nil {
+		f.Fatal(err)
+	}
+}
+
+func FuzzLoadLocationFromTZData(f *testing.F) {
+	addZoneSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// ParseTZif and ValidateTZif must not panic, whatever data is.
+		tf, perr := ParseTZif(data)
+		verr := ValidateTZif(data)
+		if perr != nil && verr == nil {
+			t.Fatalf("ValidateTZif accepts what ParseTZif rejects: %v", perr)
+		}
+		if perr == nil && tf.V2 != nil && len(tf.V2.Transitions) != len(tf.V2.TransitionTypes) {
+			t.Fatalf("ParseTZif returned %d transitions and %d types",
+				len(tf.V2.Transitions), len(tf.V2.TransitionTypes))
+		}
+
+		l, err := LoadLocationFromTZData("fuzz", data)
+		if err != nil {
+			if l != nil {
+				t.Fatalf("LoadLocationFromTZData returned both a Location and an error: %v", err)
+			}
+			return
+		}
+		if msg := CheckLocation(l); msg != "" {
+			t.Fatal(msg)
+		}
+		// Formatting a time in the zone must not fail.
+		_ = Unix(0, 0).In(l).String()
+	})
+}
+
+// fuzzLayouts are the layouts FuzzParse chooses from.
+var fuzzLayouts = []string{
+	ANSIC, UnixDate, RubyDate, RFC822, RFC822Z, RFC850,
+	RFC1123, RFC1123Z, RFC3339, RFC3339Nano, Kitchen,
+	Stamp, StampMilli, StampMicro, StampNano,
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"Jan _2 2006 3:04:05.000PM Z07:00:00",
+}
+
+// isFuzzLayout reports whether layout is one of fuzzLayouts, for which
+// a parsed time must survive a round trip through Format.
+func isFuzzLayout(layout string) bool {
+	for _, l := range fuzzLayouts {
+		if l == layout {
+			return true
+		}
+	}
+	return false
+}
+
+// roundTripTime returns the part of tm that Format with layout keeps:
+// Parse accepts a fraction of a second after the seconds field even if
+// layout has none, and Format drops it.
+func roundTripTime(layout string, tm Time) Time {
+	if !strings.Contains(layout, ".0") && !strings.Contains(layout, ".9") {
+		return tm.Truncate(Second)
+	}
+	return tm
+}
+
+func FuzzParse(f *testing.F) {
+	for _, s := range []struct{ layout, value string }{
+		{RFC3339, "2006-01-02T15:04:05Z"},
+		{RFC3339, "2019-03-31T03:00:00+02:00"},
+		{RFC3339Nano, "2006-01-02T15:04:05.999999999-07:00"},
+		{RFC1123, "Mon, 02 Jan 2006 15:04:05 MST"},
+		{RFC1123Z, "Sun, 06 Nov 1994 08:49:37 +0000"},
+		{RFC850, "Sunday, 06-Nov-94 08:49:37 GMT"},
+		{ANSIC, "Sun Nov  6 08:49:37 1994"},
+		{Kitchen, "3:04PM"},
+		{StampNano, "Jan  2 15:04:05.000000000"},
+		{"2006-01-02 15:04:05.999999999 -0700 MST", "2009-11-10 23:00:00 +0000 UTC"},
+		{"Jan _2 2006 3:04:05.000PM Z07:00:00", "Feb  3 2013 4:05:06.789AM -01:02:03"},
+		{"2006-01-02", "0000-01-01"},
+		{"2006-01-02", "9999-12-31"},
+	} {
+		f.Add(s.layout, s.value)
+	}
+	for _, layout := range fuzzLayouts {
+		f.Add(layout, Date(2020, February, 29, 23, 59, 59, 123456789, UTC).Format(layout))
+	}
+	f.Fuzz(func(t *testing.T, layout, value string) {
+		// Parse must not panic on any layout.
+		tm, err := Parse(layout, value)
+		if err != nil {
+			return
+		}
+		if !isFuzzLayout(layout) {
+			return
+		}
+		if name, offset := tm.Zone(); name != "" && offset == 0 && name != "UTC" {
+			// Unknown abbreviations are recorded with offset zero;
+			// the round trip below cannot hold for them.
+			return
+		}
+		tm = roundTripTime(layout, tm)
+		s := tm.Format(layout)
+		tm1, err := Parse(layout, s)
+		if err != nil {
+			t.Fatalf("cannot reparse %q as %q: %v", s, layout, err)
+		}
+		if !tm.Equal(tm1) {
+			t.Fatalf("round trip of %q through %q changed %v to %v", value, s, tm, tm1)
+		}
+	})
+}
+
+func FuzzParseInLocation(f *testing.F) {
+	f.Add(RFC3339, "2019-03-31T02:30:00+01:00", "Europe/London")
+	f.Add("2006-01-02 15:04 MST", "2019-11-03 01:30 EST", "America/New_York")
+	f.Add("2006-01-02 15:04 MST", "2019-11-03 01:30 EDT", "America/New_York")
+	f.Add("2006-01-02 15:04", "2019-10-06 02:15", "Australia/Lord_Howe")
+	f.Add("Jan 2 2006 15:04 MST", "Dec 30 2011 12:00 WSDT", "Pacific/Apia")
+	f.Fuzz(func(t *testing.T, layout, value, zone string) {
+		data, err := ioutil.ReadFile(filepath.Join("testdata/zoneinfo", filepath.Clean("/"+zone)))
+		if err != nil {
+			return
+		}
+		loc, err := LoadLocationFromTZData(zone, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// ParseInLocation must not panic.
+		tm, err := ParseInLocation(layout, value, loc)
+		if err != nil {
+			return
+		}
+		// A time not in loc or UTC must be in a zone fixed at its offset.
+		_, offset := tm.Zone()
+		if l := tm.Location(); l != loc && l != UTC {
+			if _, off := tm.AddDate(1, 6, 0).In(l).Zone(); off != offset {
+				t.Fatalf("ParseInLocation returned %v in a zone that is not fixed at %d", tm, offset)
+			}
+		}
+		// With a numeric offset in the layout, the time must survive a
+		// round trip through Format in its own zone.
+		if !isFuzzLayout(layout) || !strings.Contains(layout, "-0700") && !strings.Contains(layout, "Z07:00") {
+			return
+		}
+		tm = roundTripTime(layout, tm)
+		s := tm.Format(layout)
+		tm1, err := ParseInLocation(layout, s, loc)
+		if err != nil {
+			t.Fatalf("cannot reparse %q as %q in %v: %v", s, layout, loc, err)
+		}
+		if !tm.Equal(tm1) {
+			t.Fatalf("round trip of %q through %q in %v changed %v to %v", value, s, loc, tm, tm1)
+		}
+	})
+}
+
+func FuzzParseDuration(f *testing.F) {
+	for _, s := range []string{"0", "1h2m3.5s", "-1.5us", "9223372036854775807ns", "2562047h47m16.854775807s", ".5s", "1µs"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		d, err := ParseDuration(s)
+		if err != nil {
+			return
+		}
+		d1, err := ParseDuration(d.String())
+		if err != nil || d1 != d {
+			t.Fatalf("ParseDuration(%q) = %v does not round trip", s, d)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build ignore
+
+//
+// usage:
+//
+// go run genwinzones.go -output zoneinfo_winzones.go
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"text/template"
+)
+
+var filename = flag.String("output", "zoneinfo_winzones.go", "output file name")
+
+const wzURL = "https://raw.githubusercontent.com/unicode-org/cldr/main/common/supplemental/windowsZones.xml"
+
+type MapZone struct {
+	Other     string `xml:"other,attr"`
+	Territory string `xml:"territory,attr"`
+	Type      string `xml:"type,attr"`
+}
+
+type SupplementalData struct {
+	Zones []MapZone `xml:"windowsZones>mapTimezones>mapZone"`
+}
+
+type zone struct {
+	WinName  string
+	UnixName string
+}
+
+// readWindowsZones returns the zone CLDR uses for each Windows time
+// zone name: the one for territory "001", the "golden zone".
+func readWindowsZones() ([]zone, error) {
+	r, err := http.Get(wzURL)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var sd SupplementalData
+	err = xml.Unmarshal(data, &sd)
+	if err != nil {
+		return nil, err
+	}
+	var zs []zone
+	for _, z := range sd.Zones {
+		if z.Territory != "001" {
+			// to avoid dups. I don't know why.
+			continue
+		}
+		zs = append(zs, zone{WinName: z.Other, UnixName: z.Type})
+	}
+	sort.Slice(zs, func(i, j int) bool { return zs[i].UnixName < zs[j].UnixName })
+	return zs, nil
+}
+
+func main() {
+	flag.Parse()
+	zs, err := readWindowsZones()
+	if err != nil {
+		log.Fatal(err)
+	}
+	v := struct {
+		URL string
+		Zs  []zone
+	}{
+		wzURL,
+		zs,
+	}
+	var buf bytes.Buffer
+	err = template.Must(template.New("prog").Parse(prog)).Execute(&buf, v)
+	if err != nil {
+		log.Fatal(err)
+	}
+	data, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = ioutil.WriteFile(*filename, data, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+const prog = `
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by genwinzones.go; DO NOT EDIT.
+// Based on information from {{.URL}}
+
+package time
+
+// windowsZones maps Windows time zone names to IANA zone names.
+var windowsZones = map[string]string{
+{{range .Zs}}	"{{.WinName}}": "{{.UnixName}}",
+{{end}}}
+`
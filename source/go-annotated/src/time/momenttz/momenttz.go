@@ -0,0 +1,158 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package momenttz converts Locations to the packed format of the
+// moment-timezone JavaScript library, so that a Go server can serve
+// zone data to browsers from the same time zone database it uses.
+// 把 Location 导出为 moment-timezone 的打包格式，前后端共用同一份时区数据
+//
+// A packed zone is one line of fields separated by '|':
+//
+//	America/Los_Angeles|LMT PST PDT PWT PPT|7Q.W 80 70 70 70|0121213412121...|-3tFE0 1nEe0 ...
+//
+// the name, the abbreviations, the offsets in minutes west of UTC,
+// the index of the abbreviation and offset of each period, and the
+// end of each period but the last, as a difference in minutes from
+// the end of the one before, the first from the Unix epoch. Numbers
+// are written in base 60, with a fraction for seconds. The optional
+// sixth field, the population, is left out.
+//
+// The transitions computed by the POSIX TZ rule of a Location, for
+// times after those of its tzfile, are listed up to a given end:
+// moment-timezone has no rules.
+package momenttz
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Data is the bundle moment-timezone loads with moment.tz.load, ready
+// to be encoded with encoding/json.
+type Data struct {
+	Version string   `json:"version"`
+	Zones   []string `json:"zones"`
+	Links   []string `json:"links"` // "Target|Alias", such as "America/New_York|US/Eastern"
+}
+
+const base60 = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWX"
+
+// Pack returns the packed form of l, with its transitions before end.
+// It returns an error if l has more than 60 distinct zones or names
+// that cannot be packed.
+func Pack(l *time.Location, end time.Time) (string, error) {
+	return pack(l.String(), l, end)
+}
+
+func pack(name string, l *time.Location, end time.Time) (string, error) {
+	if name == "" || strings.ContainsAny(name, "| ") {
+		return "", errors.New("momenttz: cannot pack zone name " + name)
+	}
+
+	type key struct {
+		abbr   string
+		offset int
+	}
+	var (
+		keys    []key
+		indexOf = make(map[key]int)
+		indices []byte
+		untils  []string
+		last    int64
+	)
+	t := time.Time{}
+	for {
+		abbr, offset := t.In(l).Zone()
+		if abbr == "" || strings.ContainsAny(abbr, "| ") {
+			return "", errors.New("momenttz: cannot pack abbreviation " + abbr + " of " + name)
+		}
+		k := key{abbr, offset}
+		i, ok := indexOf[k]
+		if !ok {
+			i = len(keys)
+			if i == len(base60) {
+				return "", errors.New("momenttz: too many zones in " + name)
+			}
+			indexOf[k] = i
+			keys = append(keys, k)
+		}
+		indices = append(indices, base60[i])
+
+		next, ok := l.NextTransition(t)
+		if !ok || !next.Before(end) {
+			break
+		}
+		sec := next.Unix()
+		untils = append(untils, packSeconds(sec-last))
+		last, t = sec, next
+	}
+
+	abbrs := make([]string, len(keys))
+	offsets := make([]string, len(keys))
+	for i, k := range keys {
+		abbrs[i] = k.abbr
+		offsets[i] = packSeconds(int64(-k.offset))
+	}
+	return name + "|" + strings.Join(abbrs, " ") + "|" + strings.Join(offsets, " ") + "|" +
+		string(indices) + "|" + strings.Join(untils, " "), nil
+}
+
+// packSeconds returns sec as a number of minutes in base 60, with the
+// seconds, if any, as a base 60 fraction: 90 is "1.u".
+func packSeconds(sec int64) string {
+	neg := sec < 0
+	if neg {
+		sec = -sec
+	}
+	var b []byte
+	for min := sec / 60; min > 0; min /= 60 {
+		b = append(b, base60[min%60])
+	}
+	if len(b) == 0 && sec%60 == 0 {
+		return "0"
+	}
+	if neg {
+		b = append(b, '-')
+	}
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	if s := sec % 60; s != 0 {
+		b = append(b, '.', base60[s])
+	}
+	return string(b)
+}
+
+// PackAll returns the packed forms of all the zones time.AvailableZones
+// lists, with their transitions before end. Aliases, for which
+// time.CanonicalZoneName returns another zone with the same rules,
+// are given as links rather than zones. The version is that of
+// time.TZDataVersion, or "" if it is not known.
+func PackAll(end time.Time) (*Data, error) {
+	names, err := time.AvailableZones()
+	if err != nil {
+		return nil, err
+	}
+	d := &Data{Zones: []string{}, Links: []string{}}
+	d.Version, _ = time.TZDataVersion()
+	for _, name := range names {
+		l, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, err
+		}
+		if target := time.CanonicalZoneName(name); target != name {
+			if tl, err := time.LoadLocation(target); err == nil && tl.Equal(l) {
+				d.Links = append(d.Links, target+"|"+name)
+				continue
+			}
+		}
+		z, err := pack(name, l, end)
+		if err != nil {
+			return nil, err
+		}
+		d.Zones = append(d.Zones, z)
+	}
+	return d, nil
+}
@@ -0,0 +1,239 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pbtime converts between time.Time and time.Duration and the
+// protocol buffers well-known types google.protobuf.Timestamp and
+// google.protobuf.Duration, without depending on a protobuf library.
+// 与 protobuf 的 Timestamp、Duration 互相转换
+//
+// Both types are a count of seconds and a count of nanoseconds. The
+// messages defined here have the same fields, and can be copied to
+// and from those generated by protoc; Marshal and Unmarshal handle
+// their wire encoding directly:
+//
+//	ts, err := pbtime.TimestampOf(t)
+//	msg := &tspb.Timestamp{Seconds: ts.Seconds, Nanos: ts.Nanos}
+//
+// The conversions check the ranges the well-known types define.
+// Timestamps are limited to years 1 through 9999, and durations to
+// about 10,000 years; nanoseconds must be in [0, 999999999] in a
+// Timestamp, and have the sign of the seconds in a Duration.
+package pbtime
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// Seconds of 0001-01-01T00:00:00Z and 9999-12-31T23:59:59Z.
+	minTimestampSeconds = -62135596800
+	maxTimestampSeconds = 253402300799
+
+	// Seconds of 10,000 years of 365.25 days.
+	maxDurationSeconds = 315576000000
+)
+
+// A Timestamp is a google.protobuf.Timestamp: an instant as seconds
+// and nanoseconds since the Unix epoch, 1970-01-01T00:00:00Z.
+type Timestamp struct {
+	Seconds int64
+	Nanos   int32
+}
+
+// TimestampOf returns t as a Timestamp. The error reports a time
+// outside the range of Timestamp.
+func TimestampOf(t time.Time) (Timestamp, error) {
+	ts := Timestamp{t.Unix(), int32(t.Nanosecond())}
+	if err := ts.CheckValid(); err != nil {
+		return Timestamp{}, err
+	}
+	return ts, nil
+}
+
+// CheckValid reports whether ts is in the range of Timestamp, with
+// nanoseconds in [0, 999999999].
+func (ts Timestamp) CheckValid() error {
+	switch {
+	case ts.Seconds < minTimestampSeconds:
+		return fmt.Errorf("pbtime: timestamp (%d, %d) before 0001-01-01", ts.Seconds, ts.Nanos)
+	case ts.Seconds > maxTimestampSeconds:
+		return fmt.Errorf("pbtime: timestamp (%d, %d) after 9999-12-31", ts.Seconds, ts.Nanos)
+	case ts.Nanos < 0 || ts.Nanos >= 1e9:
+		return fmt.Errorf("pbtime: timestamp (%d, %d) has out-of-range nanos", ts.Seconds, ts.Nanos)
+	}
+	return nil
+}
+
+// Time returns ts as a time.Time in UTC, after checking it with
+// CheckValid.
+func (ts Timestamp) Time() (time.Time, error) {
+	if err := ts.CheckValid(); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(ts.Seconds, int64(ts.Nanos)).UTC(), nil
+}
+
+// A Duration is a google.protobuf.Duration: a signed span of time as
+// seconds and nanoseconds, both of the same sign.
+type Duration struct {
+	Seconds int64
+	Nanos   int32
+}
+
+// DurationOf returns d as a Duration. Every time.Duration is in the
+// range of Duration.
+func DurationOf(d time.Duration) Duration {
+	return Duration{int64(d / time.Second), int32(d % time.Second)}
+}
+
+// CheckValid reports whether d is in the range of Duration, with
+// nanoseconds in [-999999999, 999999999] and of the sign of the
+// seconds.
+func (d Duration) CheckValid() error {
+	switch {
+	case d.Seconds < -maxDurationSeconds || d.Seconds > maxDurationSeconds:
+		return fmt.Errorf("pbtime: duration (%d, %d) exceeds 10000 years", d.Seconds, d.Nanos)
+	case d.Nanos <= -1e9 || d.Nanos >= 1e9:
+		return fmt.Errorf("pbtime: duration (%d, %d) has out-of-range nanos", d.Seconds, d.Nanos)
+	case d.Seconds > 0 && d.Nanos < 0 || d.Seconds < 0 && d.Nanos > 0:
+		return fmt.Errorf("pbtime: duration (%d, %d) has seconds and nanos of different signs", d.Seconds, d.Nanos)
+	}
+	return nil
+}
+
+// Duration returns d as a time.Duration, after checking it with
+// CheckValid. The error also reports a d too long for a
+// time.Duration, which spans about 292 years.
+func (d Duration) Duration() (time.Duration, error) {
+	if err := d.CheckValid(); err != nil {
+		return 0, err
+	}
+	const maxSeconds = int64(1<<63-1) / int64(time.Second)
+	if d.Seconds > maxSeconds || d.Seconds < -maxSeconds {
+		return 0, fmt.Errorf("pbtime: duration (%d, %d) overflows time.Duration", d.Seconds, d.Nanos)
+	}
+	x := time.Duration(d.Seconds)*time.Second + time.Duration(d.Nanos)
+	if d.Seconds > 0 && x < 0 || d.Seconds < 0 && x > 0 {
+		return 0, fmt.Errorf("pbtime: duration (%d, %d) overflows time.Duration", d.Seconds, d.Nanos)
+	}
+	return x, nil
+}
+
+// Wire encoding. Both messages are field 1, seconds, and field 2,
+// nanos, as varints; fields with value zero are left out, and a
+// negative nanos takes ten bytes, as protobuf encodes int32.
+
+// Marshal returns the protobuf wire encoding of ts.
+func (ts Timestamp) Marshal() []byte {
+	return marshal(ts.Seconds, ts.Nanos)
+}
+
+// Unmarshal decodes the protobuf wire encoding of a Timestamp into ts.
+// It does not check the range of the result; see CheckValid.
+func (ts *Timestamp) Unmarshal(data []byte) error {
+	var err error
+	ts.Seconds, ts.Nanos, err = unmarshal(data)
+	return err
+}
+
+// Marshal returns the protobuf wire encoding of d.
+func (d Duration) Marshal() []byte {
+	return marshal(d.Seconds, d.Nanos)
+}
+
+// Unmarshal decodes the protobuf wire encoding of a Duration into d.
+// It does not check the range of the result; see CheckValid.
+func (d *Duration) Unmarshal(data []byte) error {
+	var err error
+	d.Seconds, d.Nanos, err = unmarshal(data)
+	return err
+}
+
+const (
+	tagSeconds = 1<<3 | 0 // field 1, varint
+	tagNanos   = 2<<3 | 0 // field 2, varint
+)
+
+func marshal(sec int64, nsec int32) []byte {
+	var b []byte
+	if sec != 0 {
+		b = append(b, tagSeconds)
+		b = appendVarint(b, uint64(sec))
+	}
+	if nsec != 0 {
+		b = append(b, tagNanos)
+		b = appendVarint(b, uint64(int64(nsec)))
+	}
+	return b
+}
+
+func appendVarint(b []byte, x uint64) []byte {
+	for x >= 0x80 {
+		b = append(b, byte(x)|0x80)
+		x >>= 7
+	}
+	return append(b, byte(x))
+}
+
+var errWire = errors.New("pbtime: invalid wire encoding")
+
+// unmarshal decodes the two fields. As protobuf requires, the last
+// occurrence of a field wins, and unknown fields are skipped.
+func unmarshal(data []byte) (sec int64, nsec int32, err error) {
+	for len(data) > 0 {
+		tag, n := varint(data)
+		if n == 0 {
+			return 0, 0, errWire
+		}
+		data = data[n:]
+		if tag>>3 == 0 {
+			return 0, 0, errWire
+		}
+		var v uint64
+		switch tag & 7 {
+		case 0: // varint
+			if v, n = varint(data); n == 0 {
+				return 0, 0, errWire
+			}
+		case 1: // 64-bit
+			n = 8
+		case 2: // length-delimited
+			l, m := varint(data)
+			if m == 0 || l > uint64(len(data)-m) {
+				return 0, 0, errWire
+			}
+			n = m + int(l)
+		case 5: // 32-bit
+			n = 4
+		default:
+			return 0, 0, errWire
+		}
+		if n > len(data) {
+			return 0, 0, errWire
+		}
+		data = data[n:]
+		switch tag {
+		case tagSeconds:
+			sec = int64(v)
+		case tagNanos:
+			nsec = int32(v)
+		}
+	}
+	return sec, nsec, nil
+}
+
+// varint decodes the varint at the start of b, and returns it and the
+// number of bytes read, or 0 if b does not start with a valid varint.
+func varint(b []byte) (uint64, int) {
+	var x uint64
+	for i := 0; i < len(b) && i < 10; i++ {
+		x |= uint64(b[i]&0x7f) << (7 * uint(i))
+		if b[i] < 0x80 {
+			return x, i + 1
+		}
+	}
+	return 0, 0
+}
@@ -0,0 +1,174 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package reltime
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Locale supplies the words of a Formatter.
+// 相对时间格式化所用的语言
+type Locale struct {
+	// JustNow is written for a time closer to the reference time
+	// than one of the smallest unit, as "just now".
+	JustNow string
+
+	// Past and Future are the patterns for times before and after
+	// the reference time, with {0} standing for the amount, as
+	// "{0} ago" and "in {0}".
+	Past, Future string
+
+	// Separator goes between the units of an amount of several, as
+	// " " in "2 hours 5 minutes".
+	Separator string
+
+	// Unit returns n of unit u, as "1 minute" or "3 days".
+	Unit func(n int64, u Unit) string
+}
+
+var englishUnits = [...]string{
+	Second: "second",
+	Minute: "minute",
+	Hour:   "hour",
+	Day:    "day",
+	Week:   "week",
+	Month:  "month",
+	Year:   "year",
+}
+
+// English is the Locale used by default.
+var English = &Locale{
+	JustNow:   "just now",
+	Past:      "{0} ago",
+	Future:    "in {0}",
+	Separator: " ",
+	Unit: func(n int64, u Unit) string {
+		s := strconv.FormatInt(n, 10) + " " + englishUnits[u]
+		if n != 1 {
+			s += "s"
+		}
+		return s
+	},
+}
+
+var chineseUnits = [...]string{
+	Second: "秒",
+	Minute: "分钟",
+	Hour:   "小时",
+	Day:    "天",
+	Week:   "周",
+	Month:  "个月",
+	Year:   "年",
+}
+
+// Chinese is a Locale for Simplified Chinese, as "3分钟前".
+var Chinese = &Locale{
+	JustNow: "刚刚",
+	Past:    "{0}前",
+	Future:  "{0}后",
+	Unit: func(n int64, u Unit) string {
+		return strconv.FormatInt(n, 10) + chineseUnits[u]
+	},
+}
+
+// unitLengths are the lengths of the units. Months and years are
+// approximated as 30 and 365 days.
+var unitLengths = [...]time.Duration{
+	Second: time.Second,
+	Minute: time.Minute,
+	Hour:   time.Hour,
+	Day:    24 * time.Hour,
+	Week:   7 * 24 * time.Hour,
+	Month:  30 * 24 * time.Hour,
+	Year:   365 * 24 * time.Hour,
+}
+
+// A Formatter writes times relative to a reference time, as in
+// "3 minutes ago" or "in 2 days". The zero Formatter writes one unit,
+// from seconds to years, in English.
+type Formatter struct {
+	// Smallest and Largest are the smallest and largest units used.
+	// A time closer than one Smallest unit is written as "just now".
+	// Zero means Second and Year, so that the largest unit can be
+	// any of them, as Second for "90 seconds ago"; a Largest smaller
+	// than Smallest means Smallest.
+	Smallest, Largest Unit
+
+	// MaxUnits is the number of units written at most, counting
+	// from the largest non-zero one, as 2 for "2 hours 5 minutes
+	// ago". Units whose count is zero are left out. Zero means 1.
+	MaxUnits int
+
+	// Locale supplies the words. Nil means English.
+	Locale *Locale
+}
+
+// Format returns t relative to ref, as "3 minutes ago" or
+// "in 2 days", using the zero Formatter.
+func Format(t, ref time.Time) string {
+	return Formatter{}.Format(t, ref)
+}
+
+// Format returns t relative to ref as described by f. Amounts are
+// rounded toward zero, so 119 seconds ago is "1 minute ago"; months
+// and years count as 30 and 365 days.
+func (f Formatter) Format(t, ref time.Time) string {
+	return f.FormatDuration(t.Sub(ref))
+}
+
+// FormatDuration is like Format for a time d after the reference
+// time, or before it if d is negative.
+func (f Formatter) FormatDuration(d time.Duration) string {
+	lc := f.Locale
+	if lc == nil {
+		lc = English
+	}
+	smallest, largest := f.Smallest, f.Largest
+	if smallest == 0 {
+		smallest = Second
+	}
+	if largest == 0 {
+		largest = Year
+	}
+	if largest < smallest {
+		largest = smallest
+	}
+	max := f.MaxUnits
+	if max <= 0 {
+		max = 1
+	}
+
+	pattern := lc.Future
+	if d < 0 {
+		pattern = lc.Past
+		d = -d
+		if d < 0 {
+			// -d overflowed for the minimum Duration.
+			d = 1<<63 - 1
+		}
+	}
+	var parts []string
+	first := Unit(0) // the largest non-zero unit
+	for u := largest; u >= smallest; u-- {
+		if first != 0 && int(first-u) >= max {
+			break
+		}
+		n := d / unitLengths[u]
+		if n == 0 {
+			continue
+		}
+		if first == 0 {
+			first = u
+		}
+		parts = append(parts, lc.Unit(int64(n), u))
+		d -= n * unitLengths[u]
+	}
+	if len(parts) == 0 {
+		return lc.JustNow
+	}
+	return strings.Replace(pattern, "{0}", strings.Join(parts, lc.Separator), 1)
+}
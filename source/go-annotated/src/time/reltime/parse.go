@@ -0,0 +1,446 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package reltime parses and formats times relative to a reference
+// time in the way people write them, as in "2h ago", "in 3 days",
+// "next Tuesday 5pm" and "3 minutes ago", for command line tools,
+// chat bots and activity feeds.
+// 相对时间的解析与格式化，例如 "2h ago"、"next Tuesday 5pm"
+//
+//	ref := time.Date(2024, time.March, 7, 10, 0, 0, 0, time.UTC) // a Thursday
+//	t, _ := reltime.Parse("next tuesday 5pm", ref)
+//	// t is 2024-03-12 17:00:00 UTC
+package reltime
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parse returns the instant described by s relative to ref, in the
+// Location of ref. See ParseInLocation.
+func Parse(s string, ref time.Time) (time.Time, error) {
+	return ParseInLocation(s, ref, ref.Location())
+}
+
+// ParseInLocation returns the instant described by s relative to
+// ref, reading days and wall clock times in loc, and returns it
+// in loc. Case is ignored. The expressions understood are:
+//
+//	now, today                  ref itself
+//	tomorrow, yesterday         ref ± 1 day
+//	in 2 hours, 2 hours ago     ref ± an amount
+//	2h30m ago, +90m, -1w        the same, with compact units
+//	3 days from now, 1 day later
+//	next week, last month       ref ± 1 unit
+//	tuesday, this tue           midnight starting the next Tuesday,
+//	                            which is today if ref is a Tuesday
+//	next tuesday, last tuesday  midnight starting the next or last
+//	                            Tuesday other than today
+//	5pm, 5:30 pm, 17:00, noon   that wall clock time on the day
+//	                            (optionally preceded by "at")
+//
+// Amounts are whole numbers or "a" or "an", in seconds, minutes,
+// hours, days, weeks, months or years, abbreviated s, sec, m, min, h,
+// hr, d, w, wk, mo, y or yr. Several amounts may follow each other,
+// as in "1 day 3 hours ago". Days and longer keep the wall clock
+// time, so "in 1 day" across a daylight saving time change is not
+// 24 hours later; hours and shorter are exact.
+//
+// The parts may be combined, as in "tomorrow at noon" or "next friday
+// 9am". A wall clock time skipped or repeated by a change of zone
+// offset resolves as time.ResolveLocal with time.ResolveEarlier
+// does.
+func ParseInLocation(s string, ref time.Time, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		panic("reltime: missing Location in call to ParseInLocation")
+	}
+	p := parser{toks: tokenize(s)}
+	if len(p.toks) == 0 {
+		return time.Time{}, errors.New("reltime: empty time expression")
+	}
+	if err := p.parse(); err != nil {
+		return time.Time{}, errors.New("reltime: cannot parse " + strconv.Quote(s) + ": " + err.Error())
+	}
+	return p.resolve(ref.In(loc), loc), nil
+}
+
+// units maps unit names to units.
+var units = map[string]Unit{
+	"s": Second, "sec": Second, "secs": Second, "second": Second, "seconds": Second,
+	"m": Minute, "min": Minute, "mins": Minute, "minute": Minute, "minutes": Minute,
+	"h": Hour, "hr": Hour, "hrs": Hour, "hour": Hour, "hours": Hour,
+	"d": Day, "day": Day, "days": Day,
+	"w": Week, "wk": Week, "wks": Week, "week": Week, "weeks": Week,
+	"mo": Month, "month": Month, "months": Month,
+	"y": Year, "yr": Year, "yrs": Year, "year": Year, "years": Year,
+}
+
+// A Unit is a unit of a relative time. The zero Unit is none of
+// them: in a Formatter, it stands for the default.
+type Unit int
+
+const (
+	Second Unit = iota + 1
+	Minute
+	Hour
+	Day
+	Week
+	Month
+	Year
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// A parser holds the state of a ParseInLocation call.
+type parser struct {
+	toks []string
+	i    int
+
+	years, months, days int           // calendar offsets
+	exact               time.Duration // exact offset
+
+	weekday    time.Weekday
+	weekdayDir int // 0 for none, 1 this, 2 next, -1 last
+
+	hour, min, sec int
+	clockSet       bool
+}
+
+// tokenize splits s into lower case words at spaces and commas.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+}
+
+func (p *parser) peek() string {
+	if p.i < len(p.toks) {
+		return p.toks[p.i]
+	}
+	return ""
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	p.i++
+	return t
+}
+
+func unexpected(tok string) error {
+	if tok == "" {
+		return errors.New("unexpected end")
+	}
+	return errors.New("unexpected " + strconv.Quote(tok))
+}
+
+func (p *parser) parse() error {
+	for p.i < len(p.toks) {
+		tok := p.next()
+		switch tok {
+		case "now", "today", "and":
+		case "tomorrow":
+			p.days++
+		case "yesterday":
+			p.days--
+		case "in":
+			if err := p.amounts(1); err != nil {
+				return err
+			}
+		case "at":
+			if err := p.clock(p.next(), true); err != nil {
+				return err
+			}
+		case "noon":
+			p.setClock(12, 0, 0)
+		case "midnight":
+			p.setClock(0, 0, 0)
+		case "next", "last", "this":
+			if err := p.relative(tok); err != nil {
+				return err
+			}
+		default:
+			if wd, ok := weekdays[tok]; ok {
+				p.weekday, p.weekdayDir = wd, 1
+				continue
+			}
+			if tok[0] == '+' || tok[0] == '-' {
+				sign := 1
+				if tok[0] == '-' {
+					sign = -1
+				}
+				as, ok := compact(tok[1:])
+				if !ok {
+					return unexpected(tok)
+				}
+				p.add(as, sign)
+				continue
+			}
+			if tok[0] >= '0' && tok[0] <= '9' || tok == "a" || tok == "an" {
+				p.i--
+				if p.isClock() {
+					if err := p.clock(p.next(), false); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := p.amounts(0); err != nil {
+					return err
+				}
+				continue
+			}
+			return unexpected(tok)
+		}
+	}
+	return nil
+}
+
+// An amount is a count of a unit.
+type amount struct {
+	n int
+	u Unit
+}
+
+// amounts parses one or more amounts. If sign is 0, they must be
+// followed by "ago", "from now" or "later", which give the sign.
+func (p *parser) amounts(sign int) error {
+	var as []amount
+	for {
+		tok := p.peek()
+		if tok == "and" && len(as) > 0 {
+			p.next()
+			continue
+		}
+		if c, ok := compact(tok); ok {
+			p.next()
+			as = append(as, c...)
+			continue
+		}
+		n, err := strconv.Atoi(tok)
+		if tok == "a" || tok == "an" {
+			n, err = 1, nil
+		}
+		if err != nil || n < 0 {
+			break
+		}
+		p.next()
+		u, ok := units[p.peek()]
+		if !ok {
+			return unexpected(p.peek())
+		}
+		p.next()
+		as = append(as, amount{n, u})
+	}
+	if len(as) == 0 {
+		return unexpected(p.peek())
+	}
+	if sign == 0 {
+		switch p.next() {
+		case "ago":
+			sign = -1
+		case "later":
+			sign = 1
+		case "from":
+			if p.next() != "now" {
+				return unexpected(p.toks[p.i-1])
+			}
+			sign = 1
+		default:
+			return unexpected(p.toks[p.i-1])
+		}
+	}
+	p.add(as, sign)
+	return nil
+}
+
+// compact parses amounts written without spaces, as in "2h30m".
+func compact(tok string) ([]amount, bool) {
+	var as []amount
+	for tok != "" {
+		i := 0
+		for i < len(tok) && '0' <= tok[i] && tok[i] <= '9' {
+			i++
+		}
+		j := i
+		for j < len(tok) && 'a' <= tok[j] && tok[j] <= 'z' {
+			j++
+		}
+		n, err := strconv.Atoi(tok[:i])
+		u, ok := units[tok[i:j]]
+		if err != nil || !ok {
+			return nil, false
+		}
+		as = append(as, amount{n, u})
+		tok = tok[j:]
+	}
+	return as, len(as) > 0
+}
+
+func (p *parser) add(as []amount, sign int) {
+	for _, a := range as {
+		n := sign * a.n
+		switch a.u {
+		case Second:
+			p.exact += time.Duration(n) * time.Second
+		case Minute:
+			p.exact += time.Duration(n) * time.Minute
+		case Hour:
+			p.exact += time.Duration(n) * time.Hour
+		case Day:
+			p.days += n
+		case Week:
+			p.days += 7 * n
+		case Month:
+			p.months += n
+		case Year:
+			p.years += n
+		}
+	}
+}
+
+// relative parses what follows "next", "last" or "this".
+func (p *parser) relative(which string) error {
+	tok := p.next()
+	if wd, ok := weekdays[tok]; ok {
+		p.weekday = wd
+		switch which {
+		case "this":
+			p.weekdayDir = 1
+		case "next":
+			p.weekdayDir = 2
+		case "last":
+			p.weekdayDir = -1
+		}
+		return nil
+	}
+	u, ok := units[tok]
+	if !ok || which == "this" {
+		return unexpected(tok)
+	}
+	sign := 1
+	if which == "last" {
+		sign = -1
+	}
+	p.add([]amount{{1, u}}, sign)
+	return nil
+}
+
+// isClock reports whether the next tokens are a wall clock time
+// rather than an amount.
+func (p *parser) isClock() bool {
+	tok := p.peek()
+	if strings.IndexByte(tok, ':') >= 0 || strings.HasSuffix(tok, "am") || strings.HasSuffix(tok, "pm") {
+		return true
+	}
+	if p.i+1 < len(p.toks) {
+		next := p.toks[p.i+1]
+		return next == "am" || next == "pm"
+	}
+	return false
+}
+
+// clock parses a wall clock time, such as "5pm", "5:30 pm" or
+// "17:00". If bare is set, a lone number is an hour.
+func (p *parser) clock(tok string, bare bool) error {
+	if tok == "noon" {
+		p.setClock(12, 0, 0)
+		return nil
+	}
+	if tok == "midnight" {
+		p.setClock(0, 0, 0)
+		return nil
+	}
+	s, suffix := tok, ""
+	if strings.HasSuffix(s, "am") || strings.HasSuffix(s, "pm") {
+		s, suffix = s[:len(s)-2], s[len(s)-2:]
+	} else if next := p.peek(); next == "am" || next == "pm" {
+		suffix = p.next()
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 || len(parts) == 1 && suffix == "" && !bare {
+		return unexpected(tok)
+	}
+	var f [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || i > 0 && len(part) != 2 {
+			return unexpected(tok)
+		}
+		f[i] = n
+	}
+	h, m, sec := f[0], f[1], f[2]
+	switch suffix {
+	case "am", "pm":
+		if h < 1 || h > 12 {
+			return unexpected(tok)
+		}
+		h %= 12
+		if suffix == "pm" {
+			h += 12
+		}
+	default:
+		if h > 23 {
+			return unexpected(tok)
+		}
+	}
+	if m > 59 || sec > 59 {
+		return unexpected(tok)
+	}
+	p.setClock(h, m, sec)
+	return nil
+}
+
+func (p *parser) setClock(h, m, s int) {
+	p.hour, p.min, p.sec, p.clockSet = h, m, s, true
+}
+
+// resolve applies the parsed offsets to ref, which is in loc.
+func (p *parser) resolve(ref time.Time, loc *time.Location) time.Time {
+	y, mo, d := ref.Date()
+	h, mi, s := ref.Clock()
+	ns := ref.Nanosecond()
+	if p.months != 0 || p.years != 0 {
+		// Clamp to the end of a shorter month, as "in 1 month" from
+		// January 31 means the end of February.
+		y, mo = y+p.years, mo+time.Month(p.months)
+		if last := time.Date(y, mo+1, 0, 0, 0, 0, 0, time.UTC).Day(); d > last {
+			d = last
+		}
+	}
+	d += p.days
+	if p.weekdayDir != 0 {
+		wd := time.Date(y, mo, d, 0, 0, 0, 0, time.UTC).Weekday()
+		diff := int(p.weekday - wd)
+		switch p.weekdayDir {
+		case 1:
+			diff = (diff + 7) % 7
+		case 2:
+			diff = (diff+6)%7 + 1
+		case -1:
+			diff = -((-diff+6)%7 + 1)
+		}
+		d += diff
+		h, mi, s, ns = 0, 0, 0, 0
+	}
+	if p.clockSet {
+		h, mi, s, ns = p.hour, p.min, p.sec, 0
+	}
+	var t time.Time
+	if p.years == 0 && p.months == 0 && p.days == 0 && p.weekdayDir == 0 && !p.clockSet {
+		t = ref
+	} else {
+		t, _, _ = time.ResolveLocal(y, mo, d, h, mi, s, ns, loc, time.ResolveEarlier)
+	}
+	return t.Add(p.exact).In(loc)
+}
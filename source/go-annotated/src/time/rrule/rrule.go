@@ -0,0 +1,531 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rrule expands RFC 5545 (iCalendar) recurrence rules, such
+// as "every second Tuesday at 09:00", into instants in a Location.
+// RFC 5545 重复规则（RRULE）的展开
+//
+//	chicago, _ := time.LoadLocation("America/Chicago")
+//	r, _ := rrule.Parse("FREQ=MONTHLY;BYDAY=2TU;BYHOUR=9;BYMINUTE=0;COUNT=6")
+//	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, chicago)
+//	for it := r.Iter(start); ; {
+//		t, ok := it.Next()
+//		if !ok {
+//			break
+//		}
+//		fmt.Println(t) // 09:00 CST or CDT, as in effect on each date
+//	}
+//
+// Occurrences are wall clock times in the Location of the start time,
+// so a meeting at 09:00 stays at 09:00 across daylight saving time
+// changes. As RFC 5545 requires, a time skipped by a transition is
+// moved forward by the length of the gap, and a repeated time gives
+// its first occurrence; see time.ResolveLocal.
+//
+// The supported rule parts are FREQ (YEARLY, MONTHLY, WEEKLY or
+// DAILY), INTERVAL, COUNT, UNTIL, BYMONTH, BYMONTHDAY, BYDAY, BYHOUR,
+// BYMINUTE, BYSECOND, BYSETPOS and WKST. BYYEARDAY, BYWEEKNO and the
+// HOURLY, MINUTELY and SECONDLY frequencies are not.
+package rrule
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"time/civil"
+)
+
+// A Frequency is the FREQ of a Rule: the period at which it repeats.
+type Frequency int
+
+const (
+	Yearly Frequency = iota
+	Monthly
+	Weekly
+	Daily
+)
+
+var freqNames = [...]string{"YEARLY", "MONTHLY", "WEEKLY", "DAILY"}
+
+func (f Frequency) String() string {
+	if 0 <= f && int(f) < len(freqNames) {
+		return freqNames[f]
+	}
+	return "Frequency(" + strconv.Itoa(int(f)) + ")"
+}
+
+// A WeekdayNum is an element of BYDAY: a weekday, and for monthly and
+// yearly rules an optional position N, such as 2 for the second
+// Tuesday of the period or -1 for its last Friday. N is 0 for every
+// such weekday of the period.
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// A Rule is a recurrence rule, the value of an iCalendar RRULE.
+type Rule struct {
+	Freq     Frequency
+	Interval int       // periods between occurrences; 0 means 1
+	Count    int       // number of occurrences; 0 means no limit
+	Until    time.Time // last possible occurrence; zero means no limit
+
+	ByMonth    []time.Month
+	ByMonthDay []int // 1 to 31, or -1 for the last day and so on
+	ByDay      []WeekdayNum
+	ByHour     []int
+	ByMinute   []int
+	BySecond   []int
+	BySetPos   []int // positions within each period's occurrences
+
+	// WeekStart is the day weeks start on, for weekly rules with an
+	// Interval above 1. RFC 5545 and Parse use Monday by default.
+	WeekStart time.Weekday
+}
+
+var dayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Parse parses a rule in the RFC 5545 RECUR format, such as
+// "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10", optionally preceded by "RRULE:".
+// UNTIL may be a date, "20241231", taken as the end of that day in
+// UTC, or a UTC date-time, "20241231T235959Z".
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(s, "RRULE:")
+	r := &Rule{WeekStart: time.Monday}
+	haveFreq := false
+	for _, part := range strings.Split(s, ";") {
+		i := strings.IndexByte(part, '=')
+		if i < 0 {
+			return nil, errors.New("rrule: malformed part " + strconv.Quote(part))
+		}
+		name, val := strings.ToUpper(part[:i]), part[i+1:]
+		var err error
+		switch name {
+		case "FREQ":
+			err = errors.New("rrule: unsupported FREQ " + val)
+			for f, n := range freqNames {
+				if strings.EqualFold(val, n) {
+					r.Freq, err = Frequency(f), nil
+				}
+			}
+			haveFreq = true
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(val)
+			if err == nil && r.Interval < 1 {
+				err = errors.New("rrule: INTERVAL must be positive")
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(val)
+			if err == nil && r.Count < 1 {
+				err = errors.New("rrule: COUNT must be positive")
+			}
+		case "UNTIL":
+			r.Until, err = parseUntil(val)
+		case "BYMONTH":
+			var ns []int
+			ns, err = parseInts(val, 1, 12, false)
+			for _, n := range ns {
+				r.ByMonth = append(r.ByMonth, time.Month(n))
+			}
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseInts(val, 1, 31, true)
+		case "BYDAY":
+			r.ByDay, err = parseDays(val)
+		case "BYHOUR":
+			r.ByHour, err = parseInts(val, 0, 23, false)
+		case "BYMINUTE":
+			r.ByMinute, err = parseInts(val, 0, 59, false)
+		case "BYSECOND":
+			r.BySecond, err = parseInts(val, 0, 59, false)
+		case "BYSETPOS":
+			r.BySetPos, err = parseInts(val, 1, 366, true)
+		case "WKST":
+			r.WeekStart, err = parseDay(val)
+		default:
+			err = errors.New("rrule: unsupported rule part " + name)
+		}
+		if err != nil {
+			if _, ok := err.(*strconv.NumError); ok {
+				err = errors.New("rrule: bad " + name + " value " + strconv.Quote(val))
+			}
+			return nil, err
+		}
+	}
+	if !haveFreq {
+		return nil, errors.New("rrule: missing FREQ")
+	}
+	if r.Count != 0 && !r.Until.IsZero() {
+		return nil, errors.New("rrule: COUNT and UNTIL are exclusive")
+	}
+	return r, nil
+}
+
+func parseUntil(val string) (time.Time, error) {
+	if len(val) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", val, time.UTC)
+		return t.Add(24*time.Hour - time.Second), err
+	}
+	return time.ParseInLocation("20060102T150405Z", val, time.UTC)
+}
+
+// parseInts parses a comma-separated list of integers between lo and
+// hi, or between -hi and -lo if neg is set.
+func parseInts(val string, lo, hi int, neg bool) ([]int, error) {
+	var ns []int
+	for _, f := range strings.Split(val, ",") {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, err
+		}
+		m := n
+		if neg && m < 0 {
+			m = -m
+		}
+		if m < lo || hi < m {
+			return nil, errors.New("rrule: value " + f + " out of range")
+		}
+		ns = append(ns, n)
+	}
+	return ns, nil
+}
+
+func parseDays(val string) ([]WeekdayNum, error) {
+	var ds []WeekdayNum
+	for _, f := range strings.Split(val, ",") {
+		if len(f) < 2 {
+			return nil, errors.New("rrule: bad BYDAY value " + strconv.Quote(f))
+		}
+		var d WeekdayNum
+		var err error
+		d.Day, err = parseDay(f[len(f)-2:])
+		if err != nil {
+			return nil, err
+		}
+		if n := f[:len(f)-2]; n != "" {
+			d.N, err = strconv.Atoi(n)
+			if err != nil || d.N == 0 || d.N < -53 || 53 < d.N {
+				return nil, errors.New("rrule: bad BYDAY value " + strconv.Quote(f))
+			}
+		}
+		ds = append(ds, d)
+	}
+	return ds, nil
+}
+
+func parseDay(s string) (time.Weekday, error) {
+	for i, n := range dayNames {
+		if strings.EqualFold(s, n) {
+			return time.Weekday(i), nil
+		}
+	}
+	return 0, errors.New("rrule: bad weekday " + strconv.Quote(s))
+}
+
+// String returns r in the RFC 5545 RECUR format, without "RRULE:".
+func (r *Rule) String() string {
+	var b []string
+	add := func(name string, vals ...string) {
+		if len(vals) > 0 {
+			b = append(b, name+"="+strings.Join(vals, ","))
+		}
+	}
+	ints := func(ns []int) []string {
+		var s []string
+		for _, n := range ns {
+			s = append(s, strconv.Itoa(n))
+		}
+		return s
+	}
+	add("FREQ", r.Freq.String())
+	if r.Interval > 1 {
+		add("INTERVAL", strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		add("COUNT", strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		add("UNTIL", r.Until.UTC().Format("20060102T150405Z"))
+	}
+	var months []string
+	for _, m := range r.ByMonth {
+		months = append(months, strconv.Itoa(int(m)))
+	}
+	add("BYMONTH", months...)
+	add("BYMONTHDAY", ints(r.ByMonthDay)...)
+	var days []string
+	for _, d := range r.ByDay {
+		s := dayNames[d.Day%7]
+		if d.N != 0 {
+			s = strconv.Itoa(d.N) + s
+		}
+		days = append(days, s)
+	}
+	add("BYDAY", days...)
+	add("BYHOUR", ints(r.ByHour)...)
+	add("BYMINUTE", ints(r.ByMinute)...)
+	add("BYSECOND", ints(r.BySecond)...)
+	add("BYSETPOS", ints(r.BySetPos)...)
+	if r.WeekStart != time.Monday {
+		add("WKST", dayNames[r.WeekStart%7])
+	}
+	return strings.Join(b, ";")
+}
+
+// maxEmptyPeriods bounds the periods searched without finding an
+// occurrence, so that rules that can never match, such as February
+// 30, end instead of looping forever.
+const maxEmptyPeriods = 2000
+
+// An Iter produces the occurrences of a Rule in order.
+type Iter struct {
+	r      *Rule
+	start  time.Time
+	period civil.Date // first day of the next period to expand
+	buf    []time.Time
+	n      int // occurrences returned
+	empty  int // consecutive periods without occurrences
+	done   bool
+}
+
+// Iter returns an iterator over the occurrences of r starting at
+// start, whose Location and wall clock time give those of the
+// occurrences. As in iCalendar, start is the first occurrence
+// when it matches r, and otherwise precedes the first.
+func (r *Rule) Iter(start time.Time) *Iter {
+	it := &Iter{r: r, start: start}
+	d := civil.DateOf(start)
+	switch r.Freq {
+	case Yearly:
+		d.Month, d.Day = time.January, 1
+	case Monthly:
+		d.Day = 1
+	case Weekly:
+		d = d.AddDays(-((int(d.Weekday()) - int(r.WeekStart) + 7) % 7))
+	}
+	it.period = d
+	return it
+}
+
+// Next returns the next occurrence, or false when there are no more.
+func (it *Iter) Next() (time.Time, bool) {
+	for len(it.buf) == 0 {
+		if it.done || it.empty >= maxEmptyPeriods {
+			return time.Time{}, false
+		}
+		it.expand()
+	}
+	t := it.buf[0]
+	it.buf = it.buf[1:]
+	if (!it.r.Until.IsZero() && t.After(it.r.Until)) || (it.r.Count > 0 && it.n >= it.r.Count) {
+		it.done, it.buf = true, nil
+		return time.Time{}, false
+	}
+	it.n++
+	return t, true
+}
+
+// All returns the occurrences of r from start, at most max of them.
+func (r *Rule) All(start time.Time, max int) []time.Time {
+	var ts []time.Time
+	for it := r.Iter(start); len(ts) < max; {
+		t, ok := it.Next()
+		if !ok {
+			break
+		}
+		ts = append(ts, t)
+	}
+	return ts
+}
+
+// Between returns the occurrences of r from start that are at or
+// after from and before to.
+func (r *Rule) Between(start, from, to time.Time) []time.Time {
+	var ts []time.Time
+	for it := r.Iter(start); ; {
+		t, ok := it.Next()
+		if !ok || !t.Before(to) {
+			break
+		}
+		if !t.Before(from) {
+			ts = append(ts, t)
+		}
+	}
+	return ts
+}
+
+// expand fills it.buf with the occurrences in the period starting
+// at it.period, and moves it.period to the next period.
+func (it *Iter) expand() {
+	r := it.r
+	p := it.period
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	// Only the first of the interval's periods is expanded.
+	end := advance(p, r.Freq, 1)
+	next := advance(p, r.Freq, interval)
+	it.period = next
+
+	var days []civil.Date
+	for d := p; d.Before(end); d = d.AddDays(1) {
+		if it.matchDate(d) {
+			days = append(days, d)
+		}
+	}
+
+	loc := it.start.Location()
+	tod := civil.TimeOfDayOf(it.start)
+	hours := orDefault(r.ByHour, tod.Hour)
+	mins := orDefault(r.ByMinute, tod.Minute)
+	secs := orDefault(r.BySecond, tod.Second)
+	var ts []time.Time
+	for _, d := range days {
+		for _, h := range hours {
+			for _, m := range mins {
+				for _, s := range secs {
+					ts = append(ts, d.At(civil.TimeOfDay{Hour: h, Minute: m, Second: s}, loc))
+				}
+			}
+		}
+	}
+	sort.Slice(ts, func(i, j int) bool { return ts[i].Before(ts[j]) })
+	if len(r.BySetPos) > 0 {
+		ts = setPos(ts, r.BySetPos)
+	}
+
+	it.buf = it.buf[:0]
+	for _, t := range ts {
+		if !t.Before(it.start) {
+			it.buf = append(it.buf, t)
+		}
+	}
+	if len(it.buf) == 0 {
+		it.empty++
+	} else {
+		it.empty = 0
+	}
+}
+
+// matchDate reports whether day d of the current period is selected
+// by the date parts of the rule, or by the start date when the rule
+// has none of the parts its frequency needs.
+func (it *Iter) matchDate(d civil.Date) bool {
+	r := it.r
+	s := civil.DateOf(it.start)
+	if len(r.ByMonth) > 0 && !containsMonth(r.ByMonth, d.Month) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 && !matchMonthDay(r.ByMonthDay, d) {
+		return false
+	}
+	if len(r.ByDay) > 0 && !it.matchDay(d) {
+		return false
+	}
+	// Without the parts that pick days within the period, take
+	// those of the start date.
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		switch r.Freq {
+		case Yearly:
+			if len(r.ByMonth) == 0 && d.Month != s.Month {
+				return false
+			}
+			return d.Day == s.Day
+		case Monthly:
+			return d.Day == s.Day
+		case Weekly:
+			return d.Weekday() == s.Weekday()
+		}
+	}
+	return true
+}
+
+// matchDay reports whether d matches an element of BYDAY. Positions
+// count within the month for monthly rules and yearly rules with
+// BYMONTH, and within the year otherwise.
+func (it *Iter) matchDay(d civil.Date) bool {
+	inMonth := it.r.Freq == Monthly || (it.r.Freq == Yearly && len(it.r.ByMonth) > 0)
+	for _, wd := range it.r.ByDay {
+		if d.Weekday() != wd.Day {
+			continue
+		}
+		if wd.N == 0 || it.r.Freq == Weekly || it.r.Freq == Daily {
+			return true
+		}
+		var first, last civil.Date
+		if inMonth {
+			first = civil.Date{Year: d.Year, Month: d.Month, Day: 1}
+			last = first.AddDate(0, 1, -1)
+		} else {
+			first = civil.Date{Year: d.Year, Month: time.January, Day: 1}
+			last = civil.Date{Year: d.Year, Month: time.December, Day: 31}
+		}
+		if wd.N > 0 && d.DaysSince(first)/7+1 == wd.N {
+			return true
+		}
+		if wd.N < 0 && last.DaysSince(d)/7+1 == -wd.N {
+			return true
+		}
+	}
+	return false
+}
+
+// advance returns the first day of the n-th period after the one
+// starting at p.
+func advance(p civil.Date, f Frequency, n int) civil.Date {
+	switch f {
+	case Yearly:
+		return p.AddDate(n, 0, 0)
+	case Monthly:
+		return p.AddDate(0, n, 0)
+	case Weekly:
+		return p.AddDays(7 * n)
+	}
+	return p.AddDays(n)
+}
+
+func matchMonthDay(days []int, d civil.Date) bool {
+	n := civil.Date{Year: d.Year, Month: d.Month + 1, Day: 1}.AddDays(-1).Day
+	for _, md := range days {
+		if md == d.Day || (md < 0 && n+1+md == d.Day) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMonth(ms []time.Month, m time.Month) bool {
+	for _, x := range ms {
+		if x == m {
+			return true
+		}
+	}
+	return false
+}
+
+func orDefault(ns []int, def int) []int {
+	if len(ns) == 0 {
+		return []int{def}
+	}
+	ns = append([]int(nil), ns...)
+	sort.Ints(ns)
+	return ns
+}
+
+// setPos returns the elements of ts at the given 1-based positions,
+// counted from the end if negative, in order.
+func setPos(ts []time.Time, pos []int) []time.Time {
+	var out []time.Time
+	for i := range ts {
+		for _, p := range pos {
+			if p == i+1 || p == i-len(ts) {
+				out = append(out, ts[i])
+				break
+			}
+		}
+	}
+	return out
+}
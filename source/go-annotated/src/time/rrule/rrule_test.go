@@ -0,0 +1,39 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rrule_test
+
+import (
+	"testing"
+	"time"
+	"time/rrule"
+)
+
+// TestUntilDefaultLocation checks that UNTIL is read as UTC whatever
+// time.SetDefaultLocation says.
+func TestUntilDefaultLocation(t *testing.T) {
+	defer time.SetDefaultLocation(time.DefaultLocation())
+	time.SetDefaultLocation(time.FixedZone("UTC+8", 8*60*60))
+	start := time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		rule  string
+		until time.Time
+		n     int
+	}{
+		{"FREQ=DAILY;UNTIL=20240105T000000Z", time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC), 4},
+		{"FREQ=DAILY;UNTIL=20240105", time.Date(2024, time.January, 5, 23, 59, 59, 0, time.UTC), 4},
+	} {
+		r, err := rrule.Parse(tt.rule)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.rule, err)
+			continue
+		}
+		if !r.Until.Equal(tt.until) {
+			t.Errorf("Parse(%q).Until = %v, want %v", tt.rule, r.Until, tt.until)
+		}
+		if ts := r.All(start, 10); len(ts) != tt.n {
+			t.Errorf("%q from %v gives %d occurrences, want %d: %v", tt.rule, start, len(ts), tt.n, ts)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// A Context is the part of context.Context used by SleepContext and
+// AfterContext, which package time cannot import; any
+// context.Context satisfies it.
+// context.Context 的子集，因为 context 包依赖 time 包，time 无法导入它
+type Context interface {
+	Done() <-chan struct{}
+	Err() error
+}
+
+// SleepContext pauses the current goroutine for at least the
+// duration d or until ctx is done, whichever comes first. It returns
+// nil after sleeping the whole duration and ctx.Err() otherwise.
+// If ctx is already done, SleepContext returns at once, whatever d.
+// 可被 ctx 取消的 Sleep，取消时立即返回且不会遗留计时器
+func SleepContext(ctx Context, d Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	done := ctx.Done()
+	if done == nil {
+		Sleep(d)
+		return nil
+	}
+	if d <= 0 {
+		return nil
+	}
+	t := NewTimer(d)
+	select {
+	case <-t.C:
+		return nil
+	case <-done:
+		t.Stop()
+		return ctx.Err()
+	}
+}
+
+// AfterContext is like After, sending the current time on the
+// returned channel after d, but stops the underlying timer if ctx is
+// done first, and then closes the channel instead. Unlike a select
+// over After and ctx.Done, it does not leave a timer running after
+// cancelation.
+func AfterContext(ctx Context, d Duration) <-chan Time {
+	done := ctx.Done()
+	if done == nil {
+		return After(d)
+	}
+	c := make(chan Time, 1)
+	t := NewTimer(d)
+	go func() {
+		select {
+		case now := <-t.C:
+			c <- now
+		case <-done:
+			t.Stop()
+			close(c)
+		}
+	}()
+	return c
+}
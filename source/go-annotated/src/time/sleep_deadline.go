@@ -0,0 +1,108 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "sync"
+
+// A DeadlineTimer holds a channel that delivers the current time once,
+// when the clocks of a Location first show a given date and time,
+// such as 2024-03-10 02:30 in America/Denver.
+// 在某时区的指定墙上日期时间触发一次的定时器，处理夏令时间隙和休眠唤醒
+//
+// A Timer measures a duration on the monotonic clock, which on most
+// systems does not advance while the machine is suspended, so a Timer
+// for a distant wall clock time fires late after a suspend, or early
+// or late after the system clock is set. A DeadlineTimer instead
+// sleeps for at most a minute at a time and looks at the wall clock
+// again on each wake-up, so that it fires at most about a minute
+// after the deadline. The time it sends is that at which it fired.
+type DeadlineTimer struct {
+	C <-chan Time // The channel on which the time is delivered.
+
+	c        chan Time
+	deadline Time
+	mu       sync.Mutex
+	timer    *Timer
+	done     bool // fired or stopped
+}
+
+// deadlineCheck is the longest a DeadlineTimer sleeps before it looks
+// at the wall clock again.
+const deadlineCheck = Minute
+
+// NewDeadlineTimer returns a new DeadlineTimer that sends the current
+// time on its channel when the clocks in loc show the given date and
+// time, or at once if that is in the past. A date and time skipped or
+// repeated by a transition in loc, such as 02:30 on the day daylight
+// saving time starts, is resolved by policy as ResolveLocal does; with
+// ResolveStrict, NewDeadlineTimer returns its error instead. The
+// values are normalized as for Date. It panics if loc is nil.
+func NewDeadlineTimer(year int, month Month, day, hour, min, sec int, loc *Location, policy ResolvePolicy) (*DeadlineTimer, error) {
+	if loc == nil {
+		panic("time: missing Location in call to NewDeadlineTimer")
+	}
+	deadline, _, err := ResolveLocal(year, month, day, hour, min, sec, 0, loc, policy)
+	if err != nil {
+		return nil, err
+	}
+	c := make(chan Time, 1)
+	t := &DeadlineTimer{
+		C:        c,
+		c:        c,
+		deadline: deadline,
+	}
+	t.mu.Lock()
+	t.arm()
+	t.mu.Unlock()
+	return t, nil
+}
+
+// Deadline returns the instant at which t is due to fire: the date
+// and time it was created with, resolved in its Location.
+func (t *DeadlineTimer) Deadline() Time {
+	return t.deadline
+}
+
+// Stop prevents t from firing. It returns true if the call stops the
+// timer, false if the timer has already fired or been stopped. As
+// with Timer.Stop, it does not close the channel.
+func (t *DeadlineTimer) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return false
+	}
+	t.done = true
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	return true
+}
+
+// arm fires t if its deadline has passed on the wall clock and
+// otherwise sleeps until it, or for deadlineCheck if that is sooner.
+// t.mu must be held.
+func (t *DeadlineTimer) arm() {
+	// The deadline has no monotonic clock reading, so that this
+	// compares wall clock times.
+	d := Until(t.deadline)
+	if d <= 0 {
+		t.done = true
+		t.c <- Now()
+		return
+	}
+	if d > deadlineCheck {
+		d = deadlineCheck
+	}
+	t.timer = AfterFunc(d, t.wake)
+}
+
+func (t *DeadlineTimer) wake() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.done {
+		t.arm()
+	}
+}
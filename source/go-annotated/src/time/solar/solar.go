@@ -0,0 +1,133 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package solar computes sunrise, sunset and solar noon, for
+// schedules such as "run at dusk local time" that follow the sun
+// rather than the clock.
+// 计算日出、日落和正午时刻，用于"本地黄昏时运行"之类的调度
+//
+// Times are those of the upper limb of the Sun crossing the horizon,
+// with the usual allowance for atmospheric refraction, computed with
+// the sunrise equation; they are good to about a minute away from the
+// polar regions, on flat ground. A zone's position is that of its
+// principal city in the zone1970.tab file of the time zone database,
+// as reported by time.ZoneMetadata:
+//
+//	paris, _ := time.LoadLocation("Europe/Paris")
+//	dusk, err := solar.Sunset(time.Now(), paris)
+package solar
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	// ErrPolarNight is returned for a day on which the Sun does not
+	// rise.
+	ErrPolarNight = errors.New("solar: the sun does not rise on this day")
+
+	// ErrMidnightSun is returned for a day on which the Sun does not
+	// set.
+	ErrMidnightSun = errors.New("solar: the sun does not set on this day")
+)
+
+// Sunrise returns the time of sunrise, in loc, on the day of date in
+// loc, at the principal city of loc.
+func Sunrise(date time.Time, loc *time.Location) (time.Time, error) {
+	return inZone(date, loc, -1)
+}
+
+// Sunset returns the time of sunset, in loc, on the day of date in
+// loc, at the principal city of loc.
+func Sunset(date time.Time, loc *time.Location) (time.Time, error) {
+	return inZone(date, loc, 1)
+}
+
+// SolarNoon returns the time, in loc, at which the Sun is highest in
+// the sky on the day of date in loc, at the principal city of loc.
+func SolarNoon(date time.Time, loc *time.Location) (time.Time, error) {
+	return inZone(date, loc, 0)
+}
+
+// SunriseAt returns the time of sunrise, in the Location of date, on
+// the day of date at the given latitude and longitude, in degrees,
+// positive north and east.
+func SunriseAt(date time.Time, lat, lon float64) (time.Time, error) {
+	return at(date, lat, lon, -1)
+}
+
+// SunsetAt returns the time of sunset, in the Location of date, on
+// the day of date at the given latitude and longitude.
+func SunsetAt(date time.Time, lat, lon float64) (time.Time, error) {
+	return at(date, lat, lon, 1)
+}
+
+// SolarNoonAt returns the time, in the Location of date, at which the
+// Sun is highest in the sky on the day of date at the given latitude
+// and longitude.
+func SolarNoonAt(date time.Time, lat, lon float64) (time.Time, error) {
+	return at(date, lat, lon, 0)
+}
+
+// inZone is at, at the principal city of loc.
+func inZone(date time.Time, loc *time.Location, event int) (time.Time, error) {
+	e, err := time.ZoneMetadata(time.CanonicalZoneName(loc.String()))
+	if err != nil {
+		return time.Time{}, errors.New("solar: no position known for zone " + loc.String())
+	}
+	return at(date.In(loc), e.Latitude, e.Longitude, event)
+}
+
+// Constants of the sunrise equation; angles are in degrees.
+const (
+	j2000      = 2451545.0 // Julian date of 2000-01-01 12:00
+	obliquity  = 23.4397   // of the ecliptic
+	perihelion = 102.9372  // argument of the perihelion of the Earth
+	horizon    = -0.833    // altitude of the Sun's center at sunrise
+)
+
+// at returns the time of sunrise (event -1), solar noon (0) or sunset
+// (1) on the day of date in its Location, at lat and lon.
+func at(date time.Time, lat, lon float64, event int) (time.Time, error) {
+	if !(-90 <= lat && lat <= 90) || !(-180 <= lon && lon <= 180) {
+		return time.Time{}, errors.New("solar: coordinates out of range")
+	}
+	loc := date.Location()
+	y, m, d := date.Date()
+	noon := time.Date(y, m, d, 12, 0, 0, 0, loc)
+
+	// The mean solar noon at lon nearest to noon on the clock.
+	n := math.Floor(noon.JulianDate() - j2000 + lon/360 + 0.5)
+	jstar := n - lon/360
+
+	// The Sun's position at that time.
+	mean := math.Mod(357.5291+0.98560028*jstar, 360)
+	mrad := rad(mean)
+	center := 1.9148*math.Sin(mrad) + 0.0200*math.Sin(2*mrad) + 0.0003*math.Sin(3*mrad)
+	lambda := rad(math.Mod(mean+center+180+perihelion, 360))
+	transit := j2000 + jstar + 0.0053*math.Sin(mrad) - 0.0069*math.Sin(2*lambda)
+	if event == 0 {
+		return time.FromJulianDate(transit).In(loc), nil
+	}
+
+	// The hour angle at which the Sun crosses the horizon.
+	sinDecl := math.Sin(lambda) * math.Sin(rad(obliquity))
+	cosDecl := math.Sqrt(1 - sinDecl*sinDecl)
+	phi := rad(lat)
+	cosHour := (math.Sin(rad(horizon)) - math.Sin(phi)*sinDecl) / (math.Cos(phi) * cosDecl)
+	switch {
+	case cosHour > 1:
+		return time.Time{}, ErrPolarNight
+	case cosHour < -1:
+		return time.Time{}, ErrMidnightSun
+	}
+	hour := math.Acos(cosHour) * 180 / math.Pi
+	return time.FromJulianDate(transit + float64(event)*hour/360).In(loc), nil
+}
+
+func rad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,171 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sqltime provides NullTime, a time.Time that may be null, for
+// database columns and JSON fields that may hold no time.
+// 可以为空的时间，用于数据库的可空列和 JSON 的 null
+//
+// NullTime replaces the *time.Time fields that database code uses for
+// such values: it implements sql.Scanner and driver.Valuer, and
+// encodes to and from JSON null.
+//
+//	var deleted sqltime.NullTime
+//	err := row.Scan(&deleted)
+//	if deleted.Valid {
+//		fmt.Println("deleted at", deleted.Time)
+//	}
+package sqltime
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// A NullTime is a time.Time that may be null. Time is meaningful only
+// if Valid is true.
+//
+// Decoding into a NullTime, with Scan or UnmarshalJSON, keeps the
+// Location of its Time if the decoded time has the same offset in it:
+// a column read into a NullTime set to a time in America/New_York
+// stays in America/New_York, rather than in a fixed zone as the
+// offset alone would give.
+type NullTime struct {
+	Time  time.Time
+	Valid bool // Valid is true if Time is not null
+}
+
+// From returns a valid NullTime holding t.
+func From(t time.Time) NullTime {
+	return NullTime{Time: t, Valid: true}
+}
+
+// FromPtr returns a NullTime holding *t, or null if t is nil.
+func FromPtr(t *time.Time) NullTime {
+	if t == nil {
+		return NullTime{}
+	}
+	return From(*t)
+}
+
+// Ptr returns a pointer to a copy of n.Time, or nil if n is null.
+func (n NullTime) Ptr() *time.Time {
+	if !n.Valid {
+		return nil
+	}
+	t := n.Time
+	return &t
+}
+
+// String returns n.Time.String(), or "null".
+func (n NullTime) String() string {
+	if !n.Valid {
+		return "null"
+	}
+	return n.Time.String()
+}
+
+// textLayouts are the layouts Scan accepts for times that a driver
+// returns as text, as SQLite and some MySQL drivers do. Those without
+// a zone are in UTC.
+var textLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// Scan implements the sql.Scanner interface. It accepts nil, a
+// time.Time, and text in RFC 3339 or in the forms SQL databases print
+// timestamps in, such as "2006-01-02 15:04:05.999999-07".
+func (n *NullTime) Scan(value interface{}) error {
+	var t time.Time
+	switch v := value.(type) {
+	case nil:
+		*n = NullTime{}
+		return nil
+	case time.Time:
+		// The driver chose the Location.
+		*n = From(v)
+		return nil
+	case string:
+		var err error
+		if t, err = parseText(v); err != nil {
+			return err
+		}
+	case []byte:
+		var err error
+		if t, err = parseText(string(v)); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("sqltime: cannot scan %T into NullTime", value)
+	}
+	*n = From(keepLocation(n.Time.Location(), t))
+	return nil
+}
+
+func parseText(s string) (time.Time, error) {
+	for _, layout := range textLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("sqltime: cannot parse %q as a time", s)
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullTime) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Time, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. A null
+// NullTime is encoded as null, a valid one as Time is.
+func (n NullTime) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Time.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts
+// null and what time.Time accepts.
+func (n *NullTime) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*n = NullTime{}
+		return nil
+	}
+	var t time.Time
+	if err := t.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*n = From(keepLocation(n.Time.Location(), t))
+	return nil
+}
+
+// keepLocation returns t in loc if its offset there is the one it
+// was decoded with, and t unchanged otherwise.
+func keepLocation(loc *time.Location, t time.Time) time.Time {
+	if loc == time.UTC {
+		// Also the Location of the zero Time: nothing to keep,
+		// and a UTC offset is best left as decoded.
+		return t
+	}
+	u := t.In(loc)
+	if offset(u) != offset(t) {
+		return t
+	}
+	return u
+}
+
+func offset(t time.Time) int {
+	_, off := t.Zone()
+	return off
+}
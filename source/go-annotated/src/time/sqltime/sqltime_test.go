@@ -0,0 +1,36 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqltime_test
+
+import (
+	"testing"
+	"time"
+	"time/sqltime"
+)
+
+// TestScanDefaultLocation checks that text without an offset is read
+// as UTC whatever time.SetDefaultLocation says.
+func TestScanDefaultLocation(t *testing.T) {
+	defer time.SetDefaultLocation(time.DefaultLocation())
+	time.SetDefaultLocation(time.FixedZone("UTC+8", 8*60*60))
+	for _, tt := range []struct {
+		text string
+		want time.Time
+	}{
+		{"2024-01-02 03:04:05.5", time.Date(2024, time.January, 2, 3, 4, 5, 5e8, time.UTC)},
+		{"2024-01-02T03:04:05", time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)},
+		{"2024-01-02", time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		{"2024-01-02 03:04:05-07", time.Date(2024, time.January, 2, 10, 4, 5, 0, time.UTC)},
+	} {
+		var n sqltime.NullTime
+		if err := n.Scan(tt.text); err != nil {
+			t.Errorf("Scan(%q): %v", tt.text, err)
+			continue
+		}
+		if !n.Valid || !n.Time.Equal(tt.want) {
+			t.Errorf("Scan(%q) = %v, want %v", tt.text, n.Time, tt.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "sync"
+
+// A Stopwatch measures elapsed time, as a hand-held stopwatch does:
+// it can be started and stopped, adding up the time it runs, and can
+// mark laps. It reads the monotonic clock, through the Times of its
+// Clock, so that changes to the wall clock do not affect it.
+// 秒表：基于单调时钟，支持启动、停止、计圈，可并发使用
+//
+// The zero Stopwatch is stopped, reads SystemClock and is ready to
+// use. A Stopwatch is safe for use by multiple goroutines, and must
+// not be copied after first use.
+type Stopwatch struct {
+	mu      sync.Mutex
+	clock   Clock
+	running bool
+	started Time     // when the current run started, if running
+	elapsed Duration // of the runs before the current one
+	lapMark Duration // the elapsed time at the end of the last lap
+	laps    []Lap
+}
+
+// A Lap is one lap marked by a Stopwatch.
+type Lap struct {
+	Name     string   `json:"name,omitempty"`
+	Duration Duration `json:"duration"` // from the end of the last lap
+	Split    Duration `json:"split"`    // from the start of the Stopwatch
+}
+
+// A StopwatchSnapshot is the state of a Stopwatch at one time, for
+// reports and logs. It encodes to JSON with encoding/json, Durations
+// as strings such as "1.5s".
+type StopwatchSnapshot struct {
+	Running bool     `json:"running"`
+	Elapsed Duration `json:"elapsed"`
+	Laps    []Lap    `json:"laps"`
+}
+
+// NewStopwatch returns a new, stopped Stopwatch that reads clock, or
+// SystemClock if clock is nil.
+func NewStopwatch(clock Clock) *Stopwatch {
+	return &Stopwatch{clock: clock}
+}
+
+// StartStopwatch returns a new Stopwatch, reading SystemClock, that
+// has been started.
+func StartStopwatch() *Stopwatch {
+	s := new(Stopwatch)
+	s.Start()
+	return s
+}
+
+func (s *Stopwatch) now() Time {
+	if s.clock == nil {
+		return Now()
+	}
+	return s.clock.Now()
+}
+
+// elapsedLocked returns the time s has run. s.mu must be held.
+func (s *Stopwatch) elapsedLocked() Duration {
+	if !s.running {
+		return s.elapsed
+	}
+	return s.elapsed + s.now().Sub(s.started)
+}
+
+// Start starts s, or does nothing if it is running.
+func (s *Stopwatch) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.running {
+		s.running = true
+		s.started = s.now()
+	}
+}
+
+// Stop stops s, keeping the time it has run, and returns that time.
+// It does nothing but return the time if s is stopped.
+func (s *Stopwatch) Stop() Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.elapsed = s.elapsedLocked()
+	s.running = false
+	return s.elapsed
+}
+
+// Reset stops s and sets its time back to zero, discarding its laps.
+func (s *Stopwatch) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = false
+	s.elapsed = 0
+	s.lapMark = 0
+	s.laps = nil
+}
+
+// Restart is Reset followed by Start, done at once.
+func (s *Stopwatch) Restart() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.elapsed = 0
+	s.lapMark = 0
+	s.laps = nil
+	s.running = true
+	s.started = s.now()
+}
+
+// Elapsed returns the time s has run: the total of its runs,
+// including the current one if it is running.
+func (s *Stopwatch) Elapsed() Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.elapsedLocked()
+}
+
+// Running reports whether s is running.
+func (s *Stopwatch) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
+// Lap ends the current lap of s, which began when the last one ended
+// or at the start of s, records it under name, which may be empty,
+// and returns its duration. Only the time s runs counts toward laps.
+func (s *Stopwatch) Lap(name string) Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	split := s.elapsedLocked()
+	l := Lap{Name: name, Duration: split - s.lapMark, Split: split}
+	s.laps = append(s.laps, l)
+	s.lapMark = split
+	return l.Duration
+}
+
+// Laps returns the laps recorded by s, in order.
+func (s *Stopwatch) Laps() []Lap {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Lap(nil), s.laps...)
+}
+
+// Snapshot returns the state of s.
+func (s *Stopwatch) Snapshot() StopwatchSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return StopwatchSnapshot{
+		Running: s.running,
+		Elapsed: s.elapsedLocked(),
+		Laps:    append([]Lap{}, s.laps...),
+	}
+}
+
+// String returns the time s has run, as Duration.String does.
+func (s *Stopwatch) String() string {
+	return s.Elapsed().String()
+}
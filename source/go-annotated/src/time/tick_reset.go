@@ -0,0 +1,64 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// Reset stops the ticker and resets its period to d. The next tick
+// arrives d after the call, and the ticks after that every d.
+// Reset panics if d is not positive.
+// 修改 Ticker 的周期，下一次触发在调用后 d 时间
+//
+// A tick that is already waiting in the channel is not removed;
+// use StopAndDrain first to discard it.
+func (t *Ticker) Reset(d Duration) {
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+	if t.r.f == nil {
+		panic("time: Reset called on uninitialized Ticker")
+	}
+	stopTimer(&t.r)
+	t.r.when = when(d)
+	t.r.period = int64(d)
+	startTimer(&t.r)
+}
+
+// StopAndDrain stops the ticker like Stop and then removes any tick
+// waiting in its channel, so that a later Reset starts clean and a
+// receive on t.C does not return a stale time.
+// 停止 Ticker 并清空通道中尚未读取的时间
+func (t *Ticker) StopAndDrain() {
+	t.Stop()
+	select {
+	case <-t.C:
+	default:
+	}
+}
+
+// StopAndDrain stops the timer like Stop and then removes the time
+// waiting in its channel, if any, so that it can be reused with
+// Reset without the usual
+//
+//	if !t.Stop() {
+//		<-t.C
+//	}
+//
+// which blocks forever if the value has already been received.
+// It reports whether the call stopped the timer before it expired.
+// 停止 Timer 并清空通道，无需手写 Stop 后再读取通道的逻辑
+//
+// StopAndDrain never blocks. It must not be called concurrently with
+// other receives from t.C. Like Stop, it does not wait for a send
+// that the expiring timer has already begun, so a timer expiring at
+// the very moment of the call may still deliver its value.
+func (t *Timer) StopAndDrain() bool {
+	if t.Stop() {
+		return true
+	}
+	select {
+	case <-t.C:
+	default:
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "sync"
+
+// A WallTicker holds a channel that delivers a tick every day at a
+// given wall clock time in a Location, such as 02:30 in
+// Europe/Berlin, for daily jobs.
+// 按当地墙上时间每天触发一次的 Ticker，夏令时切换时不会漏掉或重复
+//
+// Unlike a Ticker with a period of 24 hours, a WallTicker follows the
+// clocks of its Location: after a daylight saving time change it
+// still fires at 02:30, not at 01:30 or 03:30. Each deadline is
+// computed from the previous one when it fires, as ResolveLocal
+// would with ResolveEarlier: on a day when 02:30 is skipped it fires
+// at the same distance after the transition, 03:30 for a one-hour
+// gap; on a day when 02:30 happens twice it fires only the first
+// time.
+type WallTicker struct {
+	C <-chan Time // The channel on which the ticks are delivered.
+
+	c              chan Time
+	loc            *Location
+	hour, min, sec int
+	mu             sync.Mutex
+	next           Time // deadline of the pending tick
+	timer          *Timer
+	stopped        bool
+}
+
+// NewWallTicker returns a new WallTicker whose channel receives the
+// current time every day when the clocks in loc show hour:min:sec.
+// The first tick is the next such time after now. As with Ticker,
+// ticks are dropped if the reader falls behind. It panics if loc is
+// nil or the time of day is out of range. Stop the ticker to release
+// its resources.
+func NewWallTicker(hour, min, sec int, loc *Location) *WallTicker {
+	if loc == nil {
+		panic("time: missing Location in call to NewWallTicker")
+	}
+	if hour < 0 || hour > 23 || min < 0 || min > 59 || sec < 0 || sec > 59 {
+		panic("time: invalid wall clock time in call to NewWallTicker")
+	}
+	c := make(chan Time, 1)
+	t := &WallTicker{
+		C:    c,
+		c:    c,
+		loc:  loc,
+		hour: hour,
+		min:  min,
+		sec:  sec,
+	}
+	t.mu.Lock()
+	t.schedule(Now())
+	t.mu.Unlock()
+	return t
+}
+
+// Next returns the time of the next tick, or the zero Time if the
+// ticker has been stopped.
+func (t *WallTicker) Next() Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopped {
+		return Time{}
+	}
+	return t.next
+}
+
+// Stop turns off the ticker. After Stop, no more ticks will be sent.
+// Stop does not close the channel, to prevent a concurrent goroutine
+// reading from the channel from seeing an erroneous "tick".
+func (t *WallTicker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
+
+// schedule arms the timer for the first tick after the given time.
+// t.mu must be held.
+func (t *WallTicker) schedule(after Time) {
+	t.next = t.nextAfter(after)
+	t.timer = AfterFunc(Until(t.next), t.fire)
+}
+
+// fire sends a tick and schedules the next one. The next deadline is
+// computed from the one that fired, not from the current time, so
+// that a timer firing a little early cannot give a second tick for
+// the same day.
+func (t *WallTicker) fire() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopped {
+		return
+	}
+	select {
+	case t.c <- Now():
+	default:
+	}
+	after := t.next
+	if now := Now(); now.After(after) {
+		// Fired late, as after the machine was suspended:
+		// skip the missed days rather than catching up.
+		after = now
+	}
+	t.schedule(after)
+}
+
+// nextAfter returns the first instant after u at which the clocks
+// in t.loc show the ticker's time of day.
+func (t *WallTicker) nextAfter(u Time) Time {
+	y, m, d := u.In(t.loc).Date()
+	for i := 0; ; i++ {
+		next, _, _ := ResolveLocal(y, m, d+i, t.hour, t.min, t.sec, 0, t.loc, ResolveEarlier)
+		if next.After(u) {
+			return next
+		}
+	}
+}
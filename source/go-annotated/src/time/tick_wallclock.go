@@ -0,0 +1,186 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "sync"
+
+// A ClockJump reports a discontinuity of the wall clock: between two
+// readings, the wall clock moved by Wall while the monotonic clock
+// moved by Mono. The wall clock jumps forward when it is set ahead,
+// as by a large NTP correction, and when the machine wakes from
+// suspend, as on most systems the monotonic clock stands still while
+// the machine sleeps; it jumps back when it is set back.
+// 墙上时钟跳变：对比同一区间内墙上时钟和单调时钟走过的时间
+type ClockJump struct {
+	At   Time     // when the jump was noticed
+	Wall Duration // how far the wall clock moved
+	Mono Duration // how far the monotonic clock moved meanwhile
+}
+
+// Step returns how far the wall clock jumped: positive if forward,
+// negative if back.
+func (j ClockJump) Step() Duration {
+	return j.Wall - j.Mono
+}
+
+// clockJumpMin is the smallest Step that a WallClockTicker reports.
+// NTP slews the clock by at most 500 ppm, 30 ms in a minute.
+const clockJumpMin = Second
+
+// A WallClockTicker holds a channel that delivers ticks at the wall
+// clock times that are multiples of a period, as Truncate computes
+// them, such as at the start of every minute, for cron-like daemons.
+// 按墙上时钟对齐触发的 Ticker，能应对系统休眠和时钟跳变，并报告检测到的跳变
+//
+// A Ticker measures its period on the monotonic clock, so after the
+// machine is suspended, or the system clock is set, its ticks no
+// longer come at the wall clock times they used to. A WallClockTicker
+// sleeps for at most a minute at a time, like a DeadlineTimer, and
+// looks at both clocks on each wake-up. After a forward jump, the
+// missed ticks are dropped and one tick is delivered at once. After a
+// jump back, ticks continue at the multiples of the period after the
+// new time, so some wall clock times may tick twice. Each jump of a
+// second or more is also reported on Jumps.
+type WallClockTicker struct {
+	C     <-chan Time      // The channel on which the ticks are delivered.
+	Jumps <-chan ClockJump // The channel on which clock jumps are reported.
+
+	c       chan Time
+	jumps   chan ClockJump
+	clock   Clock
+	period  Duration
+	timer   ClockTimer
+	stop    chan struct{} // closed by Stop
+	mu      sync.Mutex
+	next    Time // wall clock deadline of the pending tick
+	last    Time // reading of both clocks at the last wake-up
+	stopped bool
+}
+
+// NewWallClockTicker returns a new WallClockTicker whose channel
+// receives the current time at every multiple of d on the wall clock.
+// The first tick is the next such time after now. As with Ticker, a
+// tick, or a jump, is dropped if the reader falls behind. It panics if
+// d is not positive. Stop the ticker to release its resources.
+func NewWallClockTicker(d Duration) *WallClockTicker {
+	return NewWallClockTickerWithClock(SystemClock, d)
+}
+
+// NewWallClockTickerWithClock is like NewWallClockTicker but reads
+// clock, and sleeps on its timers, so that it can be tested with a
+// fake clock that moves the wall clock apart from the monotonic one.
+// A nil clock means SystemClock.
+func NewWallClockTickerWithClock(clock Clock, d Duration) *WallClockTicker {
+	if d <= 0 {
+		panic("time: non-positive interval for NewWallClockTicker")
+	}
+	if clock == nil {
+		clock = SystemClock
+	}
+	c := make(chan Time, 1)
+	jumps := make(chan ClockJump, 1)
+	t := &WallClockTicker{
+		C:      c,
+		Jumps:  jumps,
+		c:      c,
+		jumps:  jumps,
+		clock:  clock,
+		period: d,
+		stop:   make(chan struct{}),
+	}
+	t.mu.Lock()
+	now := clock.Now()
+	t.last = now
+	t.next = now.Truncate(d).Add(d)
+	t.timer = clock.NewTimer(t.sleep(now))
+	t.mu.Unlock()
+	go t.run()
+	return t
+}
+
+// Next returns the time of the next tick, or the zero Time if the
+// ticker has been stopped.
+func (t *WallClockTicker) Next() Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopped {
+		return Time{}
+	}
+	return t.next
+}
+
+// Stop turns off the ticker. After Stop, no more ticks or jumps will
+// be sent. Stop does not close the channels, to prevent a concurrent
+// goroutine reading from them from seeing an erroneous value.
+func (t *WallClockTicker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.stopped {
+		t.stopped = true
+		t.timer.Stop()
+		close(t.stop)
+	}
+}
+
+// sleep returns how long to sleep from now: until the next tick, or
+// for deadlineCheck if that is sooner. t.mu must be held.
+func (t *WallClockTicker) sleep(now Time) Duration {
+	// next has no monotonic clock reading, so this compares wall
+	// clock times.
+	d := t.next.Sub(now)
+	if d > deadlineCheck {
+		d = deadlineCheck
+	}
+	return d
+}
+
+// run wakes t each time its timer fires, until t is stopped.
+func (t *WallClockTicker) run() {
+	for {
+		select {
+		case <-t.timer.Chan():
+			t.wake()
+		case <-t.stop:
+			return
+		}
+	}
+}
+
+// wake compares the clocks with their readings at the last wake-up,
+// reporting a jump, and ticks if the wall clock has reached the next
+// tick.
+func (t *WallClockTicker) wake() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopped {
+		return
+	}
+	now := t.clock.Now()
+	j := ClockJump{At: now, Wall: now.Round(0).Sub(t.last.Round(0)), Mono: now.Sub(t.last)}
+	t.last = now
+	if step := j.Step(); step >= clockJumpMin || step <= -clockJumpMin {
+		select {
+		case t.jumps <- j:
+		default:
+		}
+		if step < 0 {
+			// Set back: tick again from the new time, which the clock
+			// showed as early as j.Mono before now.
+			set := now.Round(0).Add(-j.Mono)
+			if next := set.Truncate(t.period).Add(t.period); next.Before(t.next) {
+				t.next = next
+			}
+		}
+	}
+	if !now.Before(t.next) {
+		select {
+		case t.c <- now:
+		default:
+		}
+		// Drop the ticks missed while asleep or skipped by a jump.
+		t.next = now.Truncate(t.period).Add(t.period)
+	}
+	t.timer.Reset(t.sleep(now))
+}
@@ -0,0 +1,187 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time_test
+
+import (
+	"testing"
+	. "time"
+	"time/clocktest"
+)
+
+// wallClockTest drives a WallClockTicker of one minute on a fake clock.
+type wallClockTest struct {
+	t   *testing.T
+	clk *clocktest.Clock
+	tk  *WallClockTicker
+}
+
+// advance moves the fake clock forward by d, as time passes, and waits
+// for the ticker to sleep again.
+func (w *wallClockTest) advance(d Duration) {
+	w.clk.Advance(d)
+	w.clk.BlockUntil(1)
+}
+
+// tick returns the tick delivered since the last call, if any.
+func (w *wallClockTest) tick() (Time, bool) {
+	select {
+	case tm := <-w.tk.C:
+		return tm, true
+	default:
+		return Time{}, false
+	}
+}
+
+// jump returns the clock jump reported since the last call, if any.
+func (w *wallClockTest) jump() (ClockJump, bool) {
+	select {
+	case j := <-w.tk.Jumps:
+		return j, true
+	default:
+		return ClockJump{}, false
+	}
+}
+
+// wantTick checks that the ticker ticked at want and reported no jump.
+func (w *wallClockTest) wantTick(want Time) {
+	w.t.Helper()
+	if tm, ok := w.tick(); !ok || !tm.Equal(want) {
+		w.t.Errorf("tick = %v, %v; want %v", tm, ok, want)
+	}
+	if j, ok := w.jump(); ok {
+		w.t.Errorf("unexpected jump %+v", j)
+	}
+}
+
+// wantJump checks that the ticker reported a jump of step.
+func (w *wallClockTest) wantJump(step Duration) {
+	w.t.Helper()
+	if j, ok := w.jump(); !ok || j.Step() != step {
+		w.t.Errorf("jump = %+v, %v; want Step %v", j, ok, step)
+	}
+}
+
+func newWallClockTest(t *testing.T, start Time) *wallClockTest {
+	clk := clocktest.Freeze(start)
+	w := &wallClockTest{t, clk, NewWallClockTickerWithClock(clk, Minute)}
+	clk.BlockUntil(1)
+	return w
+}
+
+func TestWallClockTicker(t *testing.T) {
+	start := Date(2024, January, 1, 0, 0, 30, 0, UTC)
+	w := newWallClockTest(t, start)
+	defer w.tk.Stop()
+
+	if next, want := w.tk.Next(), Date(2024, January, 1, 0, 1, 0, 0, UTC); !next.Equal(want) {
+		t.Fatalf("Next = %v, want %v", next, want)
+	}
+	w.advance(30 * Second)
+	w.wantTick(Date(2024, January, 1, 0, 1, 0, 0, UTC))
+	w.advance(Minute)
+	w.wantTick(Date(2024, January, 1, 0, 2, 0, 0, UTC))
+	w.advance(30 * Second)
+	if tm, ok := w.tick(); ok {
+		t.Errorf("tick at %v between minutes", tm)
+	}
+}
+
+func TestWallClockTickerStepForward(t *testing.T) {
+	w := newWallClockTest(t, Date(2024, January, 1, 0, 0, 0, 0, UTC))
+	defer w.tk.Stop()
+	w.advance(Minute)
+	w.wantTick(Date(2024, January, 1, 0, 1, 0, 0, UTC))
+
+	// The machine sleeps for ten minutes and wakes half a minute
+	// before the ticker was due to wake, by the monotonic clock.
+	w.advance(30 * Second)
+	w.clk.Step(10 * Minute)
+	w.advance(30 * Second)
+	w.wantJump(10 * Minute)
+	// The ticks of 0:02 to 0:11 are dropped, and one comes at once.
+	if tm, ok := w.tick(); !ok || !tm.Equal(Date(2024, January, 1, 0, 12, 0, 0, UTC)) {
+		t.Errorf("tick after the jump = %v, %v; want 0:12", tm, ok)
+	}
+	if next, want := w.tk.Next(), Date(2024, January, 1, 0, 13, 0, 0, UTC); !next.Equal(want) {
+		t.Errorf("Next = %v, want %v", next, want)
+	}
+	w.advance(Minute)
+	w.wantTick(Date(2024, January, 1, 0, 13, 0, 0, UTC))
+}
+
+func TestWallClockTickerStepBack(t *testing.T) {
+	w := newWallClockTest(t, Date(2024, January, 1, 0, 0, 0, 0, UTC))
+	defer w.tk.Stop()
+	ticks := make(map[string]int) // by wall clock time
+	for i := 0; i < 5; i++ {
+		w.advance(Minute)
+		tm, ok := w.tick()
+		if !ok {
+			t.Fatalf("no tick at minute %d", i+1)
+		}
+		ticks[tm.Format("15:04")]++
+	}
+
+	// The clock is set back three minutes, from 0:05 to 0:02.
+	w.clk.Step(-3 * Minute)
+	w.advance(Minute)
+	w.wantJump(-3 * Minute)
+	tm, ok := w.tick()
+	if !ok || !tm.Equal(Date(2024, January, 1, 0, 3, 0, 0, UTC)) {
+		t.Fatalf("tick after the jump = %v, %v; want 0:03", tm, ok)
+	}
+	ticks[tm.Format("15:04")]++
+	for i := 0; i < 3; i++ {
+		w.advance(Minute)
+		tm, ok := w.tick()
+		if !ok {
+			t.Fatalf("no tick %d minutes after the jump", i+2)
+		}
+		ticks[tm.Format("15:04")]++
+	}
+	if j, ok := w.jump(); ok {
+		t.Errorf("unexpected jump %+v", j)
+	}
+
+	// 0:03 to 0:05 tick twice, 0:06 once.
+	for min := 1; min <= 6; min++ {
+		want := 1
+		if 3 <= min && min <= 5 {
+			want = 2
+		}
+		if n := ticks[Date(2024, January, 1, 0, min, 0, 0, UTC).Format("15:04")]; n != want {
+			t.Errorf("0:%02d ticked %d times, want %d", min, n, want)
+		}
+	}
+}
+
+func TestWallClockTickerSmallStep(t *testing.T) {
+	w := newWallClockTest(t, Date(2024, January, 1, 0, 0, 0, 0, UTC))
+	defer w.tk.Stop()
+	// A slew of less than clockJumpMin is not a jump.
+	w.clk.Step(500 * Millisecond)
+	w.advance(Minute)
+	if j, ok := w.jump(); ok {
+		t.Errorf("step of 500ms reported as %+v", j)
+	}
+	if _, ok := w.tick(); !ok {
+		t.Error("no tick")
+	}
+}
+
+func TestWallClockTickerStop(t *testing.T) {
+	w := newWallClockTest(t, Date(2024, January, 1, 0, 0, 0, 0, UTC))
+	w.tk.Stop()
+	if n := w.clk.Pending(); n != 0 {
+		t.Errorf("%d timers pending after Stop", n)
+	}
+	if next := w.tk.Next(); !next.IsZero() {
+		t.Errorf("Next after Stop = %v, want zero", next)
+	}
+	w.clk.Advance(Hour)
+	if tm, ok := w.tick(); ok {
+		t.Errorf("tick at %v after Stop", tm)
+	}
+}
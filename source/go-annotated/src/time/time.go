@@ -244,6 +244,35 @@ func (t *Time) mono() int64 {
 	return t.ext
 }
 
+// Monotonic returns t's monotonic clock reading in nanoseconds, and
+// whether t has one. Readings are only meaningful relative to each
+// other, within the process that took them: the difference of two
+// readings is the elapsed time between them, as Sub computes it,
+// unaffected by changes to the wall clock.
+// 返回 t 的单调时钟读数（纳秒）以及 t 是否带有该读数
+//
+// A reading can be kept instead of the Time, for example in an
+// int64 updated atomically by many goroutines, and turned back
+// into a Time by WithMonotonic.
+func (t Time) Monotonic() (m int64, ok bool) {
+	if t.wall&hasMonotonic == 0 {
+		return 0, false
+	}
+	return t.ext, true
+}
+
+// WithMonotonic returns t with its monotonic clock reading set to m,
+// a reading returned by Monotonic in the same process. The result
+// is compared and subtracted using m, as a Time returned by Now is.
+// If t is too far from the present to hold a monotonic reading,
+// outside the years 1885 to 2157, WithMonotonic returns t without
+// one.
+// 返回单调时钟读数被设置为 m 的 t
+func (t Time) WithMonotonic(m int64) Time {
+	t.setMono(m)
+	return t
+}
+
 //时间比较方法 比较了 时间戳（并不是比较的日期）
 
 // After reports whether the time instant t is after u.
@@ -277,6 +306,28 @@ func (t Time) Equal(u Time) bool {
 	return t.sec() == u.sec() && t.nsec() == u.nsec()
 }
 
+// Compare compares the time instant t with u. If t is before u, it returns -1;
+// if t is after u, it returns +1; if they're the same, it returns 0.
+// 与 Before/After/Equal 一样，两者都带单调时钟读数时比较单调时钟
+func (t Time) Compare(u Time) int {
+	var tc, uc int64
+	if t.wall&u.wall&hasMonotonic != 0 {
+		tc, uc = t.ext, u.ext
+	} else {
+		tc, uc = t.sec(), u.sec()
+		if tc == uc {
+			tc, uc = int64(t.nsec()), int64(u.nsec())
+		}
+	}
+	switch {
+	case tc < uc:
+		return -1
+	case tc > uc:
+		return +1
+	}
+	return 0
+}
+
 // A Month specifies a month of the year (January = 1, ...).
 type Month int
 
@@ -464,8 +515,9 @@ func (t Time) abs() uint64 {
 	}
 	sec := t.unixSec()
 	if l != &utcLoc {
-		if l.cacheZone != nil && l.cacheStart <= sec && sec < l.cacheEnd {
-			sec += int64(l.cacheZone.offset)
+		if c := l.loadCache(); c != nil && c.now.start <= sec && sec < c.now.end {
+			c.count(true)
+			sec += int64(c.now.offset)
 		} else {
 			_, offset, _, _, _ := l.lookup(sec)
 			sec += int64(offset)
@@ -484,9 +536,10 @@ func (t Time) locabs() (name string, offset int, abs uint64) {
 	// Avoid function call if we hit the local time cache.
 	sec := t.unixSec()
 	if l != &utcLoc {
-		if l.cacheZone != nil && l.cacheStart <= sec && sec < l.cacheEnd {
-			name = l.cacheZone.name
-			offset = l.cacheZone.offset
+		if c := l.loadCache(); c != nil && c.now.start <= sec && sec < c.now.end {
+			c.count(true)
+			name = c.now.name
+			offset = c.now.offset
 		} else {
 			name, offset, _, _, _ = l.lookup(sec)
 		}
@@ -674,8 +727,15 @@ const (
 // second format use a smaller unit (milli-, micro-, or nanoseconds) to ensure
 // that the leading digit is non-zero. The zero duration formats as 0s.
 func (d Duration) String() string {
-	// Largest time is 2540400h10m10.000000000s
 	var buf [32]byte
+	w := d.format(&buf)
+	return string(buf[w:])
+}
+
+// format formats d, as String does, into the end of buf and returns
+// the offset of the first byte.
+func (d Duration) format(buf *[32]byte) int {
+	// Largest time is 2540400h10m10.000000000s
 	w := len(buf)
 
 	u := uint64(d)
@@ -693,7 +753,8 @@ func (d Duration) String() string {
 		w--
 		switch {
 		case u == 0:
-			return "0s"
+			buf[w] = '0'
+			return w
 		case u < uint64(Microsecond):
 			// print nanoseconds
 			prec = 0
@@ -743,7 +804,7 @@ func (d Duration) String() string {
 		buf[w] = '-'
 	}
 
-	return string(buf[w:])
+	return w
 }
 
 // fmtFrac formats the fraction of v/10**prec (e.g., ".12345") into the
@@ -790,6 +851,12 @@ func fmtInt(buf []byte, v uint64) int {
 // Nanoseconds returns the duration as an integer nanosecond count.
 func (d Duration) Nanoseconds() int64 { return int64(d) }
 
+// Microseconds returns the duration as an integer microsecond count.
+func (d Duration) Microseconds() int64 { return int64(d) / 1e3 }
+
+// Milliseconds returns the duration as an integer millisecond count.
+func (d Duration) Milliseconds() int64 { return int64(d) / 1e6 }
+
 // These methods return float64 because the dominant
 // use case is for printing a floating point number like 1.5s, and
 // a truncation to integer would make them not useful in those cases.
@@ -865,6 +932,21 @@ func (d Duration) Round(m Duration) Duration {
 	return maxDuration // overflow
 }
 
+// Abs returns the absolute value of d.
+// As a special case, the minimum Duration is converted to the maximum,
+// since its absolute value cannot be represented.
+// 取绝对值，-1<<63 没有对应的正数，饱和为最大值
+func (d Duration) Abs() Duration {
+	switch {
+	case d >= 0:
+		return d
+	case d == minDuration:
+		return maxDuration
+	default:
+		return -d
+	}
+}
+
 // Add returns the time t+d.
 func (t Time) Add(d Duration) Time {
 	dsec := int64(d / 1e9)
@@ -1054,6 +1136,34 @@ var daysBefore = [...]int32{
 	31 + 28 + 31 + 30 + 31 + 30 + 31 + 31 + 30 + 31 + 30 + 31,
 }
 
+// daysSinceEpoch takes a year and returns the number of days from
+// the absolute epoch to the start of that year.
+// This is basically (year - zeroYear) * 365, but accounting for leap days.
+func daysSinceEpoch(year int) uint64 {
+	y := uint64(int64(year) - absoluteZeroYear)
+
+	// Add in days from 400-year cycles.
+	n := y / 400
+	y -= 400 * n
+	d := daysPer400Years * n
+
+	// Add in 100-year cycles.
+	n = y / 100
+	y -= 100 * n
+	d += daysPer100Years * n
+
+	// Add in 4-year cycles.
+	n = y / 4
+	y -= 4 * n
+	d += daysPer4Years * n
+
+	// Add in non-leap years.
+	n = y
+	d += 365 * n
+
+	return d
+}
+
 func daysIn(m Month, year int) int {
 	if m == February && isLeap(year) {
 		return 29
@@ -1066,7 +1176,17 @@ func now() (sec int64, nsec int32, mono int64)
 
 // 返回的 Time 中时区 为 服务器时区
 // Now returns the current local time.
+// In a program built with the timefakenow tag, if $GO_TIME_FAKE_NOW
+// is set, Now returns the fake time it gives.
 func Now() Time {
+	if fakeNowOn {
+		return fakeNow()
+	}
+	return systemNow()
+}
+
+// systemNow returns the current local time of the system clock.
+func systemNow() Time {
 	sec, nsec, mono := now()
 	sec += unixToInternal - minWall
 	if uint64(sec)>>33 != 0 {
@@ -1105,6 +1225,22 @@ func (t Time) In(loc *Location) Time {
 	return t
 }
 
+// InZone returns t in the Location with the given name, loaded as by
+// LoadLocation: t.InZone("Europe/Paris") is t.In of the Location that
+// LoadLocation("Europe/Paris") returns. LoadLocation caches Locations,
+// so calling InZone for each Time reads the database only once per
+// name. If the zone cannot be loaded, InZone returns t and the error
+// of LoadLocation.
+// 按时区名转换，相当于 LoadLocation 加 In，时区数据有缓存
+func (t Time) InZone(name string) (Time, error) {
+	loc, err := LoadLocation(name)
+	if err != nil {
+		return t, err
+	}
+	t.setLoc(loc)
+	return t, nil
+}
+
 // 返回 当前 Time Location
 // Location returns the time zone information associated with t.
 func (t Time) Location() *Location {
@@ -1123,6 +1259,13 @@ func (t Time) Zone() (name string, offset int) {
 	return
 }
 
+// 返回 t 在所在时区是否处于夏令时
+// IsDST reports whether the time in the configured location is in Daylight Savings Time.
+func (t Time) IsDST() bool {
+	_, _, isDST, _, _ := t.loc.lookup(t.unixSec())
+	return isDST
+}
+
 // Unix returns t as a Unix time, the number of seconds elapsed
 // since January 1, 1970 UTC.
 func (t Time) Unix() int64 {
@@ -1138,12 +1281,38 @@ func (t Time) UnixNano() int64 {
 	return (t.unixSec())*1e9 + int64(t.nsec())
 }
 
+// UnixMilli returns t as a Unix time, the number of milliseconds elapsed since
+// January 1, 1970 UTC. The result is undefined if the Unix time in
+// milliseconds cannot be represented by an int64 (a date more than 292 million
+// years before or after 1970). The result does not depend on the
+// location associated with t.
+// 毫秒时间戳，常用于 JSON 和 JavaScript 的 Date
+func (t Time) UnixMilli() int64 {
+	return t.unixSec()*1e3 + int64(t.nsec())/1e6
+}
+
+// UnixMicro returns t as a Unix time, the number of microseconds elapsed since
+// January 1, 1970 UTC. The result is undefined if the Unix time in
+// microseconds cannot be represented by an int64 (a date before year -290307 or
+// after year 294246). The result does not depend on the location associated
+// with t.
+func (t Time) UnixMicro() int64 {
+	return t.unixSec()*1e6 + int64(t.nsec())/1e3
+}
+
 const timeBinaryVersion byte = 1
 
 //以下三个函数 不是线程安全的
 
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
 func (t Time) MarshalBinary() ([]byte, error) {
+	return t.AppendBinary(make([]byte, 0, 15))
+}
+
+// AppendBinary appends the encoding of MarshalBinary to b and returns
+// the extended buffer, so that encoders can reuse their buffers.
+// 追加式编码：写入调用方提供的缓冲区，避免分配
+func (t Time) AppendBinary(b []byte) ([]byte, error) {
 	var offsetMin int16 // minutes east of UTC. -1 is UTC.
 
 	if t.Location() == UTC {
@@ -1162,41 +1331,50 @@ func (t Time) MarshalBinary() ([]byte, error) {
 
 	sec := t.sec()
 	nsec := t.nsec()
-	enc := []byte{
+	return append(b,
 		timeBinaryVersion, // byte 0 : version
-		byte(sec >> 56),   // bytes 1-8: seconds
-		byte(sec >> 48),
-		byte(sec >> 40),
-		byte(sec >> 32),
-		byte(sec >> 24),
-		byte(sec >> 16),
-		byte(sec >> 8),
+		byte(sec>>56),     // bytes 1-8: seconds
+		byte(sec>>48),
+		byte(sec>>40),
+		byte(sec>>32),
+		byte(sec>>24),
+		byte(sec>>16),
+		byte(sec>>8),
 		byte(sec),
-		byte(nsec >> 24), // bytes 9-12: nanoseconds
-		byte(nsec >> 16),
-		byte(nsec >> 8),
+		byte(nsec>>24), // bytes 9-12: nanoseconds
+		byte(nsec>>16),
+		byte(nsec>>8),
 		byte(nsec),
-		byte(offsetMin >> 8), // bytes 13-14: zone offset in minutes
+		byte(offsetMin>>8), // bytes 13-14: zone offset in minutes
 		byte(offsetMin),
-	}
-
-	return enc, nil
+	), nil
 }
 
 // UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// It also accepts the zoned encoding of MarshalBinaryZoned.
 func (t *Time) UnmarshalBinary(data []byte) error {
 	buf := data
 	if len(buf) == 0 {
 		return errors.New("Time.UnmarshalBinary: no data")
 	}
 
-	if buf[0] != timeBinaryVersion {
+	version := buf[0]
+	if version != timeBinaryVersion && version != timeBinaryVersionZoned {
 		return errors.New("Time.UnmarshalBinary: unsupported version")
 	}
 
-	if len(buf) != /*version*/ 1+ /*sec*/ 8+ /*nsec*/ 4+ /*zone offset*/ 2 {
+	const v1len = /*version*/ 1 + /*sec*/ 8 + /*nsec*/ 4 + /*zone offset*/ 2
+	if len(buf) < v1len || version == timeBinaryVersion && len(buf) != v1len {
 		return errors.New("Time.UnmarshalBinary: invalid length")
 	}
+	var loc *Location
+	if version == timeBinaryVersionZoned {
+		var err error
+		if loc, err = unmarshalBinaryZoned(buf[v1len:]); err != nil {
+			return err
+		}
+		buf = buf[:v1len]
+	}
 
 	buf = buf[1:]
 	sec := int64(buf[7]) | int64(buf[6])<<8 | int64(buf[5])<<16 | int64(buf[4])<<24 |
@@ -1212,7 +1390,9 @@ func (t *Time) UnmarshalBinary(data []byte) error {
 	t.wall = uint64(nsec)
 	t.ext = sec
 
-	if offset == -1*60 {
+	if loc != nil {
+		t.setLoc(loc)
+	} else if offset == -1*60 {
 		t.setLoc(&utcLoc)
 	} else if _, localoff, _, _, _ := Local.lookup(t.unixSec()); offset == localoff {
 		t.setLoc(Local)
@@ -1240,17 +1420,13 @@ func (t *Time) GobDecode(data []byte) error {
 // MarshalJSON implements the json.Marshaler interface.
 // The time is a quoted string in RFC 3339 format, with sub-second precision added if present.
 func (t Time) MarshalJSON() ([]byte, error) {
-	if y := t.Year(); y < 0 || y >= 10000 {
-		// RFC 3339 is clear that years are 4 digits exactly.
-		// See golang.org/issue/4556#c15 for more discussion.
-		return nil, errors.New("Time.MarshalJSON: year outside of range [0,9999]")
-	}
-
 	b := make([]byte, 0, len(RFC3339Nano)+2)
 	b = append(b, '"')
-	b = t.AppendFormat(b, RFC3339Nano)
-	b = append(b, '"')
-	return b, nil
+	b, err := t.appendText(b, "Time.MarshalJSON")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '"'), nil
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -1269,11 +1445,24 @@ func (t *Time) UnmarshalJSON(data []byte) error {
 // MarshalText implements the encoding.TextMarshaler interface.
 // The time is formatted in RFC 3339 format, with sub-second precision added if present.
 func (t Time) MarshalText() ([]byte, error) {
+	return t.appendText(make([]byte, 0, len(RFC3339Nano)), "Time.MarshalText")
+}
+
+// AppendText appends the encoding of MarshalText to b and returns the
+// extended buffer.
+func (t Time) AppendText(b []byte) ([]byte, error) {
+	return t.appendText(b, "Time.AppendText")
+}
+
+// appendText appends t in RFC 3339 format, with sub-second precision
+// added if present. The error, for years outside [0,9999], names
+// method.
+func (t Time) appendText(b []byte, method string) ([]byte, error) {
 	if y := t.Year(); y < 0 || y >= 10000 {
-		return nil, errors.New("Time.MarshalText: year outside of range [0,9999]")
+		// RFC 3339 is clear that years are 4 digits exactly.
+		// See golang.org/issue/4556#c15 for more discussion.
+		return nil, errors.New(method + ": year outside of range [0,9999]")
 	}
-
-	b := make([]byte, 0, len(RFC3339Nano))
 	return t.AppendFormat(b, RFC3339Nano), nil
 }
 
@@ -1304,6 +1493,18 @@ func Unix(sec int64, nsec int64) Time {
 	return unixTime(sec, int32(nsec))
 }
 
+// UnixMilli returns the local Time corresponding to the given Unix time,
+// msec milliseconds since January 1, 1970 UTC.
+func UnixMilli(msec int64) Time {
+	return Unix(msec/1e3, (msec%1e3)*1e6)
+}
+
+// UnixMicro returns the local Time corresponding to the given Unix time,
+// usec microseconds since January 1, 1970 UTC.
+func UnixMicro(usec int64) Time {
+	return Unix(usec/1e6, (usec%1e6)*1e3)
+}
+
 func isLeap(year int) bool {
 	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
 }
@@ -1357,28 +1558,8 @@ func Date(year int, month Month, day, hour, min, sec, nsec int, loc *Location) T
 	hour, min = norm(hour, min, 60)
 	day, hour = norm(day, hour, 24)
 
-	y := uint64(int64(year) - absoluteZeroYear)
-
 	// Compute days since the absolute epoch.
-
-	// Add in days from 400-year cycles.
-	n := y / 400
-	y -= 400 * n
-	d := daysPer400Years * n
-
-	// Add in 100-year cycles.
-	n = y / 100
-	y -= 100 * n
-	d += daysPer100Years * n
-
-	// Add in 4-year cycles.
-	n = y / 4
-	y -= 4 * n
-	d += daysPer4Years * n
-
-	// Add in non-leap years.
-	n = y
-	d += 365 * n
+	d := daysSinceEpoch(year)
 
 	// Add in days before this month.
 	d += uint64(daysBefore[month-1])
@@ -0,0 +1,62 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "errors"
+
+// AddDate normalizes its result as Date does, so one month after
+// 31 January is 3 March, or 2 March in a leap year, and the result
+// may be a wall clock time skipped or repeated by a change to or from
+// daylight saving time, which Date resolves arbitrarily. Billing
+// cycles and schedules need to choose; AddDateResolved lets them.
+// 带溢出策略和夏令时策略的 AddDate：月末溢出可截断或报错，间隙和重复时间按策略解析
+
+// A DayOverflow says what AddDateResolved does when the day of the
+// month of t is past the end of the month it reaches, such as the
+// 31st in a month of 30 days.
+type DayOverflow int
+
+const (
+	// NormalizeOverflow carries the extra days into the next month,
+	// as AddDate does: 31 January plus a month is 3 March.
+	NormalizeOverflow DayOverflow = iota
+
+	// ClampOverflow moves the day back to the last day of the month:
+	// 31 January plus a month is the last day of February.
+	ClampOverflow
+
+	// RejectOverflow returns ErrDayOverflow.
+	RejectOverflow
+)
+
+// ErrDayOverflow is returned by AddDateResolved with RejectOverflow.
+var ErrDayOverflow = errors.New("time: day of month past the end of the month")
+
+// AddDateResolved is like AddDate but says what to do with a day of
+// the month that is past the end of the month reached by adding years
+// and months, by overflow, and with a wall clock time skipped or
+// repeated by a transition in the Location of t, by policy as
+// ResolveLocal does. The days are added after the day of the month is
+// settled, so the last day of January plus one month and one day is
+// 1 March with ClampOverflow.
+//
+// With RejectOverflow or ResolveStrict, AddDateResolved returns the
+// zero Time and ErrDayOverflow, ErrSkippedLocalTime or
+// ErrRepeatedLocalTime when they apply.
+func (t Time) AddDateResolved(years, months, days int, overflow DayOverflow, policy ResolvePolicy) (Time, error) {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	y, m := normMonth(year+years, int(month)+months)
+	if last := daysIn(m, y); day > last {
+		switch overflow {
+		case ClampOverflow:
+			day = last
+		case RejectOverflow:
+			return Time{}, ErrDayOverflow
+		}
+	}
+	u, _, err := ResolveLocal(y, m, day+days, hour, min, sec, t.Nanosecond(), t.Location(), policy)
+	return u, err
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// Ages and anniversaries count calendar years and months, which
+// differ in length, so t.Sub(u) / (365*24*Hour) is a day off around
+// every birthday after a leap year. YearsBetween, MonthsBetween and
+// NextAnniversary count them by the calendar.
+// 按日历计算年龄和周年：年、月长短不一，不能用固定时长相除
+//
+// An anniversary falls on the same day of the month, at the same
+// clock time in the Location of the original date, unless its month
+// is too short: then an AnniversaryRule says where it falls. The
+// usual case is a birthday on 29 February.
+
+// An AnniversaryRule says on which day an anniversary falls in a
+// month without the day it is of, such as 29 February in a common
+// year or the 31st in a month of 30 days.
+type AnniversaryRule int
+
+const (
+	// ClampToMonthEnd moves the anniversary back to the last day of
+	// the month: 29 February to 28 February, and 31 January to the
+	// last day of February.
+	ClampToMonthEnd AnniversaryRule = iota
+
+	// RollToNextMonth moves the anniversary on to the first day of
+	// the next month: 29 February to 1 March, as for coming of age
+	// in the law of England and Wales.
+	RollToNextMonth
+)
+
+// YearsBetween returns the number of whole years from from to to:
+// the number of anniversaries of from, under rule, after from and not
+// after to. It is an age when from is a date of birth. If to is
+// before from, the result is minus the number of whole years from to
+// to from.
+func YearsBetween(from, to Time, rule AnniversaryRule) int {
+	return MonthsBetween(from, to, rule) / 12
+}
+
+// MonthsBetween returns the number of whole months from from to to:
+// the number of monthly anniversaries of from, under rule, after from
+// and not after to. If to is before from, the result is minus the
+// number of whole months from to to from.
+func MonthsBetween(from, to Time, rule AnniversaryRule) int {
+	if to.Before(from) {
+		return -MonthsBetween(to, from, rule)
+	}
+	fy, fm, _ := from.Date()
+	ty, tm, _ := to.In(from.Location()).Date()
+	n := (ty-fy)*12 + int(tm-fm)
+	for n > 0 && anniversary(from, n, rule).After(to) {
+		n--
+	}
+	for !anniversary(from, n+1, rule).After(to) {
+		n++
+	}
+	return n
+}
+
+// NextAnniversary returns the first yearly anniversary of t, under
+// rule, after the instant after: a date of birth gives the next
+// birthday. It returns t itself if after is before t. The result is in
+// the Location of t.
+func NextAnniversary(t, after Time, rule AnniversaryRule) Time {
+	if after.Before(t) {
+		return t
+	}
+	return anniversary(t, 12*(YearsBetween(t, after, rule)+1), rule)
+}
+
+// anniversary returns the time n months after t, in its Location,
+// with the day moved by rule if the month is too short.
+func anniversary(t Time, n int, rule AnniversaryRule) Time {
+	y, m, d := t.Date()
+	hour, min, sec := t.Clock()
+	y, m = normMonth(y, int(m)+n)
+	if last := daysIn(m, y); d > last {
+		d = last
+		if rule == RollToNextMonth {
+			d++
+		}
+	}
+	return Date(y, m, d, hour, min, sec, t.Nanosecond(), t.Location())
+}
+
+// normMonth returns year and the month m of it, with m moved into
+// January to December.
+func normMonth(year, m int) (int, Month) {
+	m--
+	year += m / 12
+	m %= 12
+	if m < 0 {
+		m += 12
+		year--
+	}
+	return year, Month(m + 1)
+}
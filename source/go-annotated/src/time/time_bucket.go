@@ -0,0 +1,77 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// Metrics and downsampling pipelines group times into buckets of a
+// fixed width, such as 5 minutes, an hour or a day, counted from an
+// origin: Bucket(t, 24*Hour, origin, loc) with origin at 06:00 local
+// time gives days that run from 06:00 to 06:00.
+// 按固定宽度将时间分桶，用于指标聚合和降采样；以天为单位的桶遵循当地日历
+//
+// Buckets shorter than a day, or whose width is not a whole number of
+// days, are measured in absolute time, so that every bucket has the
+// same length. Buckets of whole days follow the calendar of the
+// Location instead: each starts at the wall clock time of the origin,
+// and a day with a daylight saving time transition is an hour shorter
+// or longer, as it is on the clocks.
+
+// Bucket returns the start of the bucket of width width that contains
+// t, the buckets being counted from origin: the last bucket start at
+// or before t. The result is in loc. If width <= 0, Bucket returns t
+// in loc. Buckets of less than a day require t to be within about 290
+// years of origin, the range of a Duration. It panics if loc is nil.
+func Bucket(t Time, width Duration, origin Time, loc *Location) Time {
+	t = t.In(loc)
+	if width <= 0 {
+		return t
+	}
+	if width%(24*Hour) != 0 {
+		return origin.Add(Duration(floorDiv(int64(t.Sub(origin)), int64(width))) * width).In(loc)
+	}
+
+	// Count days on the wall clock, then find the instants that show
+	// the bucket starts, which a transition may have moved.
+	wt, wo := wallUTC(t), wallUTC(origin.In(loc))
+	n := floorDiv(int64(wt.Sub(wo)/(24*Hour)), int64(width/(24*Hour)))
+	days := int(width / (24 * Hour))
+	start := bucketStart(wo, int(n)*days, loc)
+	for start.After(t) {
+		n--
+		start = bucketStart(wo, int(n)*days, loc)
+	}
+	for {
+		next := bucketStart(wo, int(n+1)*days, loc)
+		if next.After(t) {
+			return start
+		}
+		n, start = n+1, next
+	}
+}
+
+// bucketStart returns the instant in loc that shows the wall clock
+// time w, read as UTC, days days later.
+func bucketStart(w Time, days int, loc *Location) Time {
+	year, month, day := w.Date()
+	hour, min, sec := w.Clock()
+	t, _, _ := ResolveLocal(year, month, day+days, hour, min, sec, w.Nanosecond(), loc, ResolveEarlier)
+	return t
+}
+
+// wallUTC returns the time in UTC with the wall clock time of t.
+func wallUTC(t Time) Time {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	return Date(year, month, day, hour, min, sec, t.Nanosecond(), UTC)
+}
+
+// floorDiv returns x/y rounded toward negative infinity. y must be
+// positive.
+func floorDiv(x, y int64) int64 {
+	q := x / y
+	if x%y < 0 {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,95 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "sync"
+
+// In a program built with the timefakenow build tag, as by
+//
+//	go test -tags timefakenow
+//
+// Now reports the time given by $GO_TIME_FAKE_NOW, if it is set when
+// the program starts, instead of the time of the system clock, so that
+// integration tests and snapshot builds that print the date can be run
+// reproducibly without changing their code. Programs built without
+// the tag ignore the variable. The value is one of:
+//
+//	2024-01-02T03:04:05Z    Now always returns this time
+//	@2024-01-02T03:04:05Z   the clock starts at this time, when Now is
+//	                        first called, and runs from there
+//	+72h, -30m              the time of the system clock, moved by a
+//	                        duration, as ParseDuration reads it
+//
+// Times are read as by ParseRFC3339. Only Now, and the functions and
+// methods that use it, such as Since and SystemClock.Now, see the fake
+// time; timers and Sleep wait in real time. A frozen time has no
+// monotonic clock reading. A value that cannot be read is reported
+// once on standard error, and Now then reports the system clock.
+// 用 timefakenow 标签构建时，设置 GO_TIME_FAKE_NOW 环境变量即可冻结或平移 Now 返回的时间，无需修改代码
+const fakeNowEnv = "GO_TIME_FAKE_NOW"
+
+var (
+	fakeNowOn    bool   // built with timefakenow and $GO_TIME_FAKE_NOW is set
+	fakeNowValue string // and has this value
+
+	fakeNowOnce   sync.Once
+	fakeNowFrozen bool     // Now returns fakeNowTime
+	fakeNowTime   Time     // the frozen time
+	fakeNowShift  Duration // else Now adds fakeNowShift to the system time
+	fakeNowBad    bool     // the value could not be read
+)
+
+// fakeNow returns the time Now reports when $GO_TIME_FAKE_NOW is set.
+func fakeNow() Time {
+	fakeNowOnce.Do(readFakeNow)
+	if fakeNowBad {
+		return systemNow()
+	}
+	if fakeNowFrozen {
+		return fakeNowTime
+	}
+	t := systemNow()
+	u := t.Add(fakeNowShift)
+	if u.wall&hasMonotonic != 0 {
+		// Only the wall clock moves.
+		u.ext = t.ext
+	}
+	return u
+}
+
+// readFakeNow reads the value of $GO_TIME_FAKE_NOW, reporting it on
+// standard error if it cannot.
+func readFakeNow() {
+	if err := parseFakeNow(fakeNowValue); err != nil {
+		fakeNowBad = true
+		println("time: ignoring invalid " + fakeNowEnv + " " + quote(fakeNowValue) + ": " + err.Error())
+	}
+}
+
+// parseFakeNow sets the fake time from v, a value of $GO_TIME_FAKE_NOW.
+func parseFakeNow(v string) error {
+	switch v[0] {
+	case '+', '-':
+		d, err := ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		fakeNowShift = d
+	case '@':
+		t, err := ParseRFC3339(v[1:])
+		if err != nil {
+			return err
+		}
+		fakeNowShift = t.Sub(systemNow())
+	default:
+		t, err := ParseRFC3339(v)
+		if err != nil {
+			return err
+		}
+		fakeNowFrozen = true
+		fakeNowTime = t.In(Local)
+	}
+	return nil
+}
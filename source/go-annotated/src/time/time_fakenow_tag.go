@@ -0,0 +1,18 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build timefakenow
+
+package time
+
+import "syscall"
+
+func init() {
+	// Only look at the variable here: reading a time may load the
+	// local time zone, which must wait until Local is first used.
+	fakeNowValue, fakeNowOn = syscall.Getenv(fakeNowEnv)
+	if fakeNowValue == "" {
+		fakeNowOn = false
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// Quarter returns the quarter of the year in which t occurs, 1 for
+// January to March through 4 for October to December.
+func (t Time) Quarter() int {
+	return (int(t.Month())-1)/3 + 1
+}
+
+// A FiscalCalendar says how an organization divides time into fiscal
+// years, quarters and periods, the months of the fiscal year.
+// 财年日历：财年起始月份、财年命名方式，以及 4-4-5 等按周划分的 52/53 周财年
+//
+// The zero FiscalCalendar is the calendar year, with periods that are
+// the months. A fiscal year starting in another month is named for
+// the calendar year in which it ends, as the United States federal
+// fiscal year 2025 began in October 2024, unless NamedForStart is set.
+//
+// A 52-53 week calendar, set by Weeks, has years of whole weeks, each
+// ending on EndWeekday at the end of the month before StartMonth:
+// the last such day in that month, or, with EndNearest, the one
+// nearest its last day. Each quarter has 13 weeks, split into periods
+// of Weeks weeks, such as 4, 4 and 5; the extra week of a 53-week
+// year goes to the last period.
+type FiscalCalendar struct {
+	StartMonth    Month // the month fiscal years start in; 0 means January
+	NamedForStart bool  // name years for the calendar year they start in
+
+	Weeks      [3]int  // weeks per period of a quarter, adding up to 13
+	EndWeekday Weekday // the day that ends a 52-53 week year
+	EndNearest bool    // end on the EndWeekday nearest the month's end
+}
+
+// Common fiscal calendars.
+var (
+	// USFederalFiscal is the fiscal year of the United States federal
+	// government, from October to September.
+	USFederalFiscal = FiscalCalendar{StartMonth: October}
+
+	// RetailFiscal is the 4-5-4 calendar of the National Retail
+	// Federation: 52-53 week years ending on the Saturday nearest the
+	// end of January, named for the year they start in.
+	RetailFiscal = FiscalCalendar{
+		StartMonth:    February,
+		NamedForStart: true,
+		Weeks:         [3]int{4, 5, 4},
+		EndWeekday:    Saturday,
+		EndNearest:    true,
+	}
+)
+
+// Year returns the fiscal year in which t occurs, in its Location.
+func (c FiscalCalendar) Year(t Time) int {
+	year, _, _ := c.locate(t)
+	return year
+}
+
+// Quarter returns the fiscal year and quarter, 1 to 4, in which t
+// occurs, in its Location.
+func (c FiscalCalendar) Quarter(t Time) (year, quarter int) {
+	year, period, _ := c.locate(t)
+	return year, (period-1)/3 + 1
+}
+
+// Period returns the fiscal year and period, 1 to 12, in which t
+// occurs, in its Location.
+func (c FiscalCalendar) Period(t Time) (year, period int) {
+	year, period, _ = c.locate(t)
+	return year, period
+}
+
+// YearStart returns the first instant of the fiscal year in loc. See
+// StartOfDay for what the first instant is.
+func (c FiscalCalendar) YearStart(year int, loc *Location) Time {
+	return c.PeriodStart(year, 1, loc)
+}
+
+// QuarterStart returns the first instant of the quarter of the fiscal
+// year in loc. Quarters out of range count on from the year:
+// quarter 5 is the first of the next year, and quarter 0 the last of
+// the year before.
+func (c FiscalCalendar) QuarterStart(year, quarter int, loc *Location) Time {
+	return c.PeriodStart(year, 3*quarter-2, loc)
+}
+
+// PeriodStart returns the first instant of the period of the fiscal
+// year in loc. Periods out of range count on from the year, as for
+// QuarterStart.
+func (c FiscalCalendar) PeriodStart(year, period int, loc *Location) Time {
+	year, period = normPeriod(year, period)
+	y, m, d := civilDate(c.periodStart(year, period))
+	return startOfDate(y, m, d, loc)
+}
+
+// AddPeriods returns t moved n periods, in its Location, keeping its
+// clock reading and its day within the period: the 31st day of a
+// period of 31 days moved to one of 28 becomes the 28th. The result
+// is in the Location of t. AddPeriods(t, 3) moves t a quarter, and
+// AddPeriods(t, 12) a year; so, unlike t.AddDate(0, 1, 0), moving
+// 31 January a period gives the last day of February.
+func (c FiscalCalendar) AddPeriods(t Time, n int) Time {
+	year, period, start := c.locate(t)
+	y, m, d := t.Date()
+	day := civilDays(y, m, d) - start
+
+	year, period = normPeriod(year, period+n)
+	start = c.periodStart(year, period)
+	if last := c.periodStart(year, period+1) - start - 1; day > last {
+		day = last
+	}
+	y, m, d = civilDate(start + day)
+	hour, min, sec := t.Clock()
+	return Date(y, m, d, hour, min, sec, t.Nanosecond(), t.Location())
+}
+
+// locate returns the fiscal year and period of t in its Location, and
+// the day number, counting days from the Unix epoch, on which the
+// period starts.
+func (c FiscalCalendar) locate(t Time) (year, period int, start int64) {
+	y, m, d := t.Date()
+	day := civilDays(y, m, d)
+	// The fiscal year is named for one of the calendar years around.
+	year = y - 1
+	if !c.NamedForStart && c.startMonth() > January {
+		year++
+	}
+	for c.periodStart(year+1, 1) <= day {
+		year++
+	}
+	for c.periodStart(year, 1) > day {
+		year--
+	}
+	period = 1
+	for period < 12 && c.periodStart(year, period+1) <= day {
+		period++
+	}
+	return year, period, c.periodStart(year, period)
+}
+
+// normPeriod returns year and period with period moved into [1, 12].
+func normPeriod(year, period int) (int, int) {
+	year, m := normMonth(year, period)
+	return year, int(m)
+}
+
+func (c FiscalCalendar) startMonth() Month {
+	if c.StartMonth < January || c.StartMonth > December {
+		return January
+	}
+	return c.StartMonth
+}
+
+// firstYear returns the calendar year in whose StartMonth the fiscal
+// year starts; a 52-53 week year may start a few days before it.
+func (c FiscalCalendar) firstYear(year int) int {
+	if !c.NamedForStart && c.startMonth() > January {
+		return year - 1
+	}
+	return year
+}
+
+// periodStart returns the day number of the first day of the period,
+// from 1 to 13, of the fiscal year; period 13 is the first of the
+// next year.
+func (c FiscalCalendar) periodStart(year, period int) int64 {
+	if c.Weeks == [3]int{} {
+		return civilDays(c.firstYear(year), c.startMonth()+Month(period-1), 1)
+	}
+	if period == 13 {
+		return c.yearEnd(year) + 1
+	}
+	q, i := (period-1)/3, (period-1)%3
+	weeks := 13 * q
+	for _, w := range c.Weeks[:i] {
+		weeks += w
+	}
+	return c.yearEnd(year-1) + 1 + int64(7*weeks)
+}
+
+// yearEnd returns the day number of the last day of a 52-53 week
+// fiscal year.
+func (c FiscalCalendar) yearEnd(year int) int64 {
+	// The last day of the month before StartMonth.
+	end := civilDays(c.firstYear(year)+1, c.startMonth(), 0)
+	// 1 January 1970 was a Thursday.
+	wd := ((end+int64(Thursday))%7 + 7) % 7
+	back := (int(wd) - int(c.EndWeekday) + 7) % 7
+	if c.EndNearest && back > 3 {
+		return end + int64(7-back)
+	}
+	return end - int64(back)
+}
+
+// civilDays returns the number of days from the Unix epoch to the
+// given date, normalizing it as Date does.
+func civilDays(year int, month Month, day int) int64 {
+	return Date(year, month, day, 0, 0, 0, 0, UTC).Unix() / secondsPerDay
+}
+
+// civilDate returns the date n days from the Unix epoch.
+func civilDate(n int64) (year int, month Month, day int) {
+	return Unix(n*secondsPerDay, 0).UTC().Date()
+}
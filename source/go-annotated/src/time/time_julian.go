@@ -0,0 +1,73 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// Julian dates count days, with a fraction, from noon UTC on
+// 1 January 4713 BC in the proleptic Julian calendar, which is
+// 24 November 4714 BC, year -4713, in the proleptic Gregorian calendar
+// of Time; the Unix epoch is JD 2440587.5. Modified Julian dates count from midnight at the
+// start of 17 November 1858, JD 2400000.5, so that they start at
+// midnight and need fewer digits.
+// 儒略日（JD）与简化儒略日（MJD）的换算，天文和卫星软件常用
+//
+// The time scale is UTC as a Time sees it: days of 86400 seconds,
+// with leap seconds not counted, as in Unix time. Astronomical
+// software often wants JD in another scale, such as TT or TAI; for
+// those, add the difference, which for TAI is that of UTCToTAI, to
+// the Time before converting, or subtract it after. A float64 JD
+// resolves about 40 microseconds today; an MJD, about 1 microsecond.
+const (
+	julianUnixEpoch = 2440587.5 // JD of the Unix epoch
+	mjdUnixEpoch    = 40587     // MJD of the Unix epoch
+)
+
+// JulianDate returns the Julian date of t, in UTC.
+func (t Time) JulianDate() float64 {
+	days, frac := t.unixDays()
+	return (float64(days) + julianUnixEpoch) + frac
+}
+
+// ModifiedJulianDate returns the modified Julian date of t, in UTC:
+// its Julian date minus 2400000.5.
+func (t Time) ModifiedJulianDate() float64 {
+	days, frac := t.unixDays()
+	return float64(days+mjdUnixEpoch) + frac
+}
+
+// unixDays returns the whole days and the fraction of a day from the
+// Unix epoch to t.
+func (t Time) unixDays() (days int64, frac float64) {
+	sec := t.unixSec()
+	days = sec / secondsPerDay
+	if sec%secondsPerDay < 0 {
+		days--
+	}
+	sec -= days * secondsPerDay
+	return days, (float64(sec) + float64(t.nsec())/1e9) / secondsPerDay
+}
+
+// FromJulianDate returns the Time, in UTC, of the Julian date jd,
+// rounded to the nearest nanosecond. The result is undefined if jd is
+// not a finite number or the Time is out of range.
+func FromJulianDate(jd float64) Time {
+	return fromUnixDays(jd - julianUnixEpoch)
+}
+
+// FromModifiedJulianDate returns the Time, in UTC, of the modified
+// Julian date mjd, rounded to the nearest nanosecond. The result is
+// undefined if mjd is not a finite number or the Time is out of range.
+func FromModifiedJulianDate(mjd float64) Time {
+	return fromUnixDays(mjd - mjdUnixEpoch)
+}
+
+// fromUnixDays returns the Time, in UTC, d days after the Unix epoch.
+func fromUnixDays(d float64) Time {
+	days := int64(d)
+	if float64(days) > d {
+		days-- // round toward minus infinity
+	}
+	ns := int64((d-float64(days))*secondsPerDay*1e9 + 0.5)
+	return Unix(days*secondsPerDay, ns).UTC()
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time_test
+
+import (
+	"math"
+	"testing"
+	. "time"
+)
+
+var julianTests = []struct {
+	name    string
+	t       Time
+	jd, mjd float64
+}{
+	{"JD 0", Date(-4713, November, 24, 12, 0, 0, 0, UTC), 0, -2400000.5},
+	{"before JD 0", Date(-4713, November, 24, 0, 0, 0, 0, UTC), -0.5, -2400001},
+	{"MJD 0", Date(1858, November, 17, 0, 0, 0, 0, UTC), 2400000.5, 0},
+	{"before MJD 0", Date(1858, November, 16, 18, 0, 0, 0, UTC), 2400000.25, -0.25},
+	{"after MJD 0", Date(1858, November, 17, 6, 0, 0, 0, UTC), 2400000.75, 0.25},
+	{"Unix epoch", Date(1970, January, 1, 0, 0, 0, 0, UTC), 2440587.5, 40587},
+	{"before Unix epoch", Date(1969, December, 31, 12, 0, 0, 0, UTC), 2440587, 40586.5},
+	{"after Unix epoch", Date(1970, January, 1, 12, 0, 0, 0, UTC), 2440588, 40587.5},
+	{"J2000", Date(2000, January, 1, 12, 0, 0, 0, UTC), 2451545, 51544.5},
+	{"J2000 in another zone", Date(2000, January, 1, 13, 0, 0, 0, FixedZone("CET", 3600)), 2451545, 51544.5},
+}
+
+func TestJulianDate(t *testing.T) {
+	for _, tt := range julianTests {
+		if jd := tt.t.JulianDate(); jd != tt.jd {
+			t.Errorf("%s: %v.JulianDate() = %v, want %v", tt.name, tt.t, jd, tt.jd)
+		}
+		if mjd := tt.t.ModifiedJulianDate(); mjd != tt.mjd {
+			t.Errorf("%s: %v.ModifiedJulianDate() = %v, want %v", tt.name, tt.t, mjd, tt.mjd)
+		}
+		if got := FromJulianDate(tt.jd); !got.Equal(tt.t) || got.Location() != UTC {
+			t.Errorf("%s: FromJulianDate(%v) = %v, want %v", tt.name, tt.jd, got, tt.t.UTC())
+		}
+		if got := FromModifiedJulianDate(tt.mjd); !got.Equal(tt.t) || got.Location() != UTC {
+			t.Errorf("%s: FromModifiedJulianDate(%v) = %v, want %v", tt.name, tt.mjd, got, tt.t.UTC())
+		}
+	}
+}
+
+// TestJulianDateNearBoundaries checks times a second and a millisecond
+// on either side of each boundary, where the whole days change sign or
+// value: the dates must be within the resolution of a float64 of the
+// exact ones, and convert back within it.
+func TestJulianDateNearBoundaries(t *testing.T) {
+	const (
+		jdResolution  = 100 * Microsecond // about 40µs in 2000, less before
+		mjdResolution = 10 * Microsecond
+	)
+	for _, tt := range julianTests {
+		for _, d := range []Duration{-Second, -Millisecond, Millisecond, Second} {
+			tm := tt.t.Add(d)
+			days := d.Seconds() / 86400
+			if jd := tm.JulianDate(); math.Abs(jd-(tt.jd+days))*86400 > jdResolution.Seconds() {
+				t.Errorf("%s%+v: JulianDate() = %v, want %v", tt.name, d, jd, tt.jd+days)
+			}
+			if mjd := tm.ModifiedJulianDate(); math.Abs(mjd-(tt.mjd+days))*86400 > mjdResolution.Seconds() {
+				t.Errorf("%s%+v: ModifiedJulianDate() = %v, want %v", tt.name, d, mjd, tt.mjd+days)
+			}
+			if got := FromJulianDate(tm.JulianDate()); got.Sub(tm).Abs() > jdResolution {
+				t.Errorf("%s%+v: FromJulianDate(JulianDate()) = %v, want %v", tt.name, d, got, tm)
+			}
+			if got := FromModifiedJulianDate(tm.ModifiedJulianDate()); got.Sub(tm).Abs() > mjdResolution {
+				t.Errorf("%s%+v: FromModifiedJulianDate(ModifiedJulianDate()) = %v, want %v", tt.name, d, got, tm)
+			}
+		}
+	}
+}
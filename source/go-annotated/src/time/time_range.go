@@ -0,0 +1,79 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// Reports bucket time by day, week or month. Stepping a Time by
+// 24*Hour drifts by an hour at each daylight saving time transition,
+// and AddDate(0, 1, 0) from the 31st skips months; the iterators here
+// step the date instead and give the first instant of each day, week
+// or month in a Location, as the StartOf functions do:
+//	it := time.IterateDays(from, to, loc)
+//	for start, ok := it.Next(); ok; start, ok = it.Next() {
+//		...
+//	}
+// 按日、周、月遍历时间区间，返回各自在时区中的开始时刻，不受夏令时影响
+
+// A DateIter produces the first instants of consecutive days, weeks or
+// months in a Location, in order.
+type DateIter struct {
+	loc    *Location
+	to     Time
+	year   int
+	month  Month
+	day    int
+	months int // step
+	days   int // step
+}
+
+// IterateDays returns an iterator over the days in loc that overlap
+// the interval from from to to, not including to: it produces the
+// first instant of the day containing from, then that of each
+// following day that starts before to. It panics if loc is nil.
+func IterateDays(from, to Time, loc *Location) *DateIter {
+	y, m, d := from.In(loc).Date()
+	return newDateIter(y, m, d, to, loc, 0, 1)
+}
+
+// IterateWeeks is like IterateDays for the weeks of ISOWeeks, which
+// start on Monday. For other rules, see WeekRule.IterateWeeks.
+func IterateWeeks(from, to Time, loc *Location) *DateIter {
+	return ISOWeeks.IterateWeeks(from, to, loc)
+}
+
+// IterateWeeks is like IterateDays for the weeks of r.
+func (r WeekRule) IterateWeeks(from, to Time, loc *Location) *DateIter {
+	y, m, d := r.StartOfWeek(from, loc).Date()
+	return newDateIter(y, m, d, to, loc, 0, 7)
+}
+
+// IterateMonths is like IterateDays for months.
+func IterateMonths(from, to Time, loc *Location) *DateIter {
+	y, m, _ := from.In(loc).Date()
+	return newDateIter(y, m, 1, to, loc, 1, 0)
+}
+
+func newDateIter(year int, month Month, day int, to Time, loc *Location, months, days int) *DateIter {
+	return &DateIter{
+		loc:    loc,
+		to:     to,
+		year:   year,
+		month:  month,
+		day:    day,
+		months: months,
+		days:   days,
+	}
+}
+
+// Next returns the first instant of the next day, week or month, or
+// false when there are no more.
+func (it *DateIter) Next() (Time, bool) {
+	t := startOfDate(it.year, it.month, it.day, it.loc)
+	if !t.Before(it.to) {
+		return Time{}, false
+	}
+	// Date normalizes the next date, as 32 January to 1 February.
+	it.year, it.month, it.day = Date(it.year, it.month+Month(it.months), it.day+it.days, 0, 0, 0, 0, UTC).Date()
+	return t, true
+}
@@ -0,0 +1,67 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// TruncateIn and RoundIn are Truncate and Round on the wall clock of
+// a Location: TruncateIn(t, 24*Hour, loc) is local midnight, and
+// TruncateIn(t, Hour, loc) is on the hour in zones whose offset is
+// not a whole number of hours, which Truncate, working on absolute
+// time, only gets right in UTC.
+// 按某时区的墙上时间进行 Truncate 和 Round，例如截断到当地午夜，处理夏令时
+//
+// The wall clock time of t in loc is rounded as if it were in UTC,
+// and the result is the instant showing the rounded wall clock time
+// in loc. If a transition skipped that time, as on the day daylight
+// saving time starts, the result is the first instant after the gap;
+// if it repeated it, the result is the occurrence on the side of t
+// that the rounding went: the later one not after t when rounding
+// down, the earlier one not before t when rounding up. The result is
+// in loc.
+
+// TruncateIn returns the result of rounding t down to a multiple of
+// d on the wall clock of loc. If d <= 0, it returns t in loc.
+// It panics if loc is nil.
+func TruncateIn(t Time, d Duration, loc *Location) Time {
+	return roundIn(t, d, loc, false)
+}
+
+// RoundIn returns the result of rounding t to the nearest multiple of
+// d on the wall clock of loc, rounding halfway values up. Distances
+// are measured on the wall clock, so that across a gap the nearest
+// multiple may not be the nearest instant. If d <= 0, it returns t in
+// loc. It panics if loc is nil.
+func RoundIn(t Time, d Duration, loc *Location) Time {
+	return roundIn(t, d, loc, true)
+}
+
+func roundIn(t Time, d Duration, loc *Location, round bool) Time {
+	t = t.In(loc)
+	if d <= 0 {
+		return t
+	}
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	w := Date(year, month, day, hour, min, sec, t.Nanosecond(), UTC)
+	var r Time
+	if round {
+		r = w.Round(d)
+	} else {
+		r = w.Truncate(d)
+	}
+	if r.Equal(w) {
+		return t
+	}
+
+	year, month, day = r.Date()
+	hour, min, sec = r.Clock()
+	u, kind, _ := ResolveLocal(year, month, day, hour, min, sec, r.Nanosecond(), loc, ResolveEarlier)
+	if kind == LocalTimeRepeated {
+		v, _, _ := ResolveLocal(year, month, day, hour, min, sec, r.Nanosecond(), loc, ResolveLater)
+		if r.Before(w) && !v.After(t) || r.After(w) && u.Before(t) {
+			u = v
+		}
+	}
+	return u
+}
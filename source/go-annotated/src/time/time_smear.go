@@ -0,0 +1,102 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// A LeapMode says how the conversions to and from TAI and GPS time
+// treat a Time near a leap second. Systems whose clocks follow a
+// smeared NTP server, as at Google and AWS, never see a leap second:
+// their clocks instead run slightly slow around it, and their
+// timestamps must be converted with LeapSmear.
+// 闰秒处理方式：插入闰秒（LeapStep）或在 24 小时内平滑摊开（LeapSmear）
+type LeapMode int
+
+const (
+	// LeapStep takes a Time to be in UTC, with each leap second
+	// inserted as a 61st second of the minute, as UTCToTAI does.
+	LeapStep LeapMode = iota
+
+	// LeapSmear takes a Time to be from a clock that spreads each
+	// leap second linearly over the 24 hours from noon to noon UTC
+	// around it, so that each of those seconds lasts 1+1/86400 SI
+	// seconds and the clock shows no 23:59:60.
+	LeapSmear
+)
+
+// smearHalf is half the length of a smear, in seconds of the clock.
+const smearHalf = 12 * secondsPerHour
+
+// TAI is like UTCToTAI but converts t according to m, and returns
+// the fraction of a second too, which in LeapSmear mode differs from
+// t.Nanosecond() during a smear.
+// 按照 m 把 t 换算成 TAI 秒数和纳秒
+func (m LeapMode) TAI(t Time) (sec, nsec int64, err error) {
+	usec := t.Unix()
+	off, err := taiOffset(usec)
+	if err != nil {
+		return 0, 0, err
+	}
+	sec, nsec = usec+off, int64(t.Nanosecond())
+	if m != LeapSmear {
+		return sec, nsec, nil
+	}
+	for i := 1; i < len(taiLeap); i++ {
+		l := taiLeap[i]
+		if usec < l.when-smearHalf || l.when+smearHalf <= usec {
+			continue
+		}
+		// Stretch the clock time since the start of the smear by
+		// 86401/86400 and count it from the start on the TAI scale.
+		start := l.when - smearHalf
+		d := (usec-start)*1e9 + nsec
+		d += d / (2 * smearHalf)
+		sec = start + taiLeap[i-1].offset + d/1e9
+		nsec = d % 1e9
+		break
+	}
+	return sec, nsec, nil
+}
+
+// FromTAI is like TAIToUTC but converts according to m. In LeapSmear
+// mode the result is the time the smeared clock shows, so every TAI
+// second has its own Time and none maps to the second before it.
+func (m LeapMode) FromTAI(sec, nsec int64) (Time, error) {
+	t, err := TAIToUTC(sec, nsec)
+	if err != nil || m != LeapSmear {
+		return t, err
+	}
+	sec, nsec = sec+nsec/1e9, nsec%1e9
+	if nsec < 0 {
+		sec--
+		nsec += 1e9
+	}
+	for i := 1; i < len(taiLeap); i++ {
+		l := taiLeap[i]
+		start := l.when - smearHalf + taiLeap[i-1].offset
+		if sec < start || start+2*smearHalf+1 <= sec {
+			continue
+		}
+		// Shrink the TAI time since the start of the smear by
+		// 86400/86401, rounding up so that FromTAI undoes TAI.
+		d := (sec-start)*1e9 + nsec
+		d = (d*(2*smearHalf) + 2*smearHalf) / (2*smearHalf + 1)
+		return Unix(l.when-smearHalf, d).UTC(), nil
+	}
+	return t, nil
+}
+
+// GPS is like UTCToGPS but converts t according to m, as TAI does.
+func (m LeapMode) GPS(t Time) (sec, nsec int64, err error) {
+	sec, nsec, err = m.TAI(t)
+	if err != nil {
+		return 0, 0, err
+	}
+	return sec - taiMinusGPS - gpsEpoch, nsec, nil
+}
+
+// FromGPS is like GPSToUTC but converts according to m, as FromTAI
+// does.
+func (m LeapMode) FromGPS(sec, nsec int64) (Time, error) {
+	return m.FromTAI(sec+taiMinusGPS+gpsEpoch, nsec)
+}
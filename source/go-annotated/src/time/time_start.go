@@ -0,0 +1,54 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// The StartOf functions return the first instant of the day, week,
+// month, quarter or year containing t, as seen by the clocks in loc.
+// Unlike t.Truncate(24*Hour), which works on absolute time and so is
+// only right in UTC, they follow the calendar of loc.
+// 按 loc 的日历取一天、一周、一月、一季度、一年的开始时刻；Truncate(24*Hour) 只在 UTC 下正确
+//
+// The first instant is normally midnight. In zones where a daylight
+// saving time transition skips midnight, such as America/Sao_Paulo
+// before 2019, it is the first instant after the gap; where a
+// transition repeats midnight, it is the first of the two.
+// The result is in loc.
+
+// StartOfDay returns the first instant of the day containing t in loc.
+func StartOfDay(t Time, loc *Location) Time {
+	y, m, d := t.In(loc).Date()
+	return startOfDate(y, m, d, loc)
+}
+
+// StartOfWeek returns the first instant of the week containing t in
+// loc. Weeks start on Monday, as in ISO 8601.
+// For other rules, see WeekRule.StartOfWeek.
+func StartOfWeek(t Time, loc *Location) Time {
+	return ISOWeeks.StartOfWeek(t, loc)
+}
+
+// StartOfMonth returns the first instant of the month containing t in loc.
+func StartOfMonth(t Time, loc *Location) Time {
+	y, m, _ := t.In(loc).Date()
+	return startOfDate(y, m, 1, loc)
+}
+
+// StartOfQuarter returns the first instant of the quarter containing
+// t in loc. For fiscal quarters, see FiscalCalendar.QuarterStart.
+func StartOfQuarter(t Time, loc *Location) Time {
+	y, m, _ := t.In(loc).Date()
+	return startOfDate(y, m-(m-1)%3, 1, loc)
+}
+
+// StartOfYear returns the first instant of the year containing t in loc.
+func StartOfYear(t Time, loc *Location) Time {
+	return startOfDate(t.In(loc).Year(), January, 1, loc)
+}
+
+// startOfDate returns the first instant of the given date in loc.
+func startOfDate(year int, month Month, day int, loc *Location) Time {
+	t, _, _ := ResolveLocal(year, month, day, 0, 0, 0, 0, loc, ResolveEarlier)
+	return t
+}
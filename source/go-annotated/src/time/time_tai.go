@@ -0,0 +1,142 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "errors"
+
+// Conversions between UTC and the TAI and GPS time scales, which
+// count leap seconds. A Time, like Unix time, does not: each UTC day
+// is 86400 seconds long. TAI is ahead of UTC by 10 seconds plus the
+// leap seconds inserted since 1972; GPS time is 19 seconds behind TAI.
+// UTC 与 TAI、GPS 时间尺度之间的换算（计入闰秒）
+
+// taiLeap is the IERS table of the difference TAI-UTC, from
+// leap-seconds.list: starting at the Unix time when, TAI is offset
+// seconds ahead of UTC.
+var taiLeap = [...]struct {
+	when   int64
+	offset int64
+}{
+	{63072000, 10},   // 1 Jan 1972
+	{78796800, 11},   // 1 Jul 1972
+	{94694400, 12},   // 1 Jan 1973
+	{126230400, 13},  // 1 Jan 1974
+	{157766400, 14},  // 1 Jan 1975
+	{189302400, 15},  // 1 Jan 1976
+	{220924800, 16},  // 1 Jan 1977
+	{252460800, 17},  // 1 Jan 1978
+	{283996800, 18},  // 1 Jan 1979
+	{315532800, 19},  // 1 Jan 1980
+	{362793600, 20},  // 1 Jul 1981
+	{394329600, 21},  // 1 Jul 1982
+	{425865600, 22},  // 1 Jul 1983
+	{489024000, 23},  // 1 Jul 1985
+	{567993600, 24},  // 1 Jan 1988
+	{631152000, 25},  // 1 Jan 1990
+	{662688000, 26},  // 1 Jan 1991
+	{709948800, 27},  // 1 Jul 1992
+	{741484800, 28},  // 1 Jul 1993
+	{773020800, 29},  // 1 Jul 1994
+	{820454400, 30},  // 1 Jan 1996
+	{867715200, 31},  // 1 Jul 1997
+	{915148800, 32},  // 1 Jan 1999
+	{1136073600, 33}, // 1 Jan 2006
+	{1230768000, 34}, // 1 Jan 2009
+	{1341100800, 35}, // 1 Jul 2012
+	{1435708800, 36}, // 1 Jul 2015
+	{1483228800, 37}, // 1 Jan 2017
+}
+
+// taiLeapExpires is the Unix time at which taiLeap expires, 28 June
+// 2026: no leap second has been announced before then, but one may
+// be inserted after.
+const taiLeapExpires = 1782604800
+
+const (
+	taiMinusGPS = 19        // seconds TAI is ahead of GPS time
+	gpsEpoch    = 315964800 // 6 Jan 1980 00:00:00 UTC, as a Unix time
+)
+
+// ErrLeapSecondsUnknown is returned when converting a time for which
+// the number of leap seconds is not known: before 1972, when UTC did
+// not yet use leap seconds, or after the leap second table expires,
+// when a leap second may have been inserted that the table does not
+// list.
+var ErrLeapSecondsUnknown = errors.New("time: leap seconds unknown at time")
+
+// taiOffset returns TAI-UTC at the Unix time sec.
+func taiOffset(sec int64) (int64, error) {
+	if sec < taiLeap[0].when || taiLeapExpires <= sec {
+		return 0, ErrLeapSecondsUnknown
+	}
+	i := len(taiLeap) - 1
+	for taiLeap[i].when > sec {
+		i--
+	}
+	return taiLeap[i].offset, nil
+}
+
+// UTCToTAI returns t as a count of TAI seconds since the Unix epoch,
+// 1970-01-01 00:00:00 UTC, which is Unix time plus TAI-UTC. The
+// fraction of a second, t.Nanosecond(), is the same on both scales.
+// It returns ErrLeapSecondsUnknown for times before 1972 or after the
+// leap second table of this package expires in June 2026.
+// 把 UTC 时间换算成 TAI 秒数
+func UTCToTAI(t Time) (int64, error) {
+	sec := t.Unix()
+	off, err := taiOffset(sec)
+	if err != nil {
+		return 0, err
+	}
+	return sec + off, nil
+}
+
+// TAIToUTC returns the Time, in UTC, for sec TAI seconds and nsec
+// nanoseconds since the Unix epoch, as returned by UTCToTAI. Since a
+// Time cannot represent a leap second (23:59:60), an inserted leap
+// second maps to the second before it. It returns
+// ErrLeapSecondsUnknown outside the range of UTCToTAI.
+func TAIToUTC(sec, nsec int64) (Time, error) {
+	sec += nsec / 1e9
+	nsec %= 1e9
+	if nsec < 0 {
+		sec--
+		nsec += 1e9
+	}
+	if sec < taiLeap[0].when+taiLeap[0].offset || taiLeapExpires+taiLeap[len(taiLeap)-1].offset <= sec {
+		return Time{}, ErrLeapSecondsUnknown
+	}
+	i := len(taiLeap) - 1
+	for taiLeap[i].when+taiLeap[i].offset > sec {
+		i--
+	}
+	unix := sec - taiLeap[i].offset
+	if i+1 < len(taiLeap) && unix >= taiLeap[i+1].when {
+		// sec is the leap second inserted before entry i+1.
+		unix = taiLeap[i+1].when - 1
+	}
+	return Unix(unix, nsec).UTC(), nil
+}
+
+// UTCToGPS returns t as a count of GPS seconds since the GPS epoch,
+// 1980-01-06 00:00:00 UTC. GPS time counts leap seconds but, unlike
+// TAI, not those before its epoch, and is 19 seconds behind TAI. The
+// fraction of a second is t.Nanosecond(). It returns
+// ErrLeapSecondsUnknown as UTCToTAI does.
+// 把 UTC 时间换算成 GPS 秒数
+func UTCToGPS(t Time) (int64, error) {
+	tai, err := UTCToTAI(t)
+	if err != nil {
+		return 0, err
+	}
+	return tai - taiMinusGPS - gpsEpoch, nil
+}
+
+// GPSToUTC returns the Time, in UTC, for sec GPS seconds and nsec
+// nanoseconds since the GPS epoch, as returned by UTCToGPS. Leap
+// seconds and errors are handled as by TAIToUTC.
+func GPSToUTC(sec, nsec int64) (Time, error) {
+	return TAIToUTC(sec+taiMinusGPS+gpsEpoch, nsec)
+}
@@ -0,0 +1,159 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// A WeekRule says how a calendar divides days into weeks: which day
+// starts a week, and how many days of a week must fall in a new year
+// or month for it to count as that year's or month's first week.
+// 周的划分规则：一周从哪天开始，以及第一周至少需要几天
+//
+// The zero WeekRule starts weeks on Sunday, with week 1 being the
+// week that contains the first day, as in the United States.
+type WeekRule struct {
+	FirstDay Weekday // the day that starts a week
+	MinDays  int     // 1 to 7; 0 means 1
+}
+
+// Common week rules.
+var (
+	// ISOWeeks starts weeks on Monday; week 1 is the first week with
+	// at least 4 days in the year, that is the week with the first
+	// Thursday. Its WeekOfYear is Time.ISOWeek.
+	ISOWeeks = WeekRule{FirstDay: Monday, MinDays: 4}
+
+	// USWeeks starts weeks on Sunday; week 1 is the week with the
+	// first day of the year.
+	USWeeks = WeekRule{FirstDay: Sunday, MinDays: 1}
+)
+
+// WeekRuleFor returns the week rule of the region with the given
+// ISO 3166 code, such as "US" or "DE", as listed in the weekData of
+// the Unicode CLDR. Regions not listed there use Monday and a first
+// week of one day.
+func WeekRuleFor(region string) WeekRule {
+	r := WeekRule{FirstDay: Monday, MinDays: 1}
+	if len(region) != 2 {
+		return r
+	}
+	code := string([]byte{upperASCII(region[0]), upperASCII(region[1])})
+	switch {
+	case regionIn(code, sundayRegions):
+		r.FirstDay = Sunday
+	case regionIn(code, saturdayRegions):
+		r.FirstDay = Saturday
+	}
+	if regionIn(code, minDays4Regions) {
+		r.MinDays = 4
+	}
+	return r
+}
+
+// Regions by first day of the week and first-week length, from the
+// firstDay and minDays elements of CLDR's supplemental weekData.
+const (
+	sundayRegions = "AG AS BD BR BS BT BW BZ CA CO DM DO ET GT GU HK HN ID IL IN JM JP KE KH KR LA MH MM MO MT MX MZ NI NP PA PE PH PK PR PT PY SA SG SV TH TT TW UM US VE VI WS YE ZA ZW"
+
+	saturdayRegions = "AE AF BH DJ DZ EG IQ IR JO KW LY OM QA SD SY"
+
+	minDays4Regions = "AD AN AT AX BE BG CH CZ DE DK EE ES FI FJ FO FR GB GF GG GI GP GR HU IE IM IS IT JE LI LT LU MC MQ NL NO PL PT RE RU SE SJ SK SM VA"
+)
+
+// regionIn reports whether the two-letter code is in list.
+func regionIn(code, list string) bool {
+	for i := 0; i+2 <= len(list); i += 3 {
+		if list[i:i+2] == code {
+			return true
+		}
+	}
+	return false
+}
+
+func upperASCII(c byte) byte {
+	if 'a' <= c && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	return c
+}
+
+// StartOfWeek returns the first instant of the week containing t in
+// loc, under rule r. See StartOfDay for what the first instant is.
+func (r WeekRule) StartOfWeek(t Time, loc *Location) Time {
+	t = t.In(loc)
+	y, m, d := t.Date()
+	return startOfDate(y, m, d-r.daysIntoWeek(t.Weekday()), loc)
+}
+
+// NextWeek returns the first instant of the week after the one
+// containing t in loc, under rule r. Calling it repeatedly walks
+// the weeks of a calendar.
+func (r WeekRule) NextWeek(t Time, loc *Location) Time {
+	t = t.In(loc)
+	y, m, d := t.Date()
+	return startOfDate(y, m, d-r.daysIntoWeek(t.Weekday())+7, loc)
+}
+
+// WeekOfYear returns the week-based year and week number in which t
+// occurs, under rule r. Week 1 is the first week with at least
+// MinDays days in the year. As with ISOWeek, the first days of
+// January may belong to the last week of the year before, and the
+// last days of December to week 1 of the year after.
+func (r WeekRule) WeekOfYear(t Time) (year, week int) {
+	year = t.Year()
+	yday := t.YearDay()
+	start := r.firstWeekStart(t.Weekday(), yday)
+	if yday < start {
+		// In the last week of the year before.
+		year--
+		yday += daysInYear(year)
+		start = r.firstWeekStart(t.Weekday(), yday)
+	} else if next := daysInYear(year) + r.firstWeekStart(t.Weekday(), yday-daysInYear(year)); yday >= next {
+		return year + 1, 1
+	}
+	return year, (yday-start)/7 + 1
+}
+
+// WeekOfMonth returns the week of its month in which t occurs, under
+// rule r. Week 1 is the first week with at least MinDays days in the
+// month; days before it are in week 0.
+func (r WeekRule) WeekOfMonth(t Time) int {
+	day := t.Day()
+	start := r.firstWeekStart(t.Weekday(), day)
+	if day < start {
+		return 0
+	}
+	return (day-start)/7 + 1
+}
+
+// daysIntoWeek returns the number of days from the start of the
+// week to a day with weekday wd.
+func (r WeekRule) daysIntoWeek(wd Weekday) int {
+	return (int(wd) - int(r.FirstDay) + 7) % 7
+}
+
+// firstWeekStart returns the day number, counting the first day of
+// the year or month as 1, on which its week 1 starts, given that
+// day number n has weekday wd. The result may be zero or negative
+// when week 1 starts in the period before.
+func (r WeekRule) firstWeekStart(wd Weekday, n int) int {
+	min := r.MinDays
+	if min < 1 {
+		min = 1
+	}
+	// Weekday of day 1, then the start of its week.
+	wd1 := Weekday(((int(wd)-(n-1))%7 + 7) % 7)
+	start := 1 - r.daysIntoWeek(wd1)
+	if 7-r.daysIntoWeek(wd1) < min {
+		start += 7
+	}
+	return start
+}
+
+// daysInYear returns the number of days in year.
+func daysInYear(year int) int {
+	if isLeap(year) {
+		return 366
+	}
+	return 365
+}
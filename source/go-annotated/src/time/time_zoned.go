@@ -0,0 +1,109 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "errors"
+
+// The binary encoding of Time records only the zone offset in effect
+// at the instant, so a decoded Time no longer knows the rules of its
+// Location: adding a day across a DST change gives the wrong wall
+// clock. The zoned encoding, version 2, follows the 15 bytes of
+// version 1, those of the time in UTC, with the Location:
+//	kind byte: zoneKindUTC, zoneKindName or zoneKindRules
+//	zoneKindName: the name, preceded by its length as a big-endian
+//		16-bit value, to be loaded with LoadLocation
+//	zoneKindRules: the rest is the Location's MarshalBinary encoding
+// 带时区规则的二进制编码，解码后的 Time 保留完整的 Location
+const timeBinaryVersionZoned byte = 2
+
+const (
+	zoneKindUTC byte = iota
+	zoneKindName
+	zoneKindRules
+)
+
+// MarshalBinaryZoned is like MarshalBinary but also records the
+// Location of t, so that UnmarshalBinary restores a Time in a Location
+// with the same rules rather than a fixed offset.
+//
+// A Location returned by LoadLocation is recorded by name and loaded
+// again by the decoder, from its own time zone database. Any other
+// Location, including Local and those made by FixedZone or
+// LoadLocationFromTZData, is recorded in full.
+func (t Time) MarshalBinaryZoned() ([]byte, error) {
+	// In UTC, the version 1 part cannot fail on an offset that is
+	// not a whole number of minutes, as offsets before 1900 often are.
+	enc, _ := t.UTC().MarshalBinary()
+	enc[0] = timeBinaryVersionZoned
+
+	l := t.Location()
+	if l == UTC {
+		return append(enc, zoneKindUTC), nil
+	}
+	// A decoded copy of Local is also named "Local"; that name would
+	// load the decoder's own local zone.
+	if name := l.get().name; l != Local && name != "" && name != "Local" && len(name) <= 0xffff {
+		if ll, err := LoadLocation(name); err == nil && ll.Equal(l) {
+			enc = append(enc, zoneKindName)
+			return appendString16(enc, name), nil
+		}
+	}
+	rules, err := l.MarshalBinary()
+	if err != nil {
+		return nil, errors.New("Time.MarshalBinaryZoned: " + err.Error())
+	}
+	enc = append(enc, zoneKindRules)
+	return append(enc, rules...), nil
+}
+
+// unmarshalBinaryZoned decodes the Location that follows the version 1
+// encoding in data, in the version 2 encoding.
+func unmarshalBinaryZoned(data []byte) (*Location, error) {
+	if len(data) == 0 {
+		return nil, errors.New("Time.UnmarshalBinary: invalid length")
+	}
+	switch kind, data := data[0], data[1:]; kind {
+	case zoneKindUTC:
+		if len(data) != 0 {
+			return nil, errors.New("Time.UnmarshalBinary: invalid length")
+		}
+		return UTC, nil
+	case zoneKindName:
+		d := dataIO{data, false}
+		name := d.string16()
+		if d.error || len(d.p) != 0 {
+			return nil, errors.New("Time.UnmarshalBinary: invalid length")
+		}
+		l, err := LoadLocation(name)
+		if err != nil {
+			return nil, errors.New("Time.UnmarshalBinary: " + err.Error())
+		}
+		return l, nil
+	case zoneKindRules:
+		l := new(Location)
+		if err := l.UnmarshalBinary(data); err != nil {
+			return nil, errors.New("Time.UnmarshalBinary: " + err.Error())
+		}
+		return l, nil
+	}
+	return nil, errors.New("Time.UnmarshalBinary: unknown zone encoding")
+}
+
+// ZonedTime is a Time whose binary and gob encodings are the zoned
+// encoding of MarshalBinaryZoned, for use as a field of values sent
+// to other processes. Its other methods are those of Time.
+type ZonedTime struct {
+	Time
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (t ZonedTime) MarshalBinary() ([]byte, error) {
+	return t.Time.MarshalBinaryZoned()
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+func (t ZonedTime) GobEncode() ([]byte, error) {
+	return t.Time.MarshalBinaryZoned()
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timedebug
+
+import _ "unsafe" // for go:linkname
+
+//go:linkname readMetrics time.readMetrics
+func readMetrics(m []uint64)
+
+//go:linkname setLookupMetrics time.setLookupMetrics
+func setLookupMetrics(on bool)
+
+// Metrics counts the work of package time's zone caches and loaders
+// since the program started, to show whether time zone handling is a
+// hot spot. Take the difference of two readings to get a rate.
+// 时区缓存命中率、磁盘读取次数、解析失败次数
+type Metrics struct {
+	// Lookups of the zone in effect at an instant, as done by
+	// Time.Zone, Time.Date and the other methods that need the
+	// offset, that the Location's cache answered or not. They are
+	// counted only while EnableLookupMetrics is on.
+	LookupCacheHits   uint64
+	LookupCacheMisses uint64
+
+	// Calls of time.LoadLocation answered by the location cache,
+	// including cached failures, and calls that loaded the zone.
+	LoadCacheHits   uint64
+	LoadCacheMisses uint64
+
+	// Reads of zone data from a directory, zip file, tzdata file or
+	// registered time.ZoneSource, whether they found the zone or not.
+	SourceReads uint64
+
+	// Calls of time.LoadLocationFromTZData, direct or by a load,
+	// that failed.
+	ParseErrors uint64
+}
+
+// ReadMetrics returns the current counts.
+func ReadMetrics() Metrics {
+	var m [6]uint64
+	readMetrics(m[:])
+	return Metrics{
+		LookupCacheHits:   m[0],
+		LookupCacheMisses: m[1],
+		LoadCacheHits:     m[2],
+		LoadCacheMisses:   m[3],
+		SourceReads:       m[4],
+		ParseErrors:       m[5],
+	}
+}
+
+// EnableLookupMetrics turns the counting of lookups on or off; it is
+// off at startup. Lookups are frequent, and counting them costs an
+// atomic add on a counter shared by all goroutines.
+func EnableLookupMetrics(on bool) {
+	setLookupMetrics(on)
+}
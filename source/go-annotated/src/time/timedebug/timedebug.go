@@ -0,0 +1,205 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package timedebug exposes the internal zone tables of a time.Location
+// as read-only values, for studying and debugging LoadLocation.
+// 以只读的方式展示 Location 内部的 zone、tx 以及缓存，方便学习 LoadLocation 到底构建了什么
+//
+// The data is copied out of the Location on every call; changing the
+// returned values has no effect on the Location.
+//
+// This package is meant for learning and debugging. Its output mirrors
+// unexported fields of package time and may change whenever they do.
+package timedebug
+
+import (
+	"fmt"
+	"io"
+	"time"
+	_ "unsafe" // for go:linkname
+)
+
+//go:linkname debugZones time.debugZones
+func debugZones(l *time.Location, f func(name string, offset int, isDST bool))
+
+//go:linkname debugTransitions time.debugTransitions
+func debugTransitions(l *time.Location, f func(when int64, index int, isstd, isutc bool))
+
+//go:linkname debugCache time.debugCache
+func debugCache(l *time.Location) (start, end int64, zone int)
+
+//go:linkname debugExtend time.debugExtend
+func debugExtend(l *time.Location) string
+
+//go:linkname debugLookup time.debugLookup
+func debugLookup(l *time.Location, sec int64) (name string, offset int, isDST bool, start, end int64)
+
+// alpha and omega mirror the sentinels package time uses for the
+// beginning and end of time in transition tables.
+const (
+	alpha = -1 << 63
+	omega = 1<<63 - 1
+)
+
+// A Zone is one entry of a Location's zone table, such as CET or CEST.
+type Zone struct {
+	Name   string // abbreviated name, "CET"
+	Offset int    // seconds east of UTC
+	IsDST  bool   // is this zone Daylight Savings Time?
+}
+
+// A Transition is one entry of a Location's transition table.
+// Starting at When, the zone Zones[Index] is in effect.
+type Transition struct {
+	When         int64 // seconds since January 1, 1970 UTC; math.MinInt64 means "since the beginning of time"
+	Index        int   // index into the zone table
+	IsStd, IsUTC bool  // tzfile(5) indicators, unused by package time
+}
+
+// Time returns the instant of the transition in UTC.
+// A transition at the beginning of time reports the zero Time.
+func (tx Transition) Time() time.Time {
+	if tx.When == alpha {
+		return time.Time{}
+	}
+	return time.Unix(tx.When, 0).UTC()
+}
+
+// A Cache describes the lookup cache of a Location: if
+// Start <= sec < End, lookups of sec are answered with Zones[Zone]
+// without searching the transition table.
+type Cache struct {
+	Start, End int64
+	Zone       int // index into the zone table, or -1 if the cache is empty
+}
+
+// Valid reports whether the cache holds a zone.
+func (c Cache) Valid() bool { return c.Zone >= 0 }
+
+// Info is a snapshot of everything a Location holds.
+type Info struct {
+	Name        string
+	Zones       []Zone
+	Transitions []Transition
+	Extend      string // POSIX TZ rule used after the last transition, if any
+	Cache       Cache
+}
+
+// Zones returns a copy of the zone table of l.
+// A nil l is treated as time.UTC, as in package time.
+func Zones(l *time.Location) []Zone {
+	var zones []Zone
+	debugZones(l, func(name string, offset int, isDST bool) {
+		zones = append(zones, Zone{name, offset, isDST})
+	})
+	return zones
+}
+
+// Transitions returns a copy of the transition table of l.
+func Transitions(l *time.Location) []Transition {
+	var txs []Transition
+	debugTransitions(l, func(when int64, index int, isstd, isutc bool) {
+		txs = append(txs, Transition{when, index, isstd, isutc})
+	})
+	return txs
+}
+
+// Extend returns the POSIX TZ rule, such as "CET-1CEST,M3.5.0,M10.5.0/3",
+// that l uses for times after its last transition, or "" if it has none.
+func Extend(l *time.Location) string {
+	return debugExtend(l)
+}
+
+// CacheState returns the current state of the lookup cache of l.
+func CacheState(l *time.Location) Cache {
+	start, end, zone := debugCache(l)
+	return Cache{start, end, zone}
+}
+
+// Inspect returns a snapshot of l.
+func Inspect(l *time.Location) *Info {
+	return &Info{
+		Name:        l.String(),
+		Zones:       Zones(l),
+		Transitions: Transitions(l),
+		Extend:      Extend(l),
+		Cache:       CacheState(l),
+	}
+}
+
+// Dump writes a human-readable listing of l to w.
+// 以文本形式打印 Location 的全部内部数据
+func Dump(w io.Writer, l *time.Location) error {
+	return Inspect(l).Print(w)
+}
+
+// Print writes a human-readable listing of info to w.
+func (info *Info) Print(w io.Writer) error {
+	p := &printer{w: w}
+	p.printf("location %q\n", info.Name)
+	p.printf("zones (%d):\n", len(info.Zones))
+	for i, z := range info.Zones {
+		p.printf("\t[%d] %-6s %s dst=%v\n", i, z.Name, formatOffset(z.Offset), z.IsDST)
+	}
+	p.printf("transitions (%d):\n", len(info.Transitions))
+	for _, tx := range info.Transitions {
+		p.printf("\t%s -> [%d]", formatWhen(tx.When), tx.Index)
+		if tx.Index < len(info.Zones) {
+			p.printf(" %s", info.Zones[tx.Index].Name)
+		}
+		if tx.IsStd {
+			p.printf(" std")
+		}
+		if tx.IsUTC {
+			p.printf(" utc")
+		}
+		p.printf("\n")
+	}
+	if info.Extend != "" {
+		p.printf("extend: %s\n", info.Extend)
+	}
+	if c := info.Cache; c.Valid() {
+		p.printf("cache: [%s, %s) -> [%d]\n", formatWhen(c.Start), formatWhen(c.End), c.Zone)
+	} else {
+		p.printf("cache: empty\n")
+	}
+	return p.err
+}
+
+// printer remembers the first write error so Print can check it once.
+type printer struct {
+	w   io.Writer
+	err error
+}
+
+func (p *printer) printf(format string, args ...interface{}) {
+	if p.err == nil {
+		_, p.err = fmt.Fprintf(p.w, format, args...)
+	}
+}
+
+// formatWhen formats a transition time, spelling out the sentinels.
+func formatWhen(sec int64) string {
+	switch sec {
+	case alpha:
+		return "-inf"
+	case omega:
+		return "+inf"
+	}
+	return time.Unix(sec, 0).UTC().Format(time.RFC3339)
+}
+
+// formatOffset formats an offset in seconds as ±hh:mm[:ss].
+func formatOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	h, m, s := offset/3600, offset/60%60, offset%60
+	if s != 0 {
+		return fmt.Sprintf("%c%02d:%02d:%02d", sign, h, m, s)
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, h, m)
+}
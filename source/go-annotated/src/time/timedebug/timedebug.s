@@ -0,0 +1,8 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The functions in this package are declared without bodies and
+// filled in from package time with go:linkname. This file exists so
+// that the go tool does not pass -complete to the compiler, which
+// would otherwise reject Go functions with no bodies.
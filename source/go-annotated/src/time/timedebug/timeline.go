@@ -0,0 +1,167 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timedebug
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"time"
+)
+
+// A Period is a stretch of time during which a single zone is in effect.
+type Period struct {
+	Start, End time.Time // [Start, End), in UTC
+	Zone       Zone
+}
+
+// Timeline returns the periods of l that overlap the years
+// fromYear through toYear inclusive, in UTC, in time order.
+// The first and last periods are clipped to the range.
+// 计算某个年份区间内的每一段时区（偏移、是否夏令时）
+//
+// The periods follow the transition table and, past its end, the
+// POSIX TZ rule of l, exactly as package time resolves them.
+func Timeline(l *time.Location, fromYear, toYear int) ([]Period, error) {
+	if fromYear > toYear {
+		return nil, errors.New("timedebug: empty year range")
+	}
+	start := time.Date(fromYear, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+	end := time.Date(toYear+1, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	var periods []Period
+	for sec := start; sec < end; {
+		name, offset, isDST, _, zend := debugLookup(l, sec)
+		z := Zone{name, offset, isDST}
+		if zend > end || zend <= sec {
+			zend = end
+		}
+		if n := len(periods); n > 0 && periods[n-1].Zone == z {
+			// A rule-based zone is reported one year at a time,
+			// and some transitions only change the std/utc indicators.
+			periods[n-1].End = unix(zend)
+		} else {
+			periods = append(periods, Period{unix(sec), unix(zend), z})
+		}
+		sec = zend
+	}
+	return periods, nil
+}
+
+func unix(sec int64) time.Time { return time.Unix(sec, 0).UTC() }
+
+// stripWidth is the number of columns used for one year in the
+// text strip; each column covers five days.
+const stripWidth = 73
+
+// WriteTimelineText writes the timeline of l for the years fromYear
+// through toYear to w as plain text: a table of periods followed by
+// one strip per year in which '#' marks daylight saving time and '.'
+// standard time.
+func WriteTimelineText(w io.Writer, l *time.Location, fromYear, toYear int) error {
+	periods, err := Timeline(l, fromYear, toYear)
+	if err != nil {
+		return err
+	}
+	p := &printer{w: w}
+	p.printf("%s %d-%d\n\n", l, fromYear, toYear)
+	for _, pd := range periods {
+		p.printf("%s  %s  %-6s %s", pd.Start.Format(time.RFC3339), pd.End.Format(time.RFC3339),
+			pd.Zone.Name, formatOffset(pd.Zone.Offset))
+		if pd.Zone.IsDST {
+			p.printf(" DST")
+		}
+		p.printf("\n")
+	}
+	p.printf("\n")
+	for year := fromYear; year <= toYear; year++ {
+		var strip [stripWidth]byte
+		for i := range strip {
+			t := time.Date(year, time.January, 1+5*i, 12, 0, 0, 0, time.UTC)
+			strip[i] = '.'
+			if z := zoneAt(periods, t); z.IsDST {
+				strip[i] = '#'
+			}
+		}
+		p.printf("%d |%s|\n", year, strip[:])
+	}
+	return p.err
+}
+
+// zoneAt returns the zone of the period containing t.
+func zoneAt(periods []Period, t time.Time) Zone {
+	for _, pd := range periods {
+		if !t.Before(pd.Start) && t.Before(pd.End) {
+			return pd.Zone
+		}
+	}
+	return Zone{}
+}
+
+// Layout of the SVG timeline.
+const (
+	svgLabelWidth = 60
+	svgYearWidth  = 730 // two pixels per day
+	svgRowHeight  = 24
+	svgBarHeight  = 16
+)
+
+// WriteTimelineSVG writes the timeline of l for the years fromYear
+// through toYear to w as an SVG image with one row per year.
+// Daylight saving periods are drawn in orange, standard time in blue,
+// and every period is labeled with its abbreviation and offset.
+func WriteTimelineSVG(w io.Writer, l *time.Location, fromYear, toYear int) error {
+	periods, err := Timeline(l, fromYear, toYear)
+	if err != nil {
+		return err
+	}
+	rows := toYear - fromYear + 1
+	width := svgLabelWidth + svgYearWidth + 10
+	height := (rows+1)*svgRowHeight + 10
+
+	p := &printer{w: w}
+	p.printf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="monospace" font-size="10">`+"\n", width, height)
+	p.printf(`<text x="4" y="14" font-size="12">%s %d-%d</text>`+"\n", html.EscapeString(l.String()), fromYear, toYear)
+	for row := 0; row < rows; row++ {
+		year := fromYear + row
+		y := (row+1)*svgRowHeight + 4
+		yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		yearEnd := time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+		yearLen := yearEnd.Sub(yearStart).Seconds()
+		p.printf(`<text x="4" y="%d">%d</text>`+"\n", y+12, year)
+		for _, pd := range periods {
+			start, end := pd.Start, pd.End
+			if !start.Before(yearEnd) || !end.After(yearStart) {
+				continue
+			}
+			if start.Before(yearStart) {
+				start = yearStart
+			}
+			if end.After(yearEnd) {
+				end = yearEnd
+			}
+			x0 := svgLabelWidth + int(start.Sub(yearStart).Seconds()/yearLen*svgYearWidth)
+			x1 := svgLabelWidth + int(end.Sub(yearStart).Seconds()/yearLen*svgYearWidth)
+			fill := "#6fa8dc"
+			if pd.Zone.IsDST {
+				fill = "#f6b26b"
+			}
+			label := html.EscapeString(pd.Zone.Name + " " + formatOffset(pd.Zone.Offset))
+			p.printf(`<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="#444"><title>%s</title></rect>`+"\n",
+				x0, y, x1-x0, svgBarHeight, fill, label)
+			if x1-x0 > 7*len(label) {
+				p.printf(`<text x="%d" y="%d">%s</text>`+"\n", x0+3, y+12, label)
+			}
+		}
+	}
+	p.printf("</svg>\n")
+	return p.err
+}
+
+// String returns the period in the form "start end NAME ±hh:mm".
+func (pd Period) String() string {
+	return fmt.Sprintf("%s %s %s %s", pd.Start.Format(time.RFC3339), pd.End.Format(time.RFC3339), pd.Zone.Name, formatOffset(pd.Zone.Offset))
+}
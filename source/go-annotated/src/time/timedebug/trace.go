@@ -0,0 +1,118 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timedebug
+
+import (
+	"time"
+	_ "unsafe" // for go:linkname
+)
+
+//go:linkname setLoadTracer time.setLoadTracer
+func setLoadTracer(f func(kind int, name, source string, size int, elapsed int64, err error))
+
+// An EventKind identifies a step of the zone-loading path.
+type EventKind int
+
+// The values must match the trace* constants in package time.
+const (
+	LoadDone   EventKind = iota // time.LoadLocation returned
+	ReadSource                  // zone data was read from one source
+	Parse                       // time.LoadLocationFromTZData returned
+)
+
+var eventKinds = [...]string{
+	LoadDone:   "load",
+	ReadSource: "read",
+	Parse:      "parse",
+}
+
+func (k EventKind) String() string {
+	if 0 <= k && int(k) < len(eventKinds) {
+		return eventKinds[k]
+	}
+	return "EventKind(" + itoa(int(k)) + ")"
+}
+
+// A LoadEvent reports one step taken while loading a Location.
+// 加载链路上的一个事件
+type LoadEvent struct {
+	Kind    EventKind
+	Name    string        // zone name being loaded, such as "Europe/Berlin"
+	Source  string        // directory, zip file or tzdata file consulted; set only for ReadSource
+	Size    int           // bytes read (ReadSource) or parsed (Parse)
+	Elapsed time.Duration // time spent in this step
+	Err     error         // result of the step
+}
+
+// SetLoadTracer arranges for f to be called for every step of
+// time.LoadLocation, time.LoadLocationFromTZData and the readers behind
+// them, including the load of the Local location. Passing nil turns
+// tracing off.
+//
+// f is called synchronously on the goroutine doing the load, so it
+// should be quick and must not load locations itself.
+func SetLoadTracer(f func(LoadEvent)) {
+	if f == nil {
+		setLoadTracer(nil)
+		return
+	}
+	setLoadTracer(func(kind int, name, source string, size int, elapsed int64, err error) {
+		f(LoadEvent{EventKind(kind), name, source, size, time.Duration(elapsed), err})
+	})
+}
+
+// Path returns the file that a ReadSource event read: the zone's file
+// in a directory source, or the zip or tzdata file itself, in which
+// case Name is the member read. It returns "" for other events and for
+// sources registered with time.RegisterZoneSource, which are not files.
+func (e LoadEvent) Path() string {
+	if e.Kind != ReadSource || e.Source == "" || e.Source == "ZoneSource" {
+		return ""
+	}
+	if hasSuffix(e.Source, ".zip") || hasSuffix(e.Source, "tzdata") {
+		return e.Source
+	}
+	if hasSuffix(e.Source, "/") {
+		return e.Source + e.Name
+	}
+	return e.Source + "/" + e.Name
+}
+
+// SlowLoads returns a tracer for SetLoadTracer that passes to f only
+// the events that took threshold or longer. It is meant for production
+// use, to find slow zone reads, such as from a /usr/share/zoneinfo on
+// NFS or a large ZONEINFO zip file, without logging every load:
+//
+//	timedebug.SetLoadTracer(timedebug.SlowLoads(50*time.Millisecond, func(e timedebug.LoadEvent) {
+//		log.Printf("slow zone %s: %s %s took %v", e.Kind, e.Name, e.Path(), e.Elapsed)
+//	}))
+func SlowLoads(threshold time.Duration, f func(LoadEvent)) func(LoadEvent) {
+	return func(e LoadEvent) {
+		if e.Elapsed >= threshold {
+			f(e)
+		}
+	}
+}
+
+func hasSuffix(s, suffix string) bool {
+	return len(s) >= len(suffix) && s[len(s)-len(suffix):] == suffix
+}
+
+// itoa avoids importing strconv for one error string.
+func itoa(v int) string {
+	if v < 0 {
+		return "-" + itoa(-v)
+	}
+	var buf [20]byte
+	i := len(buf)
+	for v >= 10 {
+		i--
+		buf[i] = byte('0' + v%10)
+		v /= 10
+	}
+	i--
+	buf[i] = byte('0' + v)
+	return string(buf[i:])
+}
@@ -0,0 +1,52 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package tzcompress lets package time read gzip-compressed time zone
+// data. If this package is imported anywhere in the program, a file
+// named in ZONEINFO whose name ends in ".gz", such as
+// zoneinfo.zip.gz, is decompressed and read as a zip file, and gzipped
+// data from a source registered with time.RegisterZoneSource is
+// decompressed before it is parsed.
+// 导入这个包后，ZONEINFO 可以指向 gzip 压缩的 zoneinfo.zip
+//
+// Compressed with gzip -9, the zoneinfo.zip distributed by Go shrinks
+// from about 400 KB to under 100 KB. Its files must stay uncompressed
+// inside the zip, as package time reads them.
+//
+// Like time/tzdata, this package should normally be imported by a
+// program's main package, not by a library.
+package tzcompress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"time"
+)
+
+// maxSize is the most data package time reads from a file.
+const maxSize = 10 << 20
+
+func init() {
+	time.RegisterZoneDecompressor(".gz", "\x1f\x8b", gunzip)
+}
+
+// gunzip returns the decompressed contents of the gzip data, which
+// may be several gzip members, as gzip.Reader reads them.
+func gunzip(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	out, err := ioutil.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > maxSize {
+		return nil, errors.New("decompressed data is too large")
+	}
+	return out, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build ignore
+
+// This program generates zzipdata.go from zoneinfo.zip.
+// It is invoked by running go generate in this directory.
+// 把 $GOROOT/lib/time/zoneinfo.zip 转成一个字符串常量写入 zzipdata.go
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+)
+
+var zipFlag = flag.String("zip", filepath.Join(runtime.GOROOT(), "lib", "time", "zoneinfo.zip"), "uncompressed zip `file` to embed")
+
+const header = `// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by generate_zipdata. DO NOT EDIT.
+
+// This file contains an embedded zip archive that contains time zone
+// files compiled using the code and data maintained as part of the
+// IANA Time Zone Database.
+// The IANA asserts that the data is in the public domain.
+
+// For more information, see
+// https://www.iana.org/time-zones
+// ftp://ftp.iana.org/tz/code/tz-link.html
+// http://tools.ietf.org/html/rfc6557
+
+package tzdata
+
+const zipdata = `
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("generate_zipdata: ")
+	flag.Parse()
+
+	data, err := ioutil.ReadFile(*zipFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := os.Create("zzipdata.go")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	w := bufio.NewWriter(f)
+	fmt.Fprint(w, header)
+	fmt.Fprintln(w, strconv.Quote(string(data)))
+	if err := w.Flush(); err != nil {
+		log.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file exists so that the go tool does not pass -complete to
+// the compiler, which would reject registerLoadFromEmbeddedTZData,
+// a function with no body that is provided by package time.
@@ -0,0 +1,238 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package tzdist is a client for time zone data distribution servers
+// (RFC 7808), which serve the zones of the IANA Time Zone database
+// as TZif data (RFC 8536), so that a fleet of programs can get its
+// time zone data from one place rather than from each machine.
+// RFC 7808 时区数据分发（tzdist）协议的客户端，可作为 LoadLocation 的数据源
+//
+// It lives outside package time because net/http depends on time.
+//
+// A Client is a time.ZoneSource: once registered, LoadLocation asks
+// the server for the zones it does not find in ZONEINFO:
+//
+//	c, err := tzdist.Discover("tz.example.com")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	c.Register()
+//	ny, err := time.LoadLocation("America/New_York") // from the server
+package tzdist
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A Client talks to a tzdist server. Its methods may be called
+// concurrently from multiple goroutines.
+type Client struct {
+	// BaseURL is the context path of the server, such as
+	// "https://tz.example.com/tzdist", to which the resource
+	// names "zones" and "capabilities" are appended.
+	BaseURL string
+
+	// HTTPClient makes the requests. Nil means http.DefaultClient.
+	HTTPClient *http.Client
+
+	mu    sync.Mutex
+	cache map[string]cached // by zone name
+}
+
+// A cached zone is the last TZif data fetched and its entity tag,
+// for conditional requests.
+type cached struct {
+	etag string
+	data []byte
+}
+
+// NewClient returns a Client for the server with the given context
+// path, such as "https://tz.example.com/tzdist".
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: baseURL}
+}
+
+// Discover returns a Client for the tzdist server of host, found
+// through its well-known URI, https://host/.well-known/timezone,
+// which is either the context path or redirects to it.
+func Discover(host string) (*Client, error) {
+	wellKnown := "https://" + host + "/.well-known/timezone"
+	hc := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := hc.Get(wellKnown)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return NewClient(wellKnown), nil
+	case 300 <= resp.StatusCode && resp.StatusCode < 400:
+		loc, err := resp.Location()
+		if err != nil {
+			return nil, errors.New("tzdist: " + host + ": redirect without location")
+		}
+		return NewClient(strings.TrimSuffix(loc.String(), "/")), nil
+	}
+	return nil, errors.New("tzdist: " + host + ": " + resp.Status)
+}
+
+// Register registers c with time.RegisterZoneSource.
+func (c *Client) Register() {
+	time.RegisterZoneSource(c)
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// ZoneData returns the TZif data of the named zone, implementing
+// time.ZoneSource. If the server does not know the zone, it returns
+// nil, nil. The data and entity tag are remembered, so that asking
+// again for an unchanged zone costs only a conditional request.
+func (c *Client) ZoneData(name string) ([]byte, error) {
+	c.mu.Lock()
+	old, ok := c.cache[name]
+	c.mu.Unlock()
+
+	req, err := http.NewRequest("GET", c.BaseURL+"/zones/"+escapeTZID(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/tzif")
+	if ok && old.etag != "" {
+		req.Header.Set("If-None-Match", old.etag)
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		if ok {
+			return old.data, nil
+		}
+	case http.StatusNotFound:
+		return nil, nil
+	case http.StatusOK:
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		if c.cache == nil {
+			c.cache = make(map[string]cached)
+		}
+		c.cache[name] = cached{resp.Header.Get("ETag"), data}
+		c.mu.Unlock()
+		return data, nil
+	}
+	return nil, statusError(resp)
+}
+
+// escapeTZID escapes each element of a zone name for use in a path,
+// keeping the slashes between them, as in "America/Port-au-Prince".
+func escapeTZID(name string) string {
+	parts := strings.Split(name, "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(p)
+	}
+	return strings.Join(parts, "/")
+}
+
+// A Zone describes a zone in a List.
+type Zone struct {
+	ID           string    // name, such as "America/New_York"
+	LastModified time.Time // last change of the zone's data
+	ETag         string    // entity tag of the zone's data
+	Aliases      []string  // other names of the zone
+}
+
+// A List is the result of Client.List.
+type List struct {
+	// SyncToken identifies the state of the server's data, to be
+	// passed to a later List to ask only for what changed since.
+	SyncToken string
+
+	// Zones are the zones, or those that changed.
+	Zones []Zone
+}
+
+// List returns the zones of the server. If syncToken is not empty,
+// it must be the SyncToken of an earlier List, and only the zones
+// that changed since are returned. A server that cannot tell what
+// changed since so old a token returns an error, after which the
+// caller should start again with an empty token.
+func (c *Client) List(syncToken string) (*List, error) {
+	u := c.BaseURL + "/zones"
+	if syncToken != "" {
+		u += "?changedsince=" + url.QueryEscape(syncToken)
+	}
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp)
+	}
+
+	var body struct {
+		SyncToken string `json:"synctoken"`
+		Timezones []struct {
+			TZID         string   `json:"tzid"`
+			LastModified string   `json:"last-modified"`
+			ETag         string   `json:"etag"`
+			Aliases      []string `json:"aliases"`
+		} `json:"timezones"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, errors.New("tzdist: bad zone list: " + err.Error())
+	}
+	l := &List{SyncToken: body.SyncToken, Zones: make([]Zone, len(body.Timezones))}
+	for i, tz := range body.Timezones {
+		z := Zone{ID: tz.TZID, ETag: tz.ETag, Aliases: tz.Aliases}
+		if tz.LastModified != "" {
+			z.LastModified, err = time.Parse(time.RFC3339, tz.LastModified)
+			if err != nil {
+				return nil, errors.New("tzdist: bad last-modified of " + tz.TZID + ": " + err.Error())
+			}
+		}
+		l.Zones[i] = z
+	}
+	return l, nil
+}
+
+// statusError returns the error for an unexpected response, using
+// the error code of an RFC 7807 problem report if there is one.
+func statusError(resp *http.Response) error {
+	msg := "tzdist: " + resp.Request.URL.String() + ": " + resp.Status
+	var problem struct {
+		Type string `json:"type"`
+	}
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/problem+json") &&
+		json.NewDecoder(resp.Body).Decode(&problem) == nil && problem.Type != "" {
+		msg += " (" + problem.Type + ")"
+	}
+	return errors.New(msg)
+}
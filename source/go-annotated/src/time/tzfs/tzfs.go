@@ -0,0 +1,118 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build go1.16
+
+// Package tzfs loads time zones from an fs.FS, such as an embed.FS
+// holding a trimmed copy of the zoneinfo directory.
+// 从 fs.FS（例如 embed.FS）中加载时区，不访问真实文件系统，也不读取 ZONEINFO
+//
+// It lives outside package time because io/fs depends on time.
+//
+// A typical use embeds just the zones an application needs:
+//
+//	//go:embed zoneinfo
+//	var zoneinfo embed.FS
+//
+//	sub, _ := fs.Sub(zoneinfo, "zoneinfo")
+//	loc, err := tzfs.LoadLocationFromFS(sub, "Europe/Berlin")
+package tzfs
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"time"
+)
+
+// zipName is the name of the archive looked for at the root of fsys
+// when a zone is not present as a plain file.
+const zipName = "zoneinfo.zip"
+
+var errLocation = errors.New("invalid location name")
+
+// LoadLocationFromFS returns the Location with the given name, read from fsys.
+//
+// As with time.LoadLocation, the name "" or "UTC" returns time.UTC and
+// "Local" returns time.Local. Any other name is looked up as a file in
+// fsys laid out like a zoneinfo directory, so "America/New_York" is the
+// file America/New_York. If there is no such file and fsys holds an
+// uncompressed or deflated zoneinfo.zip at its root, the zone is read
+// from the archive instead.
+//
+// LoadLocationFromFS never consults the ZONEINFO environment variable or the
+// system time zone directories. As with time.LoadLocation, errors are of type
+// *time.LoadError, and a missing zone is reported as time.ErrUnknownZone.
+func LoadLocationFromFS(fsys fs.FS, name string) (*time.Location, error) {
+	switch name {
+	case "", "UTC":
+		return time.UTC, nil
+	case "Local":
+		return time.Local, nil
+	}
+	if !fs.ValidPath(name) || name == "." {
+		// No valid IANA Time Zone name contains a single dot,
+		// much less dot dot. Likewise, none begin with a slash.
+		return nil, &time.LoadError{Name: name, Err: errLocation}
+	}
+
+	data, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		data, err = readFromZip(fsys, name)
+	}
+	if err != nil {
+		return nil, &time.LoadError{Name: name, Err: err}
+	}
+	l, err := time.LoadLocationFromTZData(name, data)
+	if err != nil {
+		return nil, &time.LoadError{Name: name, Err: err}
+	}
+	return l, nil
+}
+
+// readFromZip returns the contents of name in zoneinfo.zip at the root
+// of fsys. It reports time.ErrUnknownZone if either is missing.
+func readFromZip(fsys fs.FS, name string) ([]byte, error) {
+	f, err := fsys.Open(zipName)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, time.ErrUnknownZone
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	// Files from embed.FS and os.DirFS support random access;
+	// anything else is read into memory first.
+	var (
+		ra   io.ReaderAt
+		size int64
+	)
+	if r, ok := f.(io.ReaderAt); ok {
+		st, err := f.Stat()
+		if err != nil {
+			return nil, err
+		}
+		ra, size = r, st.Size()
+	} else {
+		b, err := io.ReadAll(f)
+		if err != nil {
+			return nil, err
+		}
+		ra, size = bytes.NewReader(b), int64(len(b))
+	}
+
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		return nil, errors.New("corrupt zip file " + zipName + ": " + err.Error())
+	}
+	zf, err := zr.Open(name)
+	if err != nil {
+		return nil, time.ErrUnknownZone
+	}
+	defer zf.Close()
+	return io.ReadAll(zf)
+}
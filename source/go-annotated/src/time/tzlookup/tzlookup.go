@@ -0,0 +1,124 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package tzlookup maps geographic coordinates to IANA time zone names,
+// answering "what zone is this GPS fix in?".
+// 根据经纬度查找时区名
+//
+// It carries no zone boundary data. Instead it returns the zone whose
+// principal city, as listed in the zone1970.tab file of the time zone
+// database, is nearest to the given point. That is right for most
+// populated places, but can be wrong near borders between zones, at
+// sea, and in large zones whose principal city is far away.
+//
+//	name, err := tzlookup.Lookup(48.86, 2.35) // "Europe/Paris"
+//	loc, err := time.LoadLocation(name)
+package tzlookup
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// An Index finds the zone nearest to a point among a set of zones.
+// It is safe for concurrent use.
+type Index struct {
+	entries []entry
+}
+
+// An entry is a zone's principal city, on the unit sphere.
+type entry struct {
+	name    string
+	x, y, z float64
+}
+
+// NewIndex returns an Index of the given zones, placed at their
+// Latitude and Longitude.
+func NewIndex(zones []time.ZoneTabEntry) *Index {
+	x := &Index{entries: make([]entry, 0, len(zones))}
+	for i := range zones {
+		e := entry{name: zones[i].Name}
+		e.x, e.y, e.z = unitVector(zones[i].Latitude, zones[i].Longitude)
+		x.entries = append(x.entries, e)
+	}
+	return x
+}
+
+var (
+	defaultOnce  sync.Once
+	defaultIndex *Index
+	defaultErr   error
+)
+
+// Default returns an Index of the zones in zone1970.tab, as reported
+// by time.ZoneMetadata. It is built on first use.
+func Default() (*Index, error) {
+	defaultOnce.Do(func() {
+		names, err := time.AvailableZones()
+		if err != nil {
+			defaultErr = err
+			return
+		}
+		var zones []time.ZoneTabEntry
+		for _, name := range names {
+			// Aliases and zones such as "UTC" are not in the table.
+			if e, err := time.ZoneMetadata(name); err == nil {
+				zones = append(zones, *e)
+			}
+		}
+		if len(zones) == 0 {
+			defaultErr = errors.New("tzlookup: no zones with coordinates found")
+			return
+		}
+		defaultIndex = NewIndex(zones)
+	})
+	return defaultIndex, defaultErr
+}
+
+// Lookup returns the name of the zone nearest to the given latitude
+// and longitude, in degrees, positive north and east, using Default.
+func Lookup(lat, lon float64) (string, error) {
+	x, err := Default()
+	if err != nil {
+		return "", err
+	}
+	name, _, err := x.Lookup(lat, lon)
+	return name, err
+}
+
+// earthRadius is the mean radius of the Earth in kilometers.
+const earthRadius = 6371.0
+
+// Lookup returns the name of the zone nearest to the given latitude
+// and longitude, in degrees, positive north and east, and the
+// great-circle distance in kilometers from the point to the zone's
+// principal city.
+func (x *Index) Lookup(lat, lon float64) (name string, km float64, err error) {
+	if !(-90 <= lat && lat <= 90) || !(-180 <= lon && lon <= 180) {
+		return "", 0, errors.New("tzlookup: coordinates out of range")
+	}
+	if len(x.entries) == 0 {
+		return "", 0, errors.New("tzlookup: empty index")
+	}
+	px, py, pz := unitVector(lat, lon)
+
+	// The nearest city has the largest dot product with the point.
+	best, bestDot := -1, -2.0
+	for i := range x.entries {
+		e := &x.entries[i]
+		if d := px*e.x + py*e.y + pz*e.z; d > bestDot {
+			best, bestDot = i, d
+		}
+	}
+	return x.entries[best].name, earthRadius * math.Acos(math.Min(1, bestDot)), nil
+}
+
+// unitVector returns the point at the given latitude and longitude
+// on the unit sphere.
+func unitVector(lat, lon float64) (x, y, z float64) {
+	phi, lambda := lat*math.Pi/180, lon*math.Pi/180
+	return math.Cos(phi) * math.Cos(lambda), math.Cos(phi) * math.Sin(lambda), math.Sin(phi)
+}
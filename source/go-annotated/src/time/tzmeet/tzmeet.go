@@ -0,0 +1,150 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package tzmeet finds the times of a day when participants in
+// different time zones are all within their working hours, for
+// calendar tools that suggest meeting slots.
+// 跨时区会议时间：求多个时区工作时间的交集
+//
+// Working hours are wall clock times in each participant's Location,
+// so the windows found follow each zone's daylight saving time rules
+// on the day asked for:
+//
+//	ny, _ := time.LoadLocation("America/New_York")
+//	ber, _ := time.LoadLocation("Europe/Berlin")
+//	day := time.Date(2024, time.April, 3, 0, 0, 0, 0, time.UTC)
+//	ws := tzmeet.Find(day, []tzmeet.Participant{
+//		{Location: ny, Start: 9 * time.Hour, End: 17 * time.Hour},
+//		{Location: ber, Start: 9 * time.Hour, End: 17 * time.Hour},
+//	})
+//	// ws[0] is 13:00 to 15:00 UTC. A week earlier, when the US was
+//	// already on summer time but Europe was not, it was 13:00 to
+//	// 16:00 UTC.
+package tzmeet
+
+import "time"
+
+// A Participant is someone who must attend, with their working hours.
+type Participant struct {
+	Location *time.Location
+
+	// Start and End are the working hours as wall clock times,
+	// measured from local midnight: 9*time.Hour for 9am. An End
+	// at or before Start means the hours end on the next day, as
+	// for a night shift from 22*time.Hour to 6*time.Hour.
+	Start, End time.Duration
+
+	// Days lists the weekdays on which the hours start.
+	// Nil means every day.
+	Days []time.Weekday
+}
+
+// A Window is a span of time, from Start up to but not including End.
+type Window struct {
+	Start, End time.Time
+}
+
+// Duration returns the length of w.
+func (w Window) Duration() time.Duration {
+	return w.End.Sub(w.Start)
+}
+
+// Find returns, in order, the windows within the day of day, from
+// its midnight to the next in day.Location(), when every participant
+// is within their working hours. The windows are in day.Location().
+// With no participants, the whole day is one window.
+func Find(day time.Time, ps []Participant) []Window {
+	loc := day.Location()
+	y, m, d := day.Date()
+	ws := []Window{{
+		Start: time.Date(y, m, d, 0, 0, 0, 0, loc),
+		End:   time.Date(y, m, d+1, 0, 0, 0, 0, loc),
+	}}
+	for i := range ps {
+		ws = intersect(ws, ps[i].hours(ws[0].Start, ws[len(ws)-1].End))
+		if len(ws) == 0 {
+			return nil
+		}
+	}
+	for i := range ws {
+		ws[i].Start = ws[i].Start.In(loc)
+		ws[i].End = ws[i].End.In(loc)
+	}
+	return ws
+}
+
+// hours returns, in order, the working hours of p that overlap
+// the span from start to end.
+func (p *Participant) hours(start, end time.Time) []Window {
+	// Local days that can overlap the span: from the day before the
+	// span starts there, for hours that run past midnight, to the
+	// day it ends there.
+	s := start.In(p.Location)
+	e := end.In(p.Location)
+	y, m, d := s.Date()
+	var ws []Window
+	for day := time.Date(y, m, d-1, 12, 0, 0, 0, p.Location); !day.After(e); day = day.AddDate(0, 0, 1) {
+		if !p.works(day.Weekday()) {
+			continue
+		}
+		dy, dm, dd := day.Date()
+		from := wallClock(dy, dm, dd, p.Start, p.Location)
+		stop := p.End
+		if stop <= p.Start {
+			stop += 24 * time.Hour
+		}
+		to := wallClock(dy, dm, dd, stop, p.Location)
+		if to.After(start) && from.Before(end) && from.Before(to) {
+			ws = append(ws, Window{from, to})
+		}
+	}
+	return ws
+}
+
+// works reports whether p has working hours starting on day wd.
+func (p *Participant) works(wd time.Weekday) bool {
+	if p.Days == nil {
+		return true
+	}
+	for _, d := range p.Days {
+		if d == wd {
+			return true
+		}
+	}
+	return false
+}
+
+// wallClock returns the time off after midnight by the wall clock
+// on the given date in loc, with off possibly beyond one day.
+func wallClock(y int, m time.Month, d int, off time.Duration, loc *time.Location) time.Time {
+	h := int(off / time.Hour)
+	min := int(off % time.Hour / time.Minute)
+	sec := int(off % time.Minute / time.Second)
+	nsec := int(off % time.Second)
+	return time.Date(y, m, d, h, min, sec, nsec, loc)
+}
+
+// intersect returns the spans covered by both a and b,
+// each sorted and without overlaps.
+func intersect(a, b []Window) []Window {
+	var ws []Window
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		s, e := a[i].Start, a[i].End
+		if b[j].Start.After(s) {
+			s = b[j].Start
+		}
+		if b[j].End.Before(e) {
+			e = b[j].End
+		}
+		if s.Before(e) {
+			ws = append(ws, Window{s, e})
+		}
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return ws
+}
@@ -0,0 +1,159 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tznames
+
+// Names copied from the Unicode CLDR (metaZones.xml and the
+// timeZoneNames of each locale). Only a subset is included; zones
+// and locales not listed here fall back to the GMT format.
+// 数据摘自 CLDR，只包含常用的 metazone 和语言
+
+// zoneNames holds the names of one metazone in one locale.
+type zoneNames struct {
+	standard, daylight, generic string
+}
+
+// A localeTable holds the zone names of one locale.
+type localeTable struct {
+	tag       string // BCP 47 language tag, such as "fr"
+	gmtPrefix string // GMT format before the offset, such as "UTC"
+	gmtZero   string // name of GMT itself
+	names     map[string]zoneNames
+}
+
+// metazones maps canonical zone names to their current metazone.
+var metazones = map[string]string{
+	"Africa/Abidjan":      "GMT",
+	"Africa/Accra":        "GMT",
+	"Africa/Dakar":        "GMT",
+	"Atlantic/Reykjavik":  "GMT",
+	"Europe/Dublin":       "GMT",
+	"Europe/London":       "GMT",
+	"Atlantic/Canary":     "Europe_Western",
+	"Atlantic/Faroe":      "Europe_Western",
+	"Atlantic/Madeira":    "Europe_Western",
+	"Europe/Lisbon":       "Europe_Western",
+	"Africa/Ceuta":        "Europe_Central",
+	"Europe/Amsterdam":    "Europe_Central",
+	"Europe/Belgrade":     "Europe_Central",
+	"Europe/Berlin":       "Europe_Central",
+	"Europe/Brussels":     "Europe_Central",
+	"Europe/Budapest":     "Europe_Central",
+	"Europe/Copenhagen":   "Europe_Central",
+	"Europe/Luxembourg":   "Europe_Central",
+	"Europe/Madrid":       "Europe_Central",
+	"Europe/Malta":        "Europe_Central",
+	"Europe/Monaco":       "Europe_Central",
+	"Europe/Oslo":         "Europe_Central",
+	"Europe/Paris":        "Europe_Central",
+	"Europe/Prague":       "Europe_Central",
+	"Europe/Rome":         "Europe_Central",
+	"Europe/Stockholm":    "Europe_Central",
+	"Europe/Tirane":       "Europe_Central",
+	"Europe/Vienna":       "Europe_Central",
+	"Europe/Warsaw":       "Europe_Central",
+	"Europe/Zurich":       "Europe_Central",
+	"Asia/Beirut":         "Europe_Eastern",
+	"Asia/Nicosia":        "Europe_Eastern",
+	"Africa/Cairo":        "Europe_Eastern",
+	"Europe/Athens":       "Europe_Eastern",
+	"Europe/Bucharest":    "Europe_Eastern",
+	"Europe/Chisinau":     "Europe_Eastern",
+	"Europe/Helsinki":     "Europe_Eastern",
+	"Europe/Kyiv":         "Europe_Eastern",
+	"Europe/Riga":         "Europe_Eastern",
+	"Europe/Sofia":        "Europe_Eastern",
+	"Europe/Tallinn":      "Europe_Eastern",
+	"Europe/Vilnius":      "Europe_Eastern",
+	"America/Detroit":     "America_Eastern",
+	"America/New_York":    "America_Eastern",
+	"America/Toronto":     "America_Eastern",
+	"America/Chicago":     "America_Central",
+	"America/Mexico_City": "America_Central",
+	"America/Winnipeg":    "America_Central",
+	"America/Boise":       "America_Mountain",
+	"America/Denver":      "America_Mountain",
+	"America/Edmonton":    "America_Mountain",
+	"America/Phoenix":     "America_Mountain",
+	"America/Los_Angeles": "America_Pacific",
+	"America/Tijuana":     "America_Pacific",
+	"America/Vancouver":   "America_Pacific",
+	"America/Anchorage":   "Alaska",
+	"Pacific/Honolulu":    "Hawaii_Aleutian",
+	"Asia/Kolkata":        "India",
+	"Asia/Shanghai":       "China",
+	"Asia/Macau":          "China",
+	"Asia/Tokyo":          "Japan",
+	"Australia/Brisbane":  "Australia_Eastern",
+	"Australia/Hobart":    "Australia_Eastern",
+	"Australia/Melbourne": "Australia_Eastern",
+	"Australia/Sydney":    "Australia_Eastern",
+}
+
+// locales lists the locale tables. The first is used for unknown locales.
+var locales = []*localeTable{
+	{
+		tag:       "en",
+		gmtPrefix: "GMT",
+		gmtZero:   "GMT",
+		names: map[string]zoneNames{
+			"GMT":               {"Greenwich Mean Time", "", ""},
+			"Europe_Western":    {"Western European Standard Time", "Western European Summer Time", "Western European Time"},
+			"Europe_Central":    {"Central European Standard Time", "Central European Summer Time", "Central European Time"},
+			"Europe_Eastern":    {"Eastern European Standard Time", "Eastern European Summer Time", "Eastern European Time"},
+			"America_Eastern":   {"Eastern Standard Time", "Eastern Daylight Time", "Eastern Time"},
+			"America_Central":   {"Central Standard Time", "Central Daylight Time", "Central Time"},
+			"America_Mountain":  {"Mountain Standard Time", "Mountain Daylight Time", "Mountain Time"},
+			"America_Pacific":   {"Pacific Standard Time", "Pacific Daylight Time", "Pacific Time"},
+			"Alaska":            {"Alaska Standard Time", "Alaska Daylight Time", "Alaska Time"},
+			"Hawaii_Aleutian":   {"Hawaii-Aleutian Standard Time", "Hawaii-Aleutian Daylight Time", "Hawaii-Aleutian Time"},
+			"India":             {"India Standard Time", "", ""},
+			"China":             {"China Standard Time", "China Daylight Time", "China Time"},
+			"Japan":             {"Japan Standard Time", "Japan Daylight Time", "Japan Time"},
+			"Australia_Eastern": {"Australian Eastern Standard Time", "Australian Eastern Daylight Time", "Eastern Australia Time"},
+		},
+	},
+	{
+		tag:       "fr",
+		gmtPrefix: "UTC",
+		gmtZero:   "UTC",
+		names: map[string]zoneNames{
+			"GMT":               {"heure moyenne de Greenwich", "", ""},
+			"Europe_Western":    {"heure normale d’Europe de l’Ouest", "heure d’été d’Europe de l’Ouest", "heure d’Europe de l’Ouest"},
+			"Europe_Central":    {"heure normale d’Europe centrale", "heure d’été d’Europe centrale", "heure d’Europe centrale"},
+			"Europe_Eastern":    {"heure normale d’Europe de l’Est", "heure d’été d’Europe de l’Est", "heure d’Europe de l’Est"},
+			"America_Eastern":   {"heure normale de l’Est nord-américain", "heure d’été de l’Est nord-américain", "heure de l’Est nord-américain"},
+			"America_Central":   {"heure normale du centre nord-américain", "heure d’été du centre nord-américain", "heure du centre nord-américain"},
+			"America_Mountain":  {"heure normale des Rocheuses", "heure d’été des Rocheuses", "heure des Rocheuses"},
+			"America_Pacific":   {"heure normale du Pacifique nord-américain", "heure d’été du Pacifique nord-américain", "heure du Pacifique nord-américain"},
+			"Alaska":            {"heure normale de l’Alaska", "heure d’été de l’Alaska", "heure de l’Alaska"},
+			"Hawaii_Aleutian":   {"heure normale d’Hawaï - Aléoutiennes", "heure d’été d’Hawaï - Aléoutiennes", "heure d’Hawaï - Aléoutiennes"},
+			"India":             {"heure de l’Inde", "", ""},
+			"China":             {"heure normale de la Chine", "heure d’été de Chine", "heure de la Chine"},
+			"Japan":             {"heure normale du Japon", "heure d’été du Japon", "heure du Japon"},
+			"Australia_Eastern": {"heure normale de l’Est de l’Australie", "heure d’été de l’Est de l’Australie", "heure de l’Est de l’Australie"},
+		},
+	},
+	{
+		tag:       "zh",
+		gmtPrefix: "GMT",
+		gmtZero:   "GMT",
+		names: map[string]zoneNames{
+			"GMT":               {"格林尼治标准时间", "", ""},
+			"Europe_Western":    {"西欧标准时间", "西欧夏令时间", "西欧时间"},
+			"Europe_Central":    {"中欧标准时间", "中欧夏令时间", "中欧时间"},
+			"Europe_Eastern":    {"东欧标准时间", "东欧夏令时间", "东欧时间"},
+			"America_Eastern":   {"北美东部标准时间", "北美东部夏令时间", "北美东部时间"},
+			"America_Central":   {"北美中部标准时间", "北美中部夏令时间", "北美中部时间"},
+			"America_Mountain":  {"北美山区标准时间", "北美山区夏令时间", "北美山区时间"},
+			"America_Pacific":   {"北美太平洋标准时间", "北美太平洋夏令时间", "北美太平洋时间"},
+			"Alaska":            {"阿拉斯加标准时间", "阿拉斯加夏令时间", "阿拉斯加时间"},
+			"Hawaii_Aleutian":   {"夏威夷-阿留申标准时间", "夏威夷-阿留申夏令时间", "夏威夷-阿留申时间"},
+			"India":             {"印度时间", "", ""},
+			"China":             {"中国标准时间", "中国夏令时间", "中国时间"},
+			"Japan":             {"日本标准时间", "日本夏令时间", "日本时间"},
+			"Australia_Eastern": {"澳大利亚东部标准时间", "澳大利亚东部夏令时间", "澳大利亚东部时间"},
+		},
+	},
+}
@@ -0,0 +1,165 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tznames
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+)
+
+// PickerData is what a web front end needs to build a time zone
+// selector: the zones of the time zone database as they are at one
+// instant, with labels in one locale, and the countries that use them.
+// 时区选择器所需的数据，可直接编码为 JSON 发给前端
+type PickerData struct {
+	Locale    string          `json:"locale"`
+	At        int64           `json:"at"` // the instant, in Unix seconds
+	Zones     []PickerZone    `json:"zones"`
+	Countries []PickerCountry `json:"countries"`
+}
+
+// A PickerZone is one zone of a PickerData, sorted by offset and then
+// by ID.
+type PickerZone struct {
+	ID     string `json:"id"`     // zone name, such as "Europe/Paris"
+	City   string `json:"city"`   // last element of ID, such as "Paris"
+	Abbr   string `json:"abbr"`   // abbreviation, such as "CEST"
+	Offset int    `json:"offset"` // minutes east of UTC
+	DST    bool   `json:"dst,omitempty"`
+	Label  string `json:"label"` // such as "(GMT+02:00) Central European Summer Time - Paris"
+}
+
+// A PickerCountry lists the zones of a country, by ID, in the order
+// of zone.tab.
+type PickerCountry struct {
+	Code  string   `json:"code"` // ISO 3166 alpha-2 code, such as "FR"
+	Name  string   `json:"name,omitempty"`
+	Zones []string `json:"zones"`
+}
+
+// Picker returns the zones of the time zone database, and the
+// countries that use them, as they are at time t, labelled in the
+// given locale. The zones are those listed in zone1970.tab, as
+// reported by time.ZoneMetadata, and "UTC"; aliases are left out.
+// Country names are those of time.CountryName, which are in English.
+func Picker(t time.Time, locale string) (*PickerData, error) {
+	names, err := time.AvailableZones()
+	if err != nil {
+		return nil, err
+	}
+	lt := lookupLocale(locale)
+	d := &PickerData{Locale: lt.tag, At: t.Unix()}
+	byCode := make(map[string]*PickerCountry)
+	for _, name := range names {
+		if name == "UTC" {
+			continue
+		}
+		e, err := time.ZoneMetadata(name)
+		if err != nil {
+			continue
+		}
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			continue
+		}
+		d.Zones = append(d.Zones, pickerZone(loc, t, locale, lt))
+		for _, code := range e.Countries {
+			c := byCode[code]
+			if c == nil {
+				c = &PickerCountry{Code: code}
+				c.Name, _ = time.CountryName(code)
+				byCode[code] = c
+			}
+			c.Zones = append(c.Zones, name)
+		}
+	}
+	if len(d.Zones) == 0 {
+		return nil, errors.New("tznames: no zones found in zone1970.tab")
+	}
+	d.Zones = append(d.Zones, pickerZone(time.UTC, t, locale, lt))
+
+	sort.Slice(d.Zones, func(i, j int) bool {
+		a, b := &d.Zones[i], &d.Zones[j]
+		if a.Offset != b.Offset {
+			return a.Offset < b.Offset
+		}
+		return a.ID < b.ID
+	})
+	for _, c := range byCode {
+		// AvailableZones lists zones by name; zone.tab lists those of
+		// a country from north to south, the most populous first
+		// within a region. Zones zone.tab does not list for the
+		// country go last.
+		order := make(map[string]int)
+		if zones, err := time.ZonesForCountry(c.Code); err == nil {
+			for i, z := range zones {
+				order[z] = i + 1
+			}
+		}
+		key := func(z string) int {
+			if i, ok := order[z]; ok {
+				return i
+			}
+			return len(order) + 1
+		}
+		sort.SliceStable(c.Zones, func(i, j int) bool {
+			return key(c.Zones[i]) < key(c.Zones[j])
+		})
+		d.Countries = append(d.Countries, *c)
+	}
+	sort.Slice(d.Countries, func(i, j int) bool {
+		return d.Countries[i].Code < d.Countries[j].Code
+	})
+	return d, nil
+}
+
+// PickerJSON returns the JSON encoding of Picker(t, locale).
+func PickerJSON(t time.Time, locale string) ([]byte, error) {
+	d, err := Picker(t, locale)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(d)
+}
+
+// pickerZone returns the PickerZone of loc at time t.
+func pickerZone(loc *time.Location, t time.Time, locale string, lt *localeTable) PickerZone {
+	t = t.In(loc)
+	abbr, offset := t.Zone()
+	id := loc.String()
+	city := id
+	for i := len(id) - 1; i >= 0; i-- {
+		if id[i] == '/' {
+			city = id[i+1:]
+			break
+		}
+	}
+	b := []byte(city)
+	for i, c := range b {
+		if c == '_' {
+			b[i] = ' '
+		}
+	}
+	city = string(b)
+
+	// Zones without a name in the locale would have their offset
+	// twice.
+	gmt := gmtName(lt, offset)
+	label := "(" + gmt + ") "
+	if name := Name(loc, t, locale, Specific); name != gmt {
+		label += name + " - "
+	}
+	label += city
+	return PickerZone{
+		ID:     id,
+		City:   city,
+		Abbr:   abbr,
+		Offset: offset / 60,
+		DST:    t.IsDST(),
+		Label:  label,
+	}
+}
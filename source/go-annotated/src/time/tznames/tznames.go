@@ -0,0 +1,140 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package tznames provides localized, human-friendly time zone names,
+// such as "heure normale d’Europe centrale" for Europe/Paris in French,
+// for building internationalized date pickers.
+// 本地化的时区显示名称，例如法语的 "heure normale d’Europe centrale"
+//
+// Names follow the Unicode CLDR: zones are grouped into metazones, such
+// as "Europe_Central", that have a standard, a daylight and a generic
+// name in each locale. Zones without a name in the requested locale
+// are shown in the locale's GMT format, such as "UTC+05:45" in French.
+//
+// The tables in names.go are a subset of CLDR: the most used metazones
+// in English, French and Chinese. Zone-to-metazone mappings are the
+// current ones; historical changes of metazone are not modelled.
+package tznames
+
+import "time"
+
+// A Style selects the form of a zone name.
+type Style int
+
+const (
+	// Specific names say whether daylight saving time is in effect,
+	// such as "Central European Summer Time".
+	Specific Style = iota
+
+	// Generic names do not, such as "Central European Time",
+	// for zones whose offset changes during the year.
+	Generic
+)
+
+// Name returns the name in the given locale of the zone of loc in
+// effect at time t, such as "Central European Summer Time" for
+// Europe/Paris in July and locale "en". The locale is a BCP 47 tag
+// such as "fr" or "fr-CA"; regional variants fall back to the
+// language. Unknown locales use English.
+func Name(loc *time.Location, t time.Time, locale string, style Style) string {
+	t = t.In(loc)
+	lt := lookupLocale(locale)
+	if mz, ok := metazones[time.CanonicalZoneName(loc.String())]; ok {
+		if n, ok := lt.names[mz]; ok {
+			var s string
+			switch {
+			case style == Generic:
+				s = n.generic
+			case t.IsDST():
+				s = n.daylight
+			default:
+				s = n.standard
+			}
+			if s == "" && style == Generic && !t.IsDST() {
+				// Metazones without DST, such as "India", have only
+				// a standard name. Zones that use one of them but
+				// observe DST, such as Europe/London with "GMT",
+				// get the GMT format during DST.
+				s = n.standard
+			}
+			if s != "" {
+				return s
+			}
+		}
+	}
+	_, offset := t.Zone()
+	return gmtName(lt, offset)
+}
+
+// Locales returns the locales with names, such as "en" and "fr".
+func Locales() []string {
+	var l []string
+	for _, lt := range locales {
+		l = append(l, lt.tag)
+	}
+	return l
+}
+
+// lookupLocale returns the table for the locale tag, trying the
+// language alone if the full tag is not known.
+func lookupLocale(tag string) *localeTable {
+	for {
+		for _, lt := range locales {
+			if equalFold(lt.tag, tag) {
+				return lt
+			}
+		}
+		i := len(tag) - 1
+		for i >= 0 && tag[i] != '-' && tag[i] != '_' {
+			i--
+		}
+		if i < 0 {
+			return locales[0]
+		}
+		tag = tag[:i]
+	}
+}
+
+// gmtName formats offset in the locale's GMT format, such as
+// "GMT+05:45", or its name for GMT itself, such as "GMT".
+func gmtName(lt *localeTable, offset int) string {
+	if offset == 0 {
+		return lt.gmtZero
+	}
+	b := []byte(lt.gmtPrefix)
+	if offset < 0 {
+		b = append(b, '-')
+		offset = -offset
+	} else {
+		b = append(b, '+')
+	}
+	h, m := offset/3600, offset/60%60
+	b = append(b, byte('0'+h/10), byte('0'+h%10), ':', byte('0'+m/10), byte('0'+m%10))
+	return string(b)
+}
+
+func equalFold(a, b string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		x, y := a[i], b[i]
+		if 'A' <= x && x <= 'Z' {
+			x += 'a' - 'A'
+		}
+		if 'A' <= y && y <= 'Z' {
+			y += 'a' - 'A'
+		}
+		if x == '_' {
+			x = '-'
+		}
+		if y == '_' {
+			y = '-'
+		}
+		if x != y {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,235 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package tztest generates random time zones for fuzz and property
+// tests of code that handles time.Locations. The zones are legal, as
+// RFC 8536 and the POSIX TZ grammar define it, but are otherwise as
+// strange as the zone database allows: offsets with seconds and of
+// more than a day, transitions a second apart or centuries before
+// 1901, daylight saving time that moves the clock back, transitions
+// that change nothing, and duplicate zones.
+// 生成随机但合法的时区（Location 及对应的 TZif 字节），用于属性测试
+//
+// A typical test checks a property against many zones:
+//
+//	r := rand.New(rand.NewSource(1))
+//	for i := 0; i < 1000; i++ {
+//		loc, data := tztest.Generate(r, "Test/Zone", nil)
+//		checkRoundTrip(t, loc, data)
+//	}
+package tztest
+
+import (
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// Options controls the zones Generate makes. The zero value, and a
+// nil *Options, give the defaults.
+type Options struct {
+	// MaxZones is the most zones a Location has, not counting those
+	// of its rule. 0 means 8; the most a TZif file can hold is 256.
+	MaxZones int
+
+	// MaxTransitions is the most transitions a Location has. 0 means
+	// 50.
+	MaxTransitions int
+
+	// NoRule leaves out the POSIX TZ rule, so that every Location
+	// stays in the zone of its last transition forever.
+	NoRule bool
+}
+
+// Limits from RFC 8536, section 3.2: offsets should be in
+// [-89999, 93599], and abbreviations 3 to 6 characters long.
+const (
+	minOffset = -89999
+	maxOffset = 93599
+)
+
+// Generate returns a random Location with the given name, and a TZif
+// file describing it, as Location.AppendTZif writes it: loading the
+// file with time.LoadLocationFromTZData gives a Location that reports
+// the same zones at every instant. The results depend only on the
+// values r returns, so that a failing case can be reproduced from its
+// seed.
+//
+// Times before the first transition are in a zone of their own. If
+// the Location has a rule, its last transition is to the standard
+// time of the rule, which the rule agrees with, as RFC 8536 requires.
+func Generate(r *rand.Rand, name string, opts *Options) (*time.Location, []byte) {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.MaxZones <= 0 {
+		o.MaxZones = 8
+	}
+	if o.MaxZones > 256 {
+		o.MaxZones = 256
+	}
+	if o.MaxTransitions <= 0 {
+		o.MaxTransitions = 50
+	}
+
+	b := time.NewLocationBuilder(name)
+	type zone struct {
+		name   string
+		offset int
+		isDST  bool
+	}
+	nzone := 1 + r.Intn(o.MaxZones)
+	zones := make([]zone, nzone)
+	for i := range zones {
+		if i > 0 && r.Intn(8) == 0 {
+			zones[i] = zones[r.Intn(i)]
+		} else {
+			zones[i] = zone{abbrev(r), offset(r), r.Intn(3) == 0}
+		}
+		b.AddZone(zones[i].name, zones[i].offset, zones[i].isDST)
+	}
+
+	// Walk forward from a random start in steps of a second to
+	// decades, stopping well before times that Date cannot reach.
+	const limit = 1 << 37 // about the year 6325
+	when := r.Int63n(1<<36) - 1<<35
+	ntx := r.Intn(o.MaxTransitions + 1)
+	if !o.NoRule && ntx == o.MaxTransitions && ntx > 0 {
+		ntx-- // leave room for the transition to the rule
+	}
+	for i := 0; i < ntx && when < limit; i++ {
+		b.AddTransition(time.Unix(when, 0), r.Intn(nzone))
+		when += 1 + r.Int63n([...]int64{1 << 6, 1 << 12, 1 << 20, 1 << 25, 1 << 30}[r.Intn(5)])
+	}
+
+	if !o.NoRule && r.Intn(4) != 0 {
+		tz, stdName, stdOffset, north := rule(r)
+		// The middle of a month in which the rule gives standard time.
+		year := time.Unix(when, 0).UTC().Year() + 1
+		month := time.January
+		if !north {
+			month = time.July
+		}
+		last := time.Date(year, month, 15, 12, 0, 0, 0, time.UTC)
+		b.AddTransition(last, b.AddZone(stdName, stdOffset, false))
+		b.SetRule(tz)
+	}
+
+	l, err := b.Build()
+	if err != nil {
+		panic("tztest: " + err.Error())
+	}
+	data, err := l.AppendTZif(nil)
+	if err != nil {
+		panic("tztest: " + err.Error())
+	}
+	return l, data
+}
+
+// offset returns a random offset, in seconds east of UTC: most often
+// a whole number of hours, sometimes of quarter hours, and sometimes
+// anything in the range RFC 8536 allows.
+func offset(r *rand.Rand) int {
+	switch r.Intn(4) {
+	case 0, 1:
+		return (r.Intn(27) - 12) * 3600
+	case 2:
+		return (r.Intn(4*52+1) - 4*26) * 900
+	}
+	return minOffset + r.Intn(maxOffset-minOffset+1)
+}
+
+// abbrev returns a random zone abbreviation: letters, such as "QZT",
+// or a numeric one, such as "+0530" or "-03".
+func abbrev(r *rand.Rand) string {
+	if r.Intn(3) == 0 {
+		buf := []byte{"+-"[r.Intn(2)]}
+		n := 2 + r.Intn(4)
+		for len(buf) <= n {
+			buf = append(buf, byte('0'+r.Intn(10)))
+		}
+		return string(buf)
+	}
+	n := 3 + r.Intn(4)
+	buf := make([]byte, n)
+	for i := range buf {
+		buf[i] = byte('A' + r.Intn(26))
+	}
+	return string(buf)
+}
+
+// rule returns a random POSIX TZ rule with daylight saving time, such
+// as "AXT-5:30AXST-7,M3.2.0/1,M10.5.6/25", with the name and offset of
+// its standard time. If north is set, daylight saving time starts in
+// March to June and ends in August to November, keeping January in
+// standard time; otherwise it is the other way round, keeping July.
+func rule(r *rand.Rand) (tz, stdName string, stdOffset int, north bool) {
+	stdName, stdOffset = abbrev(r), offset(r)
+	dstName := abbrev(r)
+	// The amount the clocks move, which may be none, or backward as
+	// in Europe/Dublin.
+	save := [...]int{3600, 3600, 1800, 7200, -3600, 0, 1200}[r.Intn(7)]
+	dstOffset := stdOffset + save
+	if dstOffset > maxOffset || dstOffset < minOffset {
+		dstOffset = stdOffset
+	}
+
+	start := "," + date(r, 3+r.Intn(4))
+	end := "," + date(r, 8+r.Intn(4))
+	north = r.Intn(2) == 0
+	if !north {
+		start, end = end, start
+	}
+	tz = posixName(stdName) + posixOffset(stdOffset) +
+		posixName(dstName) + posixOffset(dstOffset) + start + end
+	return tz, stdName, stdOffset, north
+}
+
+// date returns a random "Mm.w.d/time" date in the given month. The
+// time is usually in the small hours, but may be -1 or up to 25 hours,
+// as RFC 8536 section 3.3.1 allows.
+func date(r *rand.Rand, month int) string {
+	s := "M" + strconv.Itoa(month) + "." + strconv.Itoa(1+r.Intn(5)) + "." + strconv.Itoa(r.Intn(7))
+	switch r.Intn(4) {
+	case 0:
+		return s // 2:00
+	case 1:
+		return s + "/" + strconv.Itoa(r.Intn(4))
+	case 2:
+		return s + "/" + []string{"-1", "24", "25", "0:30", "1:59:59"}[r.Intn(5)]
+	}
+	return s + "/" + strconv.Itoa(r.Intn(24)) + ":" + strconv.Itoa(r.Intn(60))
+}
+
+// posixName returns abbr as a POSIX TZ name, quoted if it is numeric.
+func posixName(abbr string) string {
+	if abbr[0] == '+' || abbr[0] == '-' {
+		return "<" + abbr + ">"
+	}
+	return abbr
+}
+
+// posixOffset returns offset, in seconds east of UTC, as a POSIX TZ
+// offset, which is west of UTC: -19800 is "5:30".
+func posixOffset(offset int) string {
+	s := ""
+	offset = -offset
+	if offset < 0 {
+		s, offset = "-", -offset
+	}
+	h, m, sec := offset/3600, offset/60%60, offset%60
+	s += strconv.Itoa(h)
+	if m != 0 || sec != 0 {
+		s += ":" + two(m)
+	}
+	if sec != 0 {
+		s += ":" + two(sec)
+	}
+	return s
+}
+
+func two(n int) string {
+	return string([]byte{byte('0' + n/10), byte('0' + n%10)})
+}
@@ -0,0 +1,322 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build go1.11
+
+// Package tzupdate keeps the time zone data of a long-running program
+// current by downloading new releases of the database, so that rule
+// changes announced after the program was deployed take effect
+// without a redeploy.
+// 从网络下载最新时区数据并缓存到本地，长期运行的程序无需重新部署即可获得规则更新
+//
+// It lives outside package time because net/http depends on time.
+//
+// An Updater downloads a zoneinfo.zip, as built by lib/time/update.bash
+// from an IANA release, from a mirror the program trusts, verifies it
+// against a published SHA-256 checksum, and keeps it in a cache
+// directory so that it survives restarts. Registered as a
+// time.ZoneSource, it takes precedence over the system database:
+//
+//	u := &tzupdate.Updater{URL: "https://mirror.example.com/tz/zoneinfo.zip"}
+//	if err := u.Register(); err != nil {
+//		log.Print(err) // no cached copy yet; the system database is used
+//	}
+//	stop := u.Start(24 * time.Hour)
+//	defer stop()
+package tzupdate
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxSize is the largest zoneinfo.zip accepted, as a guard against
+// a misbehaving server. The real one is about 400 KB.
+const maxSize = 16 << 20
+
+// An Updater fetches and serves time zone data. Its methods may be
+// called concurrently from multiple goroutines.
+type Updater struct {
+	// URL is the address of the zoneinfo.zip to download.
+	URL string
+
+	// ChecksumURL is the address of its SHA-256 checksum, in hex,
+	// optionally followed by white space and a file name as written
+	// by sha256sum. Empty means URL + ".sha256".
+	ChecksumURL string
+
+	// CacheDir is the directory in which the data is kept. Empty
+	// means "tzupdate" in the user's cache directory, as returned
+	// by os.UserCacheDir.
+	CacheDir string
+
+	// HTTPClient makes the requests. Nil means http.DefaultClient.
+	HTTPClient *http.Client
+
+	// ErrorLog receives the errors of the updates run by Start.
+	// Nil means the standard logger.
+	ErrorLog *log.Logger
+
+	registerOnce sync.Once
+
+	mu  sync.Mutex
+	db  *database // current data, or nil
+	sum string    // its checksum, in hex
+}
+
+// A database is a loaded zoneinfo.zip.
+type database struct {
+	files map[string]*zip.File
+}
+
+// Register loads the data cached by an earlier Update, if any, and
+// registers u with time.RegisterZoneSource. It returns an error if
+// there is no usable cached data, but registers u anyway, so that
+// the data of a later Update is used.
+func (u *Updater) Register() error {
+	err := u.loadCache()
+	u.registerOnce.Do(func() { time.RegisterZoneSource(u) })
+	return err
+}
+
+// ZoneData returns the TZif data of the named zone from the current
+// data, implementing time.ZoneSource. It returns nil, nil if there is
+// no current data or it has no such zone.
+func (u *Updater) ZoneData(name string) ([]byte, error) {
+	u.mu.Lock()
+	db := u.db
+	u.mu.Unlock()
+	if db == nil {
+		return nil, nil
+	}
+	f := db.files[name]
+	if f == nil {
+		return nil, nil
+	}
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+// Checksum returns the SHA-256 checksum, in hex, of the current data,
+// or "" if there is none.
+func (u *Updater) Checksum() string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.sum
+}
+
+// Update downloads the published checksum and, if it differs from
+// that of the current data, the data itself. If the data matches the
+// checksum and is a valid zoneinfo.zip, Update writes it to the cache
+// directory and switches to it, clearing the cache of
+// time.LoadLocation so that later loads see the new rules. Locations
+// already loaded keep the data they were loaded with.
+//
+// Update reports whether it switched to new data.
+func (u *Updater) Update() (bool, error) {
+	sumURL := u.ChecksumURL
+	if sumURL == "" {
+		sumURL = u.URL + ".sha256"
+	}
+	b, err := u.get(sumURL, 1024)
+	if err != nil {
+		return false, err
+	}
+	fields := strings.Fields(string(b))
+	if len(fields) == 0 || len(fields[0]) != 2*sha256.Size {
+		return false, errors.New("tzupdate: malformed checksum at " + sumURL)
+	}
+	want := strings.ToLower(fields[0])
+	if want == u.Checksum() {
+		return false, nil
+	}
+
+	data, err := u.get(u.URL, maxSize)
+	if err != nil {
+		return false, err
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != want {
+		return false, errors.New("tzupdate: checksum mismatch for " + u.URL)
+	}
+	db, err := open(data)
+	if err != nil {
+		return false, err
+	}
+	if err := u.writeCache(data); err != nil {
+		return false, err
+	}
+	u.use(db, want)
+	return true, nil
+}
+
+// Start calls Update now and then every interval in a new goroutine,
+// logging errors to u.ErrorLog, until the returned function is called.
+func (u *Updater) Start(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			if _, err := u.Update(); err != nil {
+				u.logf("%v", err)
+			}
+			select {
+			case <-t.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+func (u *Updater) logf(format string, args ...interface{}) {
+	if u.ErrorLog != nil {
+		u.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
+
+// use switches to db, whose checksum is sum.
+func (u *Updater) use(db *database, sum string) {
+	u.mu.Lock()
+	u.db, u.sum = db, sum
+	u.mu.Unlock()
+	time.ClearLocationCache()
+}
+
+// get returns the body of url, which must be at most max bytes.
+func (u *Updater) get(url string, max int64) ([]byte, error) {
+	hc := u.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("tzupdate: " + url + ": " + resp.Status)
+	}
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > max {
+		return nil, errors.New("tzupdate: " + url + ": response too large")
+	}
+	return b, nil
+}
+
+// open checks that data is a zoneinfo.zip and returns it as a
+// database.
+func open(data []byte) (*database, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.New("tzupdate: bad zoneinfo.zip: " + err.Error())
+	}
+	db := &database{files: make(map[string]*zip.File, len(zr.File))}
+	for _, f := range zr.File {
+		db.files[f.Name] = f
+	}
+	// Check one zone, which every release has, to catch an archive
+	// of something else.
+	f := db.files["UTC"]
+	if f == nil {
+		return nil, errors.New("tzupdate: bad zoneinfo.zip: no UTC zone")
+	}
+	r, err := f.Open()
+	if err == nil {
+		var b []byte
+		b, err = ioutil.ReadAll(r)
+		r.Close()
+		if err == nil {
+			_, err = time.LoadLocationFromTZData("UTC", b)
+		}
+	}
+	if err != nil {
+		return nil, errors.New("tzupdate: bad zoneinfo.zip: UTC: " + err.Error())
+	}
+	return db, nil
+}
+
+// cachePath returns the name of the cached zoneinfo.zip.
+func (u *Updater) cachePath() (string, error) {
+	dir := u.CacheDir
+	if dir == "" {
+		d, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(d, "tzupdate")
+	}
+	return filepath.Join(dir, "zoneinfo.zip"), nil
+}
+
+// loadCache switches to the cached data.
+func (u *Updater) loadCache() error {
+	name, err := u.cachePath()
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	db, err := open(data)
+	if err != nil {
+		return errors.New(err.Error() + " in " + name)
+	}
+	sum := sha256.Sum256(data)
+	u.use(db, hex.EncodeToString(sum[:]))
+	return nil
+}
+
+// writeCache replaces the cached data with data. Other processes
+// sharing the directory see either the old file or the new one.
+func (u *Updater) writeCache(data []byte) error {
+	name, err := u.cachePath()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(dir, "zoneinfo.zip.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), name)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
@@ -0,0 +1,426 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zic
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// genEnd is the last year whose transitions are listed when a
+	// POSIX TZ rule continues the zone.
+	genEnd = 2037
+
+	// lastYear is the last year whose transitions are listed when
+	// none can.
+	lastYear = 2100
+
+	// firstYear is the earliest year for rules from "minimum".
+	firstYear = 1800
+
+	// beginning stands for the start of time, when the first line
+	// of a zone begins.
+	beginning = -1 << 63
+)
+
+// A zoneKey identifies a zone of the compiled Location.
+type zoneKey struct {
+	name   string
+	offset int
+	isDST  bool
+}
+
+// An emission is a transition of the compiled Location.
+type emission struct {
+	when int64
+	z    zoneKey
+}
+
+// A compiler holds the state of the compilation of a zone.
+type compiler struct {
+	tx     []emission
+	extend string
+}
+
+// emit records that the zone changes to z at when.
+func (c *compiler) emit(when int64, z zoneKey) {
+	// A later line or rule taking effect at or before the previous
+	// transition replaces it.
+	for n := len(c.tx); n > 0 && when <= c.tx[n-1].when; n-- {
+		when = c.tx[n-1].when
+		c.tx = c.tx[:n-1]
+	}
+	if n := len(c.tx); n > 0 && c.tx[n-1].z == z {
+		return
+	}
+	c.tx = append(c.tx, emission{when, z})
+}
+
+// compile returns the Location described by the lines of a zone.
+func (db *Database) compile(name string, lines []*zoneLine) (*time.Location, error) {
+	var c compiler
+	start := int64(beginning)
+	for i, z := range lines {
+		final := i == len(lines)-1
+		if z.rules == "" {
+			k := z.key(z.save, "")
+			c.emit(start, k)
+			if final {
+				c.extend = posixFixed(k)
+			} else {
+				start = z.untilUTC(z.save)
+			}
+			continue
+		}
+
+		rules := db.rules[z.rules]
+		if rules == nil {
+			return nil, errors.New("unknown rule " + strconv.Quote(z.rules))
+		}
+		endYear := lastYear
+		if z.hasUntil {
+			endYear = z.untilYear
+		} else if ext, ok := z.posixRules(rules); ok {
+			c.extend, endYear = ext, genEnd
+		}
+		startYear := firstYear
+		if start != beginning {
+			startYear = time.Unix(start, 0).UTC().Year()
+		} else {
+			startYear = minRuleYear(rules, endYear)
+		}
+		insts := instances(rules, startYear-1, endYear, z.stdoff)
+
+		// The rules in effect at the start of the line, or standard
+		// time with the letters of the first standard time rule.
+		save, letter := 0, ""
+		for _, r := range rules {
+			if r.save == 0 {
+				letter = r.letter
+				break
+			}
+		}
+		for _, in := range insts {
+			if in.utc > start {
+				break
+			}
+			save, letter = in.r.save, in.r.letter
+		}
+		c.emit(start, z.key(save, letter))
+		for _, in := range insts {
+			if in.utc <= start {
+				continue
+			}
+			if z.hasUntil && in.utc >= z.untilUTC(save) {
+				break
+			}
+			save, letter = in.r.save, in.r.letter
+			c.emit(in.utc, z.key(save, letter))
+		}
+		if final && c.extend == "" && !hasOngoing(rules) {
+			c.extend = posixFixed(c.tx[len(c.tx)-1].z)
+		}
+		start = z.untilUTC(save)
+	}
+
+	b := time.NewLocationBuilder(name)
+	index := make(map[zoneKey]int)
+	for i, e := range c.tx {
+		idx, ok := index[e.z]
+		if !ok {
+			idx = b.AddZone(e.z.name, e.z.offset, e.z.isDST)
+			index[e.z] = idx
+		}
+		if i > 0 {
+			b.AddTransition(time.Unix(e.when, 0), idx)
+		}
+	}
+	if c.extend != "" {
+		b.SetRule(c.extend)
+	}
+	return b.Build()
+}
+
+// minRuleYear returns the first year of rules, but no earlier than
+// firstYear and no later than endYear.
+func minRuleYear(rules []*rule, endYear int) int {
+	y := endYear
+	for _, r := range rules {
+		if r.from < y {
+			y = r.from
+		}
+	}
+	if y < firstYear {
+		y = firstYear
+	}
+	return y
+}
+
+// hasOngoing reports whether any of rules continues forever.
+func hasOngoing(rules []*rule) bool {
+	for _, r := range rules {
+		if r.to == maxYear {
+			return true
+		}
+	}
+	return false
+}
+
+// key returns the zone of z with the given save and letters.
+func (z *zoneLine) key(save int, letter string) zoneKey {
+	return zoneKey{format(z.format, z.stdoff, save, letter), z.stdoff + save, save != 0}
+}
+
+// untilUTC returns the UNTIL of z as a Unix time, given the save in
+// effect at the time.
+func (z *zoneLine) untilUTC(save int) int64 {
+	if !z.hasUntil {
+		return 1<<63 - 1
+	}
+	return toUTC(dayStart(z.untilYear, z.untilMonth, z.untilDay), z.untilAt, z.stdoff, save)
+}
+
+// toUTC converts at on the day starting at the Unix time day to a
+// Unix time, given the standard offset and save in effect.
+func toUTC(day int64, at atTime, stdoff, save int) int64 {
+	t := day + int64(at.secs)
+	switch at.kind {
+	case 's':
+		t -= int64(stdoff)
+	case 'w':
+		t -= int64(stdoff + save)
+	}
+	return t
+}
+
+// dayStart returns midnight UTC of the day given by d in month m of
+// year y, as a Unix time.
+func dayStart(y int, m time.Month, d dayRule) int64 {
+	var t time.Time
+	switch d.kind {
+	case dayFixed:
+		t = time.Date(y, m, d.day, 0, 0, 0, 0, time.UTC)
+	case dayLast:
+		t = time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC)
+		t = t.AddDate(0, 0, -int(t.Weekday()-d.wd+7)%7)
+	case dayGeq:
+		t = time.Date(y, m, d.day, 0, 0, 0, 0, time.UTC)
+		t = t.AddDate(0, 0, int(d.wd-t.Weekday()+7)%7)
+	case dayLeq:
+		t = time.Date(y, m, d.day, 0, 0, 0, 0, time.UTC)
+		t = t.AddDate(0, 0, -int(t.Weekday()-d.wd+7)%7)
+	}
+	return t.Unix()
+}
+
+// An instance is a rule taking effect in a given year.
+type instance struct {
+	r   *rule
+	utc int64
+}
+
+// instances returns the times in years y0 to y1 when rules take
+// effect, in order, for a zone with standard offset stdoff.
+func instances(rules []*rule, y0, y1, stdoff int) []instance {
+	type wall struct {
+		r *rule
+		t int64 // day and AT, as if in UTC, for ordering
+		d int64 // day
+	}
+	var ws []wall
+	for _, r := range rules {
+		from, to := r.from, r.to
+		if from < y0 {
+			from = y0
+		}
+		if to > y1 {
+			to = y1
+		}
+		for y := from; y <= to; y++ {
+			d := dayStart(y, r.month, r.on)
+			ws = append(ws, wall{r, d + int64(r.at.secs), d})
+		}
+	}
+	sort.SliceStable(ws, func(i, j int) bool { return ws[i].t < ws[j].t })
+
+	insts := make([]instance, len(ws))
+	save := 0
+	for i, w := range ws {
+		insts[i] = instance{w.r, toUTC(w.d, w.r.at, stdoff, save)}
+		save = w.r.save
+	}
+	return insts
+}
+
+// format returns the abbreviation given by the FORMAT field f.
+func format(f string, stdoff, save int, letter string) string {
+	if i := strings.IndexByte(f, '/'); i >= 0 {
+		if save != 0 {
+			return f[i+1:]
+		}
+		return f[:i]
+	}
+	var b []byte
+	for i := 0; i < len(f); i++ {
+		if f[i] != '%' || i+1 == len(f) {
+			b = append(b, f[i])
+			continue
+		}
+		i++
+		switch f[i] {
+		case 's':
+			b = append(b, letter...)
+		case 'z':
+			b = appendNumericOffset(b, stdoff+save)
+		default:
+			b = append(b, f[i])
+		}
+	}
+	return string(b)
+}
+
+// appendNumericOffset appends off as %z does: +05, +0530 or -033408.
+func appendNumericOffset(b []byte, off int) []byte {
+	sign := byte('+')
+	if off < 0 {
+		sign, off = '-', -off
+	}
+	b = append(b, sign)
+	b = append2(b, off/3600)
+	if off%3600 != 0 {
+		b = append2(b, off/60%60)
+		if off%60 != 0 {
+			b = append2(b, off%60)
+		}
+	}
+	return b
+}
+
+func append2(b []byte, n int) []byte {
+	return append(b, byte('0'+n/10), byte('0'+n%10))
+}
+
+// posixFixed returns the POSIX TZ string for the fixed zone k.
+func posixFixed(k zoneKey) string {
+	return posixName(k.name) + posixTime(-k.offset)
+}
+
+// posixRules returns the POSIX TZ string for a zone line whose rules
+// continue forever, if they can be written as one.
+func (z *zoneLine) posixRules(rules []*rule) (string, bool) {
+	var std, dst *rule
+	for _, r := range rules {
+		if r.to != maxYear {
+			continue
+		}
+		if r.save == 0 && std == nil {
+			std = r
+		} else if r.save != 0 && dst == nil {
+			dst = r
+		} else {
+			return "", false
+		}
+	}
+	if std == nil || dst == nil {
+		return "", false
+	}
+	start, ok1 := posixDate(dst, z.stdoff, 0)
+	end, ok2 := posixDate(std, z.stdoff, dst.save)
+	if !ok1 || !ok2 {
+		return "", false
+	}
+	s := posixName(format(z.format, z.stdoff, 0, std.letter)) + posixTime(-z.stdoff) +
+		posixName(format(z.format, z.stdoff, dst.save, dst.letter))
+	if dst.save != 3600 {
+		s += posixTime(-(z.stdoff + dst.save))
+	}
+	return s + "," + start + "," + end, true
+}
+
+// daysBefore[m] is the number of days before month m+1 in a year
+// that is not a leap year.
+var daysBefore = [...]int{0, 31, 59, 90, 120, 151, 181, 212, 243, 273, 304, 334, 365}
+
+// posixDate returns the POSIX TZ date and time when r takes effect,
+// as "M3.5.0/1", given the standard offset and the save before it.
+func posixDate(r *rule, stdoff, save int) (string, bool) {
+	t := r.at.secs
+	switch r.at.kind {
+	case 's':
+		t += save
+	case 'u':
+		t += stdoff + save
+	}
+	var s string
+	on := r.on
+	if on.kind == dayLeq {
+		// Sun<=25 is Sun>=19.
+		if on.day-6 < 1 {
+			return "", false
+		}
+		on = dayRule{kind: dayGeq, day: on.day - 6, wd: on.wd}
+	}
+	switch on.kind {
+	case dayFixed:
+		if r.month == time.February && on.day == 29 {
+			return "", false
+		}
+		s = "J" + strconv.Itoa(daysBefore[r.month-1]+on.day)
+	case dayLast:
+		s = "M" + strconv.Itoa(int(r.month)) + ".5." + strconv.Itoa(int(on.wd))
+	case dayGeq:
+		// POSIX counts weeks from the 1st, 8th, 15th and 22nd. For
+		// other days, as Fri>=23, move to the weekday k days before
+		// in the week that starts k days before, and k days later
+		// in the day: Thu>=22 at AT+24h.
+		k := (on.day - 1) % 7
+		week := (on.day-k-1)/7 + 1
+		if week > 4 {
+			return "", false
+		}
+		wd := (int(on.wd) - k + 7) % 7
+		t += k * 86400
+		s = "M" + strconv.Itoa(int(r.month)) + "." + strconv.Itoa(week) + "." + strconv.Itoa(wd)
+	}
+	if t < -167*3600 || t > 167*3600 {
+		return "", false
+	}
+	if t != 2*3600 {
+		s += "/" + posixTime(t)
+	}
+	return s, true
+}
+
+// posixName returns name as a POSIX TZ zone name, quoted in <> unless
+// it is three or more letters.
+func posixName(name string) string {
+	if len(name) >= 3 && strings.Trim(name, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz") == "" {
+		return name
+	}
+	return "<" + name + ">"
+}
+
+// posixTime returns secs as [-]h[:mm[:ss]].
+func posixTime(secs int) string {
+	var b []byte
+	if secs < 0 {
+		b = append(b, '-')
+		secs = -secs
+	}
+	b = strconv.AppendInt(b, int64(secs/3600), 10)
+	if secs%3600 != 0 {
+		b = append(b, ':')
+		b = append2(b, secs/60%60)
+		if secs%60 != 0 {
+			b = append(b, ':')
+			b = append2(b, secs%60)
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,386 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zic
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Years meaning "minimum" and "maximum" in a Rule line.
+const (
+	minYear = -1 << 31
+	maxYear = 1<<31 - 1
+)
+
+// Kinds of dayRule.
+const (
+	dayFixed = iota // day of the month
+	dayLast         // last weekday of the month, as lastSun
+	dayGeq          // first weekday on or after day, as Sun>=8
+	dayLeq          // last weekday on or before day, as Sun<=25
+)
+
+// A dayRule is the ON field of a Rule line, or the day of an UNTIL.
+type dayRule struct {
+	kind int
+	day  int
+	wd   time.Weekday
+}
+
+// An atTime is the AT field of a Rule line, or the time of an UNTIL:
+// seconds since midnight in wall clock ('w'), standard ('s') or
+// universal ('u') time.
+type atTime struct {
+	secs int
+	kind byte
+}
+
+// A rule is a Rule line.
+type rule struct {
+	from, to int
+	month    time.Month
+	on       dayRule
+	at       atTime
+	save     int
+	letter   string
+}
+
+// A zoneLine is a Zone line or a continuation line.
+type zoneLine struct {
+	stdoff int
+	rules  string // name of the rules, or "" for save
+	save   int
+	format string
+
+	hasUntil   bool
+	untilYear  int
+	untilMonth time.Month
+	untilDay   dayRule
+	untilAt    atTime
+}
+
+// Parse adds the rules, zones and links of a source file to db.
+// The name of the file is used in error messages. Rules may be used
+// by zones of other files, so all files should be parsed before
+// compiling any zone.
+func (db *Database) Parse(name string, r io.Reader) error {
+	s := bufio.NewScanner(r)
+	db.cont = nil
+	for n := 1; s.Scan(); n++ {
+		f, err := fields(s.Text())
+		if err == nil && len(f) > 0 {
+			err = db.parseLine(f)
+		}
+		if err != nil {
+			return errors.New("zic: " + name + ":" + strconv.Itoa(n) + ": " + err.Error())
+		}
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+	if db.cont != nil {
+		return errors.New("zic: " + name + ": missing continuation line for zone " + db.contName)
+	}
+	return nil
+}
+
+// fields splits a line into fields at white space, honoring double
+// quotes and dropping the comment that starts with '#'.
+func fields(line string) ([]string, error) {
+	var f []string
+	for {
+		line = strings.TrimLeft(line, " \t\r\f\v")
+		if line == "" || line[0] == '#' {
+			return f, nil
+		}
+		var b []byte
+		for line != "" && !strings.ContainsRune(" \t\r\f\v#", rune(line[0])) {
+			if line[0] != '"' {
+				b = append(b, line[0])
+				line = line[1:]
+				continue
+			}
+			end := strings.IndexByte(line[1:], '"')
+			if end < 0 {
+				return nil, errors.New("unterminated quoted string")
+			}
+			b = append(b, line[1:1+end]...)
+			line = line[end+2:]
+		}
+		f = append(f, string(b))
+	}
+}
+
+func (db *Database) parseLine(f []string) error {
+	if db.cont != nil {
+		// Continuation lines start with STDOFF, a number.
+		if f[0] == "" || f[0][0] != '-' && (f[0][0] < '0' || '9' < f[0][0]) {
+			return errors.New("missing continuation line for zone " + db.contName)
+		}
+		return db.parseZoneLine(f, db.contName)
+	}
+	switch strings.ToLower(f[0]) {
+	case "r", "ru", "rul", "rule":
+		return db.parseRule(f)
+	case "z", "zo", "zon", "zone":
+		if len(f) < 5 {
+			return errors.New("wrong number of fields on Zone line")
+		}
+		if db.zones[f[1]] != nil {
+			return errors.New("duplicate zone " + f[1])
+		}
+		db.cont = nil
+		return db.parseZoneLine(f[2:], f[1])
+	case "l", "li", "lin", "link":
+		if len(f) != 3 {
+			return errors.New("wrong number of fields on Link line")
+		}
+		db.links[f[2]] = f[1]
+		return nil
+	case "le", "lea", "leap", "e", "ex", "exp", "expi", "expir", "expire", "expires":
+		return nil
+	}
+	return errors.New("input line of unknown type " + strconv.Quote(f[0]))
+}
+
+// parseRule parses the fields of a Rule line.
+func (db *Database) parseRule(f []string) error {
+	if len(f) != 10 {
+		return errors.New("wrong number of fields on Rule line")
+	}
+	r := &rule{}
+	var err error
+	if r.from, err = parseYear(f[2], minYear); err != nil {
+		return err
+	}
+	if strings.HasPrefix("only", strings.ToLower(f[3])) {
+		r.to = r.from
+	} else if r.to, err = parseYear(f[3], maxYear); err != nil {
+		return err
+	}
+	if r.to < r.from {
+		return errors.New("starting year greater than ending year")
+	}
+	if f[4] != "-" {
+		return errors.New("year type " + strconv.Quote(f[4]) + " is not supported")
+	}
+	if r.month, err = parseMonth(f[5]); err != nil {
+		return err
+	}
+	if r.on, err = parseDay(f[6]); err != nil {
+		return err
+	}
+	if r.at, err = parseAt(f[7]); err != nil {
+		return err
+	}
+	save := strings.TrimRight(f[8], "sd")
+	if r.save, err = parseHMS(save); err != nil {
+		return err
+	}
+	if f[9] != "-" {
+		r.letter = f[9]
+	}
+	db.rules[f[1]] = append(db.rules[f[1]], r)
+	return nil
+}
+
+// parseZoneLine parses STDOFF RULES FORMAT [UNTIL] of the zone name,
+// and appends it to the zone.
+func (db *Database) parseZoneLine(f []string, name string) error {
+	if len(f) < 3 || len(f) > 7 {
+		return errors.New("wrong number of fields on Zone line")
+	}
+	z := &zoneLine{format: f[2]}
+	var err error
+	if z.stdoff, err = parseHMS(f[0]); err != nil {
+		return err
+	}
+	switch {
+	case f[1] == "-":
+	case f[1] != "" && (f[1][0] == '-' || '0' <= f[1][0] && f[1][0] <= '9'):
+		if z.save, err = parseHMS(strings.TrimRight(f[1], "sd")); err != nil {
+			return err
+		}
+	default:
+		z.rules = f[1]
+	}
+	if strings.Count(z.format, "/") > 1 || strings.Contains(z.format, "/") && strings.Contains(z.format, "%") {
+		return errors.New("bad FORMAT " + strconv.Quote(z.format))
+	}
+	if len(f) > 3 {
+		z.hasUntil = true
+		if z.untilYear, err = parseYear(f[3], 0); err != nil {
+			return err
+		}
+		z.untilMonth, z.untilDay = time.January, dayRule{kind: dayFixed, day: 1}
+		if len(f) > 4 {
+			if z.untilMonth, err = parseMonth(f[4]); err != nil {
+				return err
+			}
+		}
+		if len(f) > 5 {
+			if z.untilDay, err = parseDay(f[5]); err != nil {
+				return err
+			}
+		}
+		z.untilAt.kind = 'w'
+		if len(f) > 6 {
+			if z.untilAt, err = parseAt(f[6]); err != nil {
+				return err
+			}
+		}
+	}
+	db.zones[name] = append(db.zones[name], z)
+	if z.hasUntil {
+		db.cont, db.contName = db.zones[name], name
+	} else {
+		db.cont = nil
+	}
+	return nil
+}
+
+// parseYear parses a year, or "minimum" or "maximum" abbreviated to at
+// least two letters, which give def. If def is 0, only numbers are
+// accepted.
+func parseYear(s string, def int) (int, error) {
+	ls := strings.ToLower(s)
+	if def != 0 && len(ls) >= 2 {
+		if def == minYear && strings.HasPrefix("minimum", ls) || def == maxYear && strings.HasPrefix("maximum", ls) {
+			return def, nil
+		}
+	}
+	y, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.New("invalid year " + strconv.Quote(s))
+	}
+	return y, nil
+}
+
+// prefixIndex returns the index of the one name in names of which s
+// is a prefix, ignoring case, or -1.
+func prefixIndex(names []string, s string) int {
+	ls := strings.ToLower(s)
+	found := -1
+	for i, name := range names {
+		if ls != "" && strings.HasPrefix(strings.ToLower(name), ls) {
+			if found >= 0 {
+				return -1
+			}
+			found = i
+		}
+	}
+	return found
+}
+
+var monthNames = []string{"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December"}
+
+var dayNames = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+func parseMonth(s string) (time.Month, error) {
+	i := prefixIndex(monthNames, s)
+	if i < 0 {
+		return 0, errors.New("invalid month name " + strconv.Quote(s))
+	}
+	return time.Month(i + 1), nil
+}
+
+// parseDay parses an ON field: 5, lastSun, Sun>=8 or Sun<=25.
+func parseDay(s string) (dayRule, error) {
+	bad := errors.New("invalid day of month " + strconv.Quote(s))
+	if strings.HasPrefix(strings.ToLower(s), "last") {
+		i := prefixIndex(dayNames, s[4:])
+		if i < 0 {
+			return dayRule{}, bad
+		}
+		return dayRule{kind: dayLast, wd: time.Weekday(i)}, nil
+	}
+	kind, op := dayGeq, strings.Index(s, ">=")
+	if op < 0 {
+		kind, op = dayLeq, strings.Index(s, "<=")
+	}
+	if op < 0 {
+		d, err := strconv.Atoi(s)
+		if err != nil || d < 1 || d > 31 {
+			return dayRule{}, bad
+		}
+		return dayRule{kind: dayFixed, day: d}, nil
+	}
+	i := prefixIndex(dayNames, s[:op])
+	d, err := strconv.Atoi(s[op+2:])
+	if i < 0 || err != nil || d < 1 || d > 31 {
+		return dayRule{}, bad
+	}
+	return dayRule{kind: kind, day: d, wd: time.Weekday(i)}, nil
+}
+
+// parseAt parses a time with an optional suffix: w for wall clock
+// time, the default, s for standard time, or u, g or z for universal
+// time.
+func parseAt(s string) (atTime, error) {
+	kind := byte('w')
+	if s != "" {
+		switch s[len(s)-1] {
+		case 'w':
+			s = s[:len(s)-1]
+		case 's':
+			kind, s = 's', s[:len(s)-1]
+		case 'u', 'g', 'z':
+			kind, s = 'u', s[:len(s)-1]
+		}
+	}
+	secs, err := parseHMS(s)
+	return atTime{secs, kind}, err
+}
+
+// parseHMS parses a signed time of day or duration, as 2, 2:00,
+// -0:34:08 or 25:00, into seconds. "-" means zero. A fraction of a
+// second is rounded.
+func parseHMS(s string) (int, error) {
+	if s == "-" || s == "" {
+		return 0, nil
+	}
+	bad := errors.New("invalid time " + strconv.Quote(s))
+	neg := s[0] == '-'
+	if neg {
+		s = s[1:]
+	}
+	frac := ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s, frac = s[:i], s[i+1:]
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, bad
+	}
+	secs := 0
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || i > 0 && n >= 60 {
+			return 0, bad
+		}
+		secs = secs*60 + n
+	}
+	for i := len(parts); i < 3; i++ {
+		secs *= 60
+	}
+	if frac != "" {
+		if len(parts) != 3 || strings.Trim(frac, "0123456789") != "" {
+			return 0, bad
+		}
+		if frac[0] >= '5' {
+			secs++
+		}
+	}
+	if neg {
+		secs = -secs
+	}
+	return secs, nil
+}
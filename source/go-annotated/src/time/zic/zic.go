@@ -0,0 +1,129 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package zic compiles the source files of the IANA Time Zone
+// database, such as "europe" and "northamerica", into Locations and
+// TZif data, as the zic program does, so that programs can use new
+// or custom rules without the system zic binary.
+// 纯 Go 实现的 zic：把 tzdata 源文件（Rule/Zone/Link）编译成 Location 或 TZif
+//
+//	db := zic.New()
+//	for _, name := range []string{"europe", "northamerica"} {
+//		f, err := os.Open(filepath.Join(tzdataDir, name))
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		err = db.Parse(name, f)
+//		f.Close()
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+//	berlin, err := db.Location("Europe/Berlin")
+//
+// A Database is also a time.ZoneSource, so it can be registered with
+// time.RegisterZoneSource to make LoadLocation use the compiled zones.
+//
+// The input is in the format described in the zic manual: Rule, Zone
+// and Link lines, which may be abbreviated to R, Z and L as in the
+// tzdata.zi file. Leap and Expires lines are ignored. As with zic,
+// transitions are listed explicitly up to 2037, after which a POSIX
+// TZ rule continues the zone when its rules can be written as one.
+// Otherwise, transitions are listed up to 2100.
+package zic
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// A Database holds the rules, zones and links of parsed source files.
+// It is safe to call its methods concurrently once parsing is done.
+type Database struct {
+	rules map[string][]*rule
+	zones map[string][]*zoneLine
+	links map[string]string // link name to target
+
+	// The zone whose continuation lines may follow, if any, and the
+	// file and line it was parsed from.
+	cont     []*zoneLine
+	contName string
+}
+
+// New returns an empty Database.
+func New() *Database {
+	return &Database{
+		rules: make(map[string][]*rule),
+		zones: make(map[string][]*zoneLine),
+		links: make(map[string]string),
+	}
+}
+
+// Names returns the sorted names of the zones and links in db.
+func (db *Database) Names() []string {
+	names := make([]string, 0, len(db.zones)+len(db.links))
+	for name := range db.zones {
+		names = append(names, name)
+	}
+	for name := range db.links {
+		if db.zones[name] == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// errUnknown is returned for names that are neither zones nor links.
+var errUnknown = errors.New("zic: unknown zone")
+
+// resolve returns the zone lines for name, following links.
+func (db *Database) resolve(name string) ([]*zoneLine, error) {
+	for i := 0; i < 16; i++ {
+		if z := db.zones[name]; z != nil {
+			return z, nil
+		}
+		target, ok := db.links[name]
+		if !ok {
+			return nil, errUnknown
+		}
+		name = target
+	}
+	return nil, errors.New("zic: link loop at " + name)
+}
+
+// Location compiles the named zone, or the zone a link of that name
+// points to, into a Location of that name.
+func (db *Database) Location(name string) (*time.Location, error) {
+	z, err := db.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	l, err := db.compile(name, z)
+	if err != nil {
+		return nil, errors.New("zic: " + name + ": " + err.Error())
+	}
+	return l, nil
+}
+
+// TZif compiles the named zone as Location does and returns it as
+// the contents of a TZif file (RFC 8536).
+func (db *Database) TZif(name string) ([]byte, error) {
+	l, err := db.Location(name)
+	if err != nil {
+		return nil, err
+	}
+	return l.AppendTZif(nil)
+}
+
+// ZoneData returns TZif for the named zone, implementing
+// time.ZoneSource. It returns nil, nil if db has no such zone.
+func (db *Database) ZoneData(name string) ([]byte, error) {
+	data, err := db.TZif(name)
+	if err == errUnknown {
+		return nil, nil
+	}
+	return data, err
+}
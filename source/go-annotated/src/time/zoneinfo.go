@@ -6,8 +6,11 @@ package time
 
 import (
 	"errors"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"syscall"
+	"unsafe"
 )
 
 //go:generate env ZONEINFO=$GOROOT/lib/time/zoneinfo.zip go run genzabbrs.go -output zoneinfo_abbrs_windows.go
@@ -25,25 +28,47 @@ type Location struct {
 	zone []zone
 	tx   []zoneTrans
 
+	// lazy, if not nil, holds the transition table instead of tx,
+	// until it is needed; use transitions to read it.
+	// 延迟解析的转换表
+	lazy *lazyTx
+
+	// extend is a POSIX TZ string, such as "CST6CDT,M3.2.0,M11.1.0",
+	// used to compute zones for times after the last transition.
+	// 最后一次转换之后的时间，按照这条 POSIX TZ 规则计算时区
+	extend string
+
+	// leap is the leap second table, sorted by time.
+	// Only the "right/" time zone files have one.
+	// 闰秒表
+	leap []leapSecond
+
+	// alias is the name passed to LoadCanonicalLocation,
+	// if it differs from name.
+	// 通过别名加载时记录原来的名字
+	alias string
+
+	// source records where the data came from; see Source.
+	source LocationSource
+
+	// shared is set on the Locations interned by FixedZone, which any
+	// number of callers may hold. The methods that change a Location
+	// refuse to change them.
+	shared bool
+
 	// Most lookups will be for the current time
 	// 大多数查找会是当前时间。
 	// To avoid the binary search through tx, keep a
 	// 为了避免在tx中进行二分查找，
-	// static one-element cache that gives the correct
-	// 保持一个静态的单元素缓存，它在创建位置时提供了正确的时区。
-	// zone for the time when the Location was created.
-	// if cacheStart <= t < cacheEnd,
-	// lookup can return cacheZone.
-	// 查询可以返回cacheZone
-	// The units for cacheStart and cacheEnd are seconds
-	// cacheStart和cacheEnd的单元是自1970年1月1日以来的秒
-	// since January 1, 1970 UTC, to match the argument
-	// to lookup.
-	// 为了匹配查找的参数
-
-	cacheStart int64
-	cacheEnd   int64
-	cacheZone  *zone		//缓存的时区
+	// cache that gives the correct zone for the time when
+	// the Location was created, along with the zones most
+	// recently returned by lookup.
+	// 缓存创建时的时区，以及最近查询过的时区区间
+	// The cache is a *zoneCache: it is replaced as a whole, and
+	// its recent windows updated, with atomic operations, so
+	// lookups may run concurrently with cache updates.
+	// 缓存用原子操作更新，并发查询是安全的
+	cache unsafe.Pointer // *zoneCache
 }
 
 // A zone represents a single time zone such as CEST or CET.
@@ -100,6 +125,10 @@ func (l *Location) get() *Location {
 	}
 	if l == &localLoc {
 		localOnce.Do(initLocal)
+		// 系统时区变化后，使用重新加载的数据
+		if p := atomic.LoadPointer(&localReloaded); p != nil {
+			return (*Location)(p)
+		}
 	}
 	return l
 }
@@ -110,19 +139,71 @@ func (l *Location) String() string {
 	return l.get().name
 }
 
+// Equal reports whether l and other describe the same time zone rules:
+// the same zones, the same transitions between them, the same rule for
+// times after the last transition and the same leap seconds.
+// Names are not compared, so two Locations loaded independently from
+// the same data are equal, and so are aliases such as "US/Eastern"
+// and "America/New_York".
+// 比较时区规则是否相同，而不是比较指针
+func (l *Location) Equal(other *Location) bool {
+	l, other = l.get(), other.get()
+	if l == other {
+		return true
+	}
+	ltx, otx := l.transitions(), other.transitions()
+	if len(l.zone) != len(other.zone) || len(ltx) != len(otx) ||
+		len(l.leap) != len(other.leap) || l.extend != other.extend {
+		return false
+	}
+	for i := range l.zone {
+		if l.zone[i] != other.zone[i] {
+			return false
+		}
+	}
+	// The standard/wall and UTC/local indicators do not affect
+	// lookups, and are not compared.
+	for i := range ltx {
+		if ltx[i].when != otx[i].when || ltx[i].index != otx[i].index {
+			return false
+		}
+	}
+	for i := range l.leap {
+		if l.leap[i] != other.leap[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // FixedZone returns a Location that always uses
 // 直接创建一个 Location
 // the given zone name and offset (seconds east of UTC).
 // 传递参数为 时区偏移（秒）
+//
+// Calls with the same name and an offset of whole minutes, less than
+// a day, may return the same Location, so that parsing many times
+// with the offset "+05:30" does not allocate one for each. Such a
+// Location is shared: UnmarshalBinary and UnmarshalJSON refuse to
+// overwrite it, and SetCacheOptions leaves it as it is.
 func FixedZone(name string, offset int) *Location {
+	if offset%secondsPerMinute == 0 && -secondsPerDay < offset && offset < secondsPerDay {
+		return internFixedZone(name, offset)
+	}
+	return fixedZone(name, offset)
+}
+
+// fixedZone returns a new Location, as FixedZone describes.
+func fixedZone(name string, offset int) *Location {
 	l := &Location{
-		name:       name,
-		zone:       []zone{{name, offset, false}},
-		tx:         []zoneTrans{{alpha, 0, false, false}},
-		cacheStart: alpha,
-		cacheEnd:   omega,
+		name:   name,
+		zone:   []zone{{name, offset, false}},
+		tx:     []zoneTrans{{alpha, 0, false, false}},
+		source: LocationSource{Kind: SourceRule},
 	}
-	l.cacheZone = &l.zone[0]
+	c := newZoneCache(nil)
+	c.now = zoneWindow{name, offset, false, alpha, omega}
+	l.cache = unsafe.Pointer(c)
 	return l
 }
 
@@ -154,35 +235,54 @@ func (l *Location) lookup(sec int64) (name string, offset int, isDST bool, start
 	}
 
 	// 若可以使用缓存，则使用缓存
-	if zone := l.cacheZone; zone != nil && l.cacheStart <= sec && sec < l.cacheEnd {
-		name = zone.name
-		offset = zone.offset
-		isDST = zone.isDST
-		start = l.cacheStart
-		end = l.cacheEnd
-		return
+	c := l.loadCache()
+	if name, offset, isDST, start, end, ok := c.find(l, sec); ok {
+		countLookup(true)
+		c.count(true)
+		return name, offset, isDST, start, end
+	}
+	countLookup(false)
+	c.count(false)
+
+	if c == nil {
+		c = newZoneCache(nil)
+		if !atomic.CompareAndSwapPointer(&l.cache, nil, unsafe.Pointer(c)) {
+			c = l.loadCache()
+		}
+	}
+	if name, offset, isDST, start, end, ok := c.search(l, sec); ok {
+		return name, offset, isDST, start, end
+	}
+	return l.lookupTx(sec)
+}
+
+// lookupTx is lookup without the caches: it searches the
+// transition table and applies the extend string.
+func (l *Location) lookupTx(sec int64) (name string, offset int, isDST bool, start, end int64) {
+	if name, offset, isDST, start, end, ok := l.lookupLast(sec); ok {
+		return name, offset, isDST, start, end
 	}
 
   //使用高端算法查找 zone
-	if len(l.tx) == 0 || sec < l.tx[0].when {
+	tx := l.transitions()
+	if len(tx) == 0 || sec < tx[0].when {
 		zone := &l.zone[l.lookupFirstZone()]
 		name = zone.name
 		offset = zone.offset
 		isDST = zone.isDST
 		start = alpha
-		if len(l.tx) > 0 {
-			end = l.tx[0].when
+		if len(tx) > 0 {
+			end = tx[0].when
 		} else {
 			end = omega
 		}
-		return
+		return name, offset, isDST, start, end
 	}
 
 	// Binary search for entry with largest time <= sec.
 	//二分搜索zone
 	// Not using sort.Search to avoid dependencies.
 	// 不使用 sort 库 避免出现依赖
-	tx := l.tx
 	end = omega
 	lo := 0
 	hi := len(tx)
@@ -202,9 +302,91 @@ func (l *Location) lookup(sec int64) (name string, offset int, isDST bool, start
 	isDST = zone.isDST
 	start = tx[lo].when
 	// end = maintained during the search
+
+	// If we're at the end of the known zone transitions,
+	// try the extend string.
+	// 超出了转换表的范围，使用 POSIX TZ 规则计算
+	if lo == len(tx)-1 && l.extend != "" {
+		if ename, eoffset, estart, eend, eisDST, ok := tzset(l.extend, start, sec); ok {
+			return ename, eoffset, eisDST, estart, eend
+		}
+	}
+	return name, offset, isDST, start, end
+}
+
+
+// ZoneBounds returns the bounds of the zone of l in effect at time t:
+// the zone begins at start and the next zone begins at end, so the
+// offset and abbreviation of t are valid for all times in [start, end).
+// Both are returned in l.
+// If the zone begins at the beginning of time, start is the zero Time.
+// If the zone goes on forever, end is the zero Time.
+// 返回 t 所在时区段的起止时间，调度程序可以据此知道当前偏移何时失效
+func (l *Location) ZoneBounds(t Time) (start, end Time) {
+	l = l.get()
+	name, offset, isDST, startSec, endSec := l.lookup(t.unixSec())
+
+	// Zones computed from the extend rule are reported a year at a
+	// time, and a transition may change nothing but the std/utc
+	// indicators; skip boundaries where the zone stays the same.
+	// TZ rules repeat with the 400-year Gregorian cycle, so a zone
+	// that survives longer than that never ends.
+	const cycle = 401 * 366 * secondsPerDay
+	for first := endSec; endSec != omega; {
+		n, o, d, _, e := l.lookup(endSec)
+		if n != name || o != offset || d != isDST || e <= endSec {
+			break
+		}
+		endSec = e
+		if endSec-first > cycle {
+			endSec = omega
+		}
+	}
+	for first := startSec; startSec != alpha; {
+		n, o, d, s, _ := l.lookup(startSec - 1)
+		if n != name || o != offset || d != isDST || s >= startSec {
+			break
+		}
+		startSec = s
+		if first-startSec > cycle {
+			startSec = alpha
+		}
+	}
+
+	if startSec != alpha {
+		start = unixTime(startSec, 0)
+		start.setLoc(l)
+	}
+	if endSec != omega {
+		end = unixTime(endSec, 0)
+		end.setLoc(l)
+	}
 	return
 }
 
+// NextTransition returns the first time after t at which the offset,
+// abbreviation or daylight saving status of l changes, in l.
+// If l never changes after t, NextTransition returns the zero Time
+// and false.
+// 返回 t 之后的下一次时区转换，例如夏令时开始或结束的时刻
+func (l *Location) NextTransition(t Time) (Time, bool) {
+	_, end := l.ZoneBounds(t)
+	return end, !end.IsZero()
+}
+
+// PrevTransition returns the last time before t at which the offset,
+// abbreviation or daylight saving status of l changed, in l.
+// If l never changed before t, PrevTransition returns the zero Time
+// and false.
+// 返回 t 之前的上一次时区转换
+func (l *Location) PrevTransition(t Time) (Time, bool) {
+	start, _ := l.ZoneBounds(t)
+	if !start.IsZero() && start.unixSec() == t.unixSec() && t.nsec() == 0 {
+		// t is itself a transition; look at the zone before it.
+		start, _ = l.ZoneBounds(start.Add(-Second))
+	}
+	return start, !start.IsZero()
+}
 
 //一言以蔽之 高端算法查找 zone
 // lookupFirstZone returns the index of the time zone to use for times
@@ -229,8 +411,8 @@ func (l *Location) lookupFirstZone() int {
 	}
 
 	// Case 2.
-	if len(l.tx) > 0 && l.zone[l.tx[0].index].isDST {
-		for zi := int(l.tx[0].index) - 1; zi >= 0; zi-- {
+	if tx := l.transitions(); len(tx) > 0 && l.zone[tx[0].index].isDST {
+		for zi := int(tx[0].index) - 1; zi >= 0; zi-- {
 			if !l.zone[zi].isDST {
 				return zi
 			}
@@ -251,7 +433,7 @@ func (l *Location) lookupFirstZone() int {
 // firstZoneUsed returns whether the first zone is used by some
 // transition.
 func (l *Location) firstZoneUsed() bool {
-	for _, tx := range l.tx {
+	for _, tx := range l.transitions() {
 		if tx.index == 0 {
 			return true
 		}
@@ -261,24 +443,28 @@ func (l *Location) firstZoneUsed() bool {
 
 // lookupName returns information about the time zone with
 // the given name (such as "EST") at the given pseudo-Unix time
-// (what the given time of day would be in UTC).
-func (l *Location) lookupName(name string, unix int64) (offset int, ok bool) {
+// (what the given time of day would be in UTC). If inEffect is set,
+// only a zone in effect at that time matches; otherwise any does.
+func (l *Location) lookupName(name string, unix int64, inEffect bool) (offset int, ok bool) {
 	l = l.get()
 
-	// First try for a zone with the right name that was actually
+	// Callers first try for a zone with the right name that was actually
 	// in effect at the given time. (In Sydney, Australia, both standard
 	// and daylight-savings time are abbreviated "EST". Using the
 	// offset helps us pick the right one for the given time.
 	// It's not perfect: during the backward transition we might pick
 	// either one.)
-	for i := range l.zone {
-		zone := &l.zone[i]
-		if zone.name == name {
-			nam, offset, _, _, _ := l.lookup(unix - int64(zone.offset))
-			if nam == zone.name {
-				return offset, true
+	if inEffect {
+		for i := range l.zone {
+			zone := &l.zone[i]
+			if zone.name == name {
+				nam, offset, _, _, _ := l.lookup(unix - int64(zone.offset))
+				if nam == zone.name {
+					return offset, true
+				}
 			}
 		}
+		return
 	}
 
 	// Otherwise fall back to an ordinary name match.
@@ -293,14 +479,444 @@ func (l *Location) lookupName(name string, unix int64) (offset int, ok bool) {
 	return
 }
 
-// NOTE(rsc): Eventually we will need to accept the POSIX TZ environment
-// syntax too, but I don't feel like implementing it today.
+// tzsetLocation returns a Location with the given name whose zones
+// are described entirely by the POSIX TZ string s, such as
+// "CST6CDT,M3.2.0,M11.1.0" or "<+0330>-3:30". It reports false if s
+// is not a valid TZ string. The returned Location has no transitions
+// of its own; every lookup is answered by tzset.
+// 根据 POSIX TZ 字符串直接构建 Location（没有 tzfile 时使用）
+//
+// Since s comes from the user, its zone names must follow POSIX more
+// strictly than tzset, which also reads the footers of tzfiles: a name
+// not between '<' and '>' must be three or more letters, so that
+// "Europe/Pariss1" is not taken for a zone named "Europe/Pariss".
+func tzsetLocation(name, s string) (*Location, bool) {
+	// Reject anything tzset would not accept before
+	// building the zone table from the pieces.
+	if _, _, _, _, _, ok := tzset(s, alpha, 0); !ok {
+		return nil, false
+	}
+	if !isPOSIXName(s) {
+		return nil, false
+	}
+	stdName, rest, _ := tzsetName(s)
+	stdOffset, rest, _ := tzsetOffset(rest)
+	zones := []zone{{stdName, -stdOffset, false}}
+	if len(rest) > 0 && rest[0] != ',' {
+		if !isPOSIXName(rest) {
+			return nil, false
+		}
+		dstName, rest, _ := tzsetName(rest)
+		dstOffset := -stdOffset + secondsPerHour
+		if len(rest) > 0 && rest[0] != ',' && rest[0] != ';' {
+			dstOffset, _, _ = tzsetOffset(rest)
+			dstOffset = -dstOffset
+		}
+		zones = append(zones, zone{dstName, dstOffset, true})
+	}
+
+	l := &Location{
+		name:   name,
+		zone:   zones,
+		tx:     []zoneTrans{{alpha, 0, false, false}},
+		extend: s,
+		source: LocationSource{Kind: SourceRule},
+	}
+
+	l.resetCache()
+	return l, true
+}
+
+// tzset takes a timezone string like the one found in the TZ environment
+// variable, the end of the last time zone transition expressed as seconds
+// since January 1, 1970 00:00:00 UTC, and a time expressed the same way.
+// We call this a tzset string since in C the function tzset reads TZ.
+// The return values are as for lookup, plus ok which reports whether the
+// parse succeeded.
+func tzset(s string, initEnd, sec int64) (name string, offset int, start, end int64, isDST, ok bool) {
+	var (
+		stdName, dstName     string
+		stdOffset, dstOffset int
+	)
 
-var errLocation = errors.New("time: invalid location name")
+	stdName, s, ok = tzsetName(s)
+	if ok {
+		stdOffset, s, ok = tzsetOffset(s)
+	}
+	if !ok {
+		return "", 0, 0, 0, false, false
+	}
+
+	// The numbers in the tzset string are added to local time to get UTC,
+	// but our offsets are added to UTC to get local time,
+	// so we negate the number we see here.
+	stdOffset = -stdOffset
 
-var zoneinfo *string
+	if len(s) == 0 || s[0] == ',' {
+		// No daylight savings time.
+		return stdName, stdOffset, initEnd, omega, false, true
+	}
+
+	dstName, s, ok = tzsetName(s)
+	if ok {
+		if len(s) == 0 || s[0] == ',' {
+			dstOffset = stdOffset + secondsPerHour
+		} else {
+			dstOffset, s, ok = tzsetOffset(s)
+			dstOffset = -dstOffset // as with stdOffset, above
+		}
+	}
+	if !ok {
+		return "", 0, 0, 0, false, false
+	}
+
+	if len(s) == 0 {
+		// Default DST rules per tzcode.
+		s = ",M3.2.0,M11.1.0"
+	}
+	// The TZ definition does not mention ';' here but tzcode accepts it.
+	if s[0] != ',' && s[0] != ';' {
+		return "", 0, 0, 0, false, false
+	}
+	s = s[1:]
+
+	var startRule, endRule rule
+	startRule, s, ok = tzsetRule(s)
+	if !ok || len(s) == 0 || s[0] != ',' {
+		return "", 0, 0, 0, false, false
+	}
+	s = s[1:]
+	endRule, s, ok = tzsetRule(s)
+	if !ok || len(s) > 0 {
+		return "", 0, 0, 0, false, false
+	}
+
+	year, _, _, yday := absDate(uint64(sec+unixToInternal+internalToAbsolute), false)
+
+	ysec := int64(yday*secondsPerDay) + sec%secondsPerDay
+
+	// Compute start of year in seconds since Unix epoch.
+	d := daysSinceEpoch(year)
+	abs := int64(d * secondsPerDay)
+	abs += absoluteToInternal + internalToUnix
+	yearEnd := abs + 365*secondsPerDay
+	if isLeap(year) {
+		yearEnd += secondsPerDay
+	}
+
+	startSec := int64(tzruleTime(year, startRule, stdOffset))
+	endSec := int64(tzruleTime(year, endRule, dstOffset))
+	dstIsDST, stdIsDST := true, false
+	// Note: this is a flipping of "DST" and "STD" while retaining the labels
+	// This happens in southern hemispheres. The labelling here thus is a little
+	// inconsistent with the goal.
+	if endSec < startSec {
+		startSec, endSec = endSec, startSec
+		stdName, dstName = dstName, stdName
+		stdOffset, dstOffset = dstOffset, stdOffset
+		stdIsDST, dstIsDST = dstIsDST, stdIsDST
+	}
+
+	// The start and end values that we return are accurate
+	// close to a daylight savings transition, but are otherwise
+	// just the start and end of the year. That suffices for
+	// the only caller that cares, which is Date.
+	if ysec < startSec {
+		return stdName, stdOffset, abs, startSec + abs, stdIsDST, true
+	} else if ysec >= endSec {
+		return stdName, stdOffset, endSec + abs, yearEnd, stdIsDST, true
+	} else {
+		return dstName, dstOffset, startSec + abs, endSec + abs, dstIsDST, true
+	}
+}
+
+// tzsetName returns the timezone name at the start of the tzset string s,
+// and the remainder of s, and reports whether the parsing is OK.
+func tzsetName(s string) (string, string, bool) {
+	if len(s) == 0 {
+		return "", "", false
+	}
+	if s[0] != '<' {
+		for i, r := range s {
+			switch r {
+			case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', ',', '-', '+':
+				if i < 3 {
+					return "", "", false
+				}
+				return s[:i], s[i:], true
+			}
+		}
+		if len(s) < 3 {
+			return "", "", false
+		}
+		return s, "", true
+	} else {
+		for i, r := range s {
+			if r == '>' {
+				return s[1:i], s[i+1:], true
+			}
+		}
+		return "", "", false
+	}
+}
+
+// isPOSIXName reports whether the zone name at the start of the tzset
+// string s is quoted, as in "<+0330>", or is three or more letters.
+func isPOSIXName(s string) bool {
+	if len(s) > 0 && s[0] == '<' {
+		return true
+	}
+	name, _, ok := tzsetName(s)
+	return ok && isAlphaName(name)
+}
+
+// tzsetOffset returns the timezone offset at the start of the tzset string s,
+// and the remainder of s, and reports whether the parsing is OK.
+// The timezone offset is returned as a number of seconds.
+func tzsetOffset(s string) (offset int, rest string, ok bool) {
+	if len(s) == 0 {
+		return 0, "", false
+	}
+	neg := false
+	if s[0] == '+' {
+		s = s[1:]
+	} else if s[0] == '-' {
+		s = s[1:]
+		neg = true
+	}
+
+	// The tzdata code permits values up to 24 * 7 here,
+	// although POSIX does not.
+	var hours int
+	hours, s, ok = tzsetNum(s, 0, 24*7)
+	if !ok {
+		return 0, "", false
+	}
+	off := hours * secondsPerHour
+	if len(s) == 0 || s[0] != ':' {
+		if neg {
+			off = -off
+		}
+		return off, s, true
+	}
+
+	var mins int
+	mins, s, ok = tzsetNum(s[1:], 0, 59)
+	if !ok {
+		return 0, "", false
+	}
+	off += mins * secondsPerMinute
+	if len(s) == 0 || s[0] != ':' {
+		if neg {
+			off = -off
+		}
+		return off, s, true
+	}
+
+	var secs int
+	secs, s, ok = tzsetNum(s[1:], 0, 59)
+	if !ok {
+		return 0, "", false
+	}
+	off += secs
+
+	if neg {
+		off = -off
+	}
+	return off, s, true
+}
+
+// ruleKind is the kinds of rules that can be seen in a tzset string.
+type ruleKind int
+
+const (
+	ruleJulian ruleKind = iota
+	ruleDOY
+	ruleMonthWeekDay
+)
+
+// rule is a rule read from a tzset string.
+type rule struct {
+	kind ruleKind
+	day  int
+	week int
+	mon  int
+	time int // transition time
+}
+
+// tzsetRule parses a rule from a tzset string.
+// It returns the rule, and the remainder of the string, and reports success.
+func tzsetRule(s string) (rule, string, bool) {
+	var r rule
+	if len(s) == 0 {
+		return rule{}, "", false
+	}
+	ok := false
+	if s[0] == 'J' {
+		var jday int
+		jday, s, ok = tzsetNum(s[1:], 1, 365)
+		if !ok {
+			return rule{}, "", false
+		}
+		r.kind = ruleJulian
+		r.day = jday
+	} else if s[0] == 'M' {
+		var mon int
+		mon, s, ok = tzsetNum(s[1:], 1, 12)
+		if !ok || len(s) == 0 || s[0] != '.' {
+			return rule{}, "", false
+		}
+		var week int
+		week, s, ok = tzsetNum(s[1:], 1, 5)
+		if !ok || len(s) == 0 || s[0] != '.' {
+			return rule{}, "", false
+		}
+		var day int
+		day, s, ok = tzsetNum(s[1:], 0, 6)
+		if !ok {
+			return rule{}, "", false
+		}
+		r.kind = ruleMonthWeekDay
+		r.day = day
+		r.week = week
+		r.mon = mon
+	} else {
+		var day int
+		day, s, ok = tzsetNum(s, 0, 365)
+		if !ok {
+			return rule{}, "", false
+		}
+		r.kind = ruleDOY
+		r.day = day
+	}
+
+	if len(s) == 0 || s[0] != '/' {
+		r.time = 2 * secondsPerHour // 2am is the default
+		return r, s, true
+	}
+
+	offset, s, ok := tzsetOffset(s[1:])
+	if !ok {
+		return rule{}, "", false
+	}
+	r.time = offset
+
+	return r, s, true
+}
+
+// tzsetNum parses a number from a tzset string.
+// It returns the number, and the remainder of the string, and reports success.
+// The number must be between min and max.
+func tzsetNum(s string, min, max int) (num int, rest string, ok bool) {
+	if len(s) == 0 {
+		return 0, "", false
+	}
+	num = 0
+	for i, r := range s {
+		if r < '0' || r > '9' {
+			if i == 0 || num < min {
+				return 0, "", false
+			}
+			return num, s[i:], true
+		}
+		num *= 10
+		num += int(r) - '0'
+		if num > max {
+			return 0, "", false
+		}
+	}
+	if num < min {
+		return 0, "", false
+	}
+	return num, "", true
+}
+
+// tzruleTime takes a year, a rule, and a timezone offset,
+// and returns the number of seconds since the start of the year
+// that the rule takes effect.
+func tzruleTime(year int, r rule, off int) int {
+	var s int
+	switch r.kind {
+	case ruleJulian:
+		s = (r.day - 1) * secondsPerDay
+		if isLeap(year) && r.day >= 60 {
+			s += secondsPerDay
+		}
+	case ruleDOY:
+		s = r.day * secondsPerDay
+	case ruleMonthWeekDay:
+		// Zeller's Congruence.
+		m1 := (r.mon+9)%12 + 1
+		yy0 := year
+		if r.mon <= 2 {
+			yy0--
+		}
+		yy1 := yy0 / 100
+		yy2 := yy0 % 100
+		dow := ((26*m1-2)/10 + 1 + yy2 + yy2/4 + yy1/4 - 2*yy1) % 7
+		if dow < 0 {
+			dow += 7
+		}
+		// Now dow is the day-of-week of the first day of r.mon.
+		// Get the day-of-month of the first "dow" day.
+		d := r.day - dow
+		if d < 0 {
+			d += 7
+		}
+		for i := 1; i < r.week; i++ {
+			if d+7 >= daysIn(Month(r.mon), year) {
+				break
+			}
+			d += 7
+		}
+		d += int(daysBefore[r.mon-1])
+		if isLeap(year) && r.mon > 2 {
+			d++
+		}
+		s = d * secondsPerDay
+	}
+
+	return s + r.time - off
+}
+
+var errLocation = errors.New("invalid location name")
+
+var zoneinfo []string
 var zoneinfoOnce sync.Once
 
+// zoneinfoDirs returns the directories and zip files named by the
+// ZONEINFO environment variable, in order. Like PATH, ZONEINFO may
+// name several, separated by ':' (';' on Windows); empty entries
+// are ignored. In sandbox mode there are none. The result may be
+// appended to without affecting later calls.
+// ZONEINFO 可以像 PATH 一样包含多个路径，按顺序查找
+func zoneinfoDirs() []string {
+	zoneinfoOnce.Do(func() {
+		env, _ := syscall.Getenv("ZONEINFO")
+		sep := byte(':')
+		if runtime.GOOS == "windows" {
+			sep = ';'
+		}
+		for len(env) > 0 {
+			i := 0
+			for i < len(env) && env[i] != sep {
+				i++
+			}
+			if i > 0 {
+				zoneinfo = append(zoneinfo, env[:i])
+			}
+			if i < len(env) {
+				i++
+			}
+			env = env[i:]
+		}
+	})
+	if zoneSandboxed() {
+		return nil
+	}
+	return zoneinfo[:len(zoneinfo):len(zoneinfo)]
+}
+
 // LoadLocation returns the Location with the given name.
 // 根据给的 时区名 获取 Location
 //
@@ -312,20 +928,53 @@ var zoneinfoOnce sync.Once
 // 时区名是根据文件名设定的，文件名是根据 IANA 时区库设定的
 // https://www.iana.org/time-zones
 //
+// If no such file exists, the name may instead be a POSIX TZ string
+// such as "CST6CDT,M3.2.0,M11.1.0" or "<+0330>-3:30", in which case the
+// returned Location follows that rule for all times. Zone names in the
+// string must be three or more letters, or be quoted with '<' and '>'.
+// 找不到对应文件时，也可以是 POSIX TZ 字符串
+//
+// Zones that are a fixed offset from UTC load even without a
+// database: the names of UTC such as "Etc/UTC" and "Etc/GMT",
+// "Etc/GMT-14" to "Etc/GMT+12", and names such as "UTC+05:30" and
+// "UTC-03:00", in which, unlike in a TZ string, the sign is that of
+// the offset east of UTC.
+//
 // The time zone database needed by LoadLocation may not be
 // present on all systems, especially non-Unix systems.
 //
-// LoadLocation looks in the directory or uncompressed zip file
-// named by the ZONEINFO environment variable, if any, then looks in
+// LoadLocation looks in the directories and uncompressed zip files
+// named by the ZONEINFO environment variable, if any, in order (the
+// entries are separated by ':', or ';' on Windows), and in zip files
+// compressed in a format registered with RegisterZoneDecompressor,
+// then asks the sources added by RegisterZoneSource, then looks in
 // known installation locations on Unix systems,
 // and finally looks in $GOROOT/lib/time/zoneinfo.zip.
+// SetSystemZoneSources changes the last two.
+//
+// If the zone cannot be loaded, the error is a *LoadError. Its Err is
+// ErrUnknownZone if a time zone database was found but has no such
+// zone, ErrNoTZData if no database was found, ErrCorruptTZData if the
+// zone's data is malformed, or the error reported by a source.
+// 错误可以区分"没有这个时区"和"本机没有时区数据库"
+//
+// LoadLocation caches the zones it loads, so later calls with the
+// same name do not read the database again. Names it cannot find are
+// cached too, unless a source reported some other error; see
+// ClearLocationCache. Each call returns a Location of its own, which
+// shares the tables of the cached zone, so that changing it, as with
+// SetCacheOptions or UnmarshalBinary, does not affect other callers.
 
 // 加载 Location 所需的时区数据库可能不会出现在所有系统上，尤其是非unix系统。
 // LoadLocation 在目录中查找 未压缩的压缩文件 或 命名ZONEINFO环境变量,如果有,那是在在Unix系统上已知的安装位置,
 // 最后查找 $GOROOT/lib/time/zoneinfo.zip。
 
-//一次加载，会读写一次文件
-func LoadLocation(name string) (*Location, error) {
+//第一次加载会读取文件，之后使用缓存
+func LoadLocation(name string) (l *Location, err error) {
+	if trace := loadTracer(); trace != nil {
+		start := runtimeNano()
+		defer func() { trace(traceLoad, name, "", 0, runtimeNano()-start, err) }()
+	}
 	if name == "" || name == "UTC" {
 		return UTC, nil
 	}
@@ -335,22 +984,85 @@ func LoadLocation(name string) (*Location, error) {
 	if containsDotDot(name) || name[0] == '/' || name[0] == '\\' {
 		// No valid IANA Time Zone name contains a single dot,
 		// much less dot dot. Likewise, none begin with a slash.
-		return nil, errLocation
+		return nil, &LoadError{Name: name, Err: errLocation}
 	}
+	return loadLocationCached(name)
+}
 
-	//保证这个方法只调用一次 加锁类似于单例模式
-	zoneinfoOnce.Do(func() {
-		env, _ := syscall.Getenv("ZONEINFO")
-		zoneinfo = &env
-	})
-	if *zoneinfo != "" {
-		if zoneData, err := loadTzinfoFromDirOrZip(*zoneinfo, name); err == nil {
-			if z, err := LoadLocationFromTZData(name, zoneData); err == nil {
-				return z, nil
-			}
-		}
+// MustLoadLocation is like LoadLocation but panics if the zone cannot
+// be loaded. It simplifies the initialization of global variables
+// holding Locations that the program cannot do without.
+// 加载失败时 panic，适合初始化包级变量
+func MustLoadLocation(name string) *Location {
+	l, err := LoadLocation(name)
+	if err != nil {
+		panic("time: MustLoadLocation(" + quote(name) + "): " + err.Error())
 	}
-	return loadLocation(name, zoneSources)
+	return l
+}
+
+// LoadLocationDefault is like LoadLocation but returns fallback if the
+// zone cannot be loaded, for zones read from configuration that may be
+// empty, misspelled or missing from the host's database:
+//	loc := time.LoadLocationDefault(cfg.Zone, time.UTC)
+// Use LoadLocation to find out why a zone did not load.
+func LoadLocationDefault(name string, fallback *Location) *Location {
+	l, err := LoadLocation(name)
+	if err != nil {
+		return fallback
+	}
+	return l
+}
+
+// loadLocationUncached is LoadLocation without the cache,
+// for names that are neither UTC nor Local.
+func loadLocationUncached(name string) (*Location, error) {
+	var s zoneSearch
+	if z := s.loadDatabase(name); z != nil {
+		return z, nil
+	}
+	if z, ok := syntheticZone(name); ok {
+		return z, nil
+	}
+	// No tzfile by that name; it may be a POSIX TZ string
+	// such as "CST6CDT,M3.2.0,M11.1.0".
+	if z, ok := tzsetLocation(name, name); ok {
+		return z, nil
+	}
+	e := s.error(name)
+	if e.Err == ErrUnknownZone {
+		e.Suggestions = suggestZones(name)
+	}
+	return nil, e
+}
+
+// loadDatabase returns the named zone from the first time zone
+// database that has it, looking where LoadLocation does: in $ZONEINFO,
+// the sources added by RegisterZoneSource, the system database and
+// the embedded one, in order.
+func (s *zoneSearch) loadDatabase(name string) *Location {
+	if z := s.loadFrom(name, zoneinfoDirs()); z != nil {
+		return z
+	}
+	// 先查询通过 RegisterZoneSource 注册的数据源
+	if z := s.loadFromZoneSources(name); z != nil {
+		return z
+	}
+	if z := s.loadFrom(name, systemZoneSources()); z != nil {
+		return z
+	}
+	return s.loadEmbedded(name)
+}
+
+// loadLocalZone returns the named zone, such as the one in $TZ, for
+// Local. Every platform looks for it in the databases LoadLocation
+// uses, so that $ZONEINFO, registered sources and time/tzdata serve
+// Local as they serve LoadLocation, before any format of its own.
+// 所有平台的本地时区都按 LoadLocation 的顺序查找时区数据库
+func loadLocalZone(name string) (*Location, bool) {
+	var s zoneSearch
+	z := s.loadDatabase(name)
+	return z, z != nil
 }
 
 // containsDotDot reports whether s contains "..".
@@ -0,0 +1,127 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "sync"
+
+var (
+	zoneLinksOnce sync.Once
+	zoneLinks     map[string]string // alias -> target
+)
+
+// CanonicalZoneName returns the current name of the zone called name,
+// which may be a deprecated alias: CanonicalZoneName("Asia/Calcutta")
+// is "Asia/Kolkata" and CanonicalZoneName("US/Eastern") is
+// "America/New_York". Programs can use it to normalize stored names.
+// 把废弃的时区别名转换成当前的规范名称
+//
+// Aliases are read once from the links in the tzdata.zi or backward
+// file of the first of the sources LoadLocation uses that has one.
+// If name is not an alias, or no source lists the links, as is the
+// case for $GOROOT/lib/time/zoneinfo.zip, name is returned unchanged.
+func CanonicalZoneName(name string) string {
+	zoneLinksOnce.Do(loadZoneLinks)
+	// Follow chains of links, but not forever.
+	for i := 0; i < 8; i++ {
+		target, ok := zoneLinks[name]
+		if !ok {
+			break
+		}
+		name = target
+	}
+	return name
+}
+
+// LoadCanonicalLocation is like LoadLocation, but if name is an alias
+// it returns the Location of the canonical zone, as reported by
+// CanonicalZoneName. The Location's String method returns the
+// canonical name and its Alias method returns name.
+// As with LoadLocation, "" and "UTC" return UTC and "Local" returns Local.
+func LoadCanonicalLocation(name string) (*Location, error) {
+	if name == "" || name == "UTC" || name == "Local" {
+		return LoadLocation(name)
+	}
+	canon := CanonicalZoneName(name)
+	l, err := LoadLocation(canon)
+	if err != nil || canon == name {
+		return l, err
+	}
+	// LoadLocation returns UTC itself for "UTC"; copy it.
+	c := l.clone()
+	c.alias = name
+	return c, nil
+}
+
+// Alias returns the name that was passed to LoadCanonicalLocation to
+// obtain l, if it was an alias of l's zone, and "" otherwise.
+func (l *Location) Alias() string {
+	return l.get().alias
+}
+
+// loadZoneLinks reads the table of aliases into zoneLinks.
+func loadZoneLinks() {
+	sources := append(zoneinfoDirs(), systemZoneSources()...)
+	for _, source := range sources {
+		if len(source) >= 6 && source[len(source)-6:] == "tzdata" {
+			// Android's tzdata file has no links.
+			continue
+		}
+		for _, file := range [...]string{"tzdata.zi", "backward"} {
+			data, err := loadTzinfoFromDirOrZip(source, file)
+			if err != nil {
+				continue
+			}
+			if links := parseZoneLinks(data); len(links) > 0 {
+				zoneLinks = links
+				return
+			}
+		}
+	}
+}
+
+// parseZoneLinks returns the links in data, zic input in which a link
+// is a line "Link TARGET LINK-NAME", or "L TARGET LINK-NAME" in the
+// compact form used by tzdata.zi.
+func parseZoneLinks(data []byte) map[string]string {
+	links := make(map[string]string)
+	for len(data) > 0 {
+		line := data
+		for i, c := range data {
+			if c == '\n' {
+				line = data[:i]
+				break
+			}
+		}
+		data = data[len(line):]
+		if len(data) > 0 {
+			data = data[1:]
+		}
+
+		// Split into fields, dropping any comment.
+		var f [3]string
+		n := 0
+		for len(line) > 0 && n <= len(f) {
+			for len(line) > 0 && (line[0] == ' ' || line[0] == '\t') {
+				line = line[1:]
+			}
+			if len(line) == 0 || line[0] == '#' {
+				break
+			}
+			i := 0
+			for i < len(line) && line[i] != ' ' && line[i] != '\t' && line[i] != '#' {
+				i++
+			}
+			if n < len(f) {
+				f[n] = string(line[:i])
+			}
+			n++
+			line = line[i:]
+		}
+		if n == 3 && (f[0] == "L" || f[0] == "Link") {
+			links[f[2]] = f[1]
+		}
+	}
+	return links
+}
@@ -0,0 +1,162 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Parse the "tzdata" packed timezone file used on Android.
+// The format is lifted from ZoneInfoDB.java and ZoneInfo.java in
+// java/libcore/util in the AOSP.
+// Android 把所有时区打包在一个 tzdata 文件里，而不是一个目录
+
+package time
+
+import (
+	"errors"
+	"runtime"
+	"syscall"
+)
+
+// Updated copies of tzdata come first: the time zone APEX module on
+// Android 10 and later, then the data installed by OTA updates, and
+// finally the copy in the system image.
+var zoneSources = []string{
+	"/apex/com.android.tzdata/etc/tz/tzdata",
+	"/data/misc/zoneinfo/current/tzdata",
+	"/system/usr/share/zoneinfo/tzdata",
+	runtime.GOROOT() + "/lib/time/zoneinfo.zip",
+}
+
+// readLocal returns the local time zone and a summary of the
+// settings it was read from; see initLocal.
+func readLocal() (*Location, string) {
+	// The system time zone is the persist.sys.timezone property,
+	// which cannot be read without cgo. Honor $TZ, which shells
+	// like the one in adb set from it, and fall back to UTC.
+	// 系统时区保存在 persist.sys.timezone 属性中，不使用 cgo 无法读取
+	tz, ok := syscall.Getenv("TZ")
+	if ok && tz != "" && tz[0] == ':' {
+		tz = tz[1:]
+	}
+	if ok && tz != "" && tz != "UTC" {
+		if z, ok := loadLocalZone(tz); ok {
+			return z, "TZ=" + tz
+		}
+		if z, ok := tzsetLocation(tz, tz); ok {
+			return z, "TZ=" + tz
+		}
+	}
+	return &Location{name: "UTC"}, "TZ=" + tz
+}
+
+func init() {
+	loadTzinfoFromTzdata = androidLoadTzinfoFromTzdata
+	listTzdata = androidListTzdata
+	tzdataVersion = androidTzdataVersion
+}
+
+const (
+	androidHeaderSize = 12 + 3*4
+	androidNameSize   = 40
+	androidEntrySize  = androidNameSize + 3*4
+)
+
+// androidTzdataIndex reads the index of the open tzdata file fd.
+// The index is a sequence of entries of androidEntrySize bytes:
+//	name[40] offset[4] length[4] rawOffset[4]
+// The offset of each zone's TZif data is relative to dataOff.
+func androidTzdataIndex(fd uintptr, file string) (index []byte, dataOff uint32, err error) {
+	// "tzdata" followed by the version, such as "2018e", and a NUL,
+	// then the offsets of the index, the data and zone.tab.
+	buf := make([]byte, androidHeaderSize)
+	if err := preadn(fd, buf, 0); err != nil {
+		return nil, 0, errors.New("corrupt tzdata file " + file)
+	}
+	d := dataIO{buf, false}
+	if magic := d.read(6); string(magic) != "tzdata" {
+		return nil, 0, errors.New("corrupt tzdata file " + file)
+	}
+	d = dataIO{buf[12:], false}
+	indexOff, _ := d.big4()
+	dataOff, _ = d.big4()
+	if dataOff < indexOff || dataOff-indexOff > maxFileSize {
+		return nil, 0, errors.New("corrupt tzdata file " + file)
+	}
+	indexSize := dataOff - indexOff
+	entrycount := indexSize / androidEntrySize
+	index = make([]byte, entrycount*androidEntrySize)
+	if err := preadn(fd, index, int(indexOff)); err != nil {
+		return nil, 0, errors.New("corrupt tzdata file " + file)
+	}
+	return index, dataOff, nil
+}
+
+func androidLoadTzinfoFromTzdata(file, name string) ([]byte, error) {
+	if len(name) > androidNameSize {
+		return nil, errors.New(name + " is longer than the maximum zone name length (40 bytes)")
+	}
+	fd, err := open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer closefd(fd)
+	index, dataOff, err := androidTzdataIndex(fd, file)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(index); i += androidEntrySize {
+		entry := index[i : i+androidEntrySize]
+		// len(name) <= androidNameSize is checked at function entry
+		if string(entry[:len(name)]) != name {
+			continue
+		}
+		if len(name) < androidNameSize && entry[len(name)] != 0 {
+			continue
+		}
+		d := dataIO{entry[androidNameSize:], false}
+		off, _ := d.big4()
+		size, _ := d.big4()
+		if size > maxFileSize {
+			return nil, errors.New("corrupt tzdata file " + file)
+		}
+		buf := make([]byte, size)
+		if err := preadn(fd, buf, int(off+dataOff)); err != nil {
+			return nil, errors.New("corrupt tzdata file " + file)
+		}
+		return buf, nil
+	}
+	return nil, errors.New("cannot find " + name + " in tzdata file " + file)
+}
+
+// androidListTzdata returns the names of the zones in a tzdata file.
+func androidListTzdata(file string) ([]string, error) {
+	fd, err := open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer closefd(fd)
+	index, _, err := androidTzdataIndex(fd, file)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(index)/androidEntrySize)
+	for i := 0; i < len(index); i += androidEntrySize {
+		if name := byteString(index[i : i+androidNameSize]); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// androidTzdataVersion returns the version in the header of the
+// tzdata file, such as "2018e".
+func androidTzdataVersion(file string) (string, error) {
+	fd, err := open(file)
+	if err != nil {
+		return "", err
+	}
+	defer closefd(fd)
+	buf := make([]byte, 12)
+	if err := preadn(fd, buf, 0); err != nil || string(buf[:6]) != "tzdata" {
+		return "", errors.New("corrupt tzdata file " + file)
+	}
+	return byteString(buf[6:]), nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "errors"
+
+// A LocationBuilder assembles a Location from explicit zones and
+// transitions, for programs such as tests and simulations that need
+// a synthetic time zone without writing a tzfile.
+// 不需要构造 tzfile 字节，直接用时区和转换时间拼出一个 Location
+//
+// Zones are added with AddZone and transitions between them with
+// AddTransition. Before the first transition, the Location is in
+// zone 0. After the last transition, it stays in the last zone, or
+// follows the POSIX TZ rule given to SetRule.
+type LocationBuilder struct {
+	name   string
+	zone   []zone
+	tx     []zoneTrans
+	extend string
+	err    error // first error from AddTransition
+}
+
+// NewLocationBuilder returns a LocationBuilder for a Location
+// with the given name.
+func NewLocationBuilder(name string) *LocationBuilder {
+	return &LocationBuilder{name: name}
+}
+
+// AddZone adds a zone with the given abbreviation, such as "CET",
+// and offset in seconds east of UTC, and returns its index for
+// use with AddTransition.
+func (b *LocationBuilder) AddZone(name string, offset int, isDST bool) int {
+	b.zone = append(b.zone, zone{name, offset, isDST})
+	return len(b.zone) - 1
+}
+
+// AddTransition records that the Location changes to the zone with
+// the given index at time when. Transitions must be added in order.
+func (b *LocationBuilder) AddTransition(when Time, zone int) {
+	if zone < 0 || zone >= len(b.zone) {
+		if b.err == nil {
+			b.err = errors.New("LocationBuilder.AddTransition: zone index out of range")
+		}
+		return
+	}
+	b.tx = append(b.tx, zoneTrans{when: when.Unix(), index: uint8(zone)})
+}
+
+// SetRule sets the POSIX TZ string, such as "CET-1CEST,M3.5.0,M10.5.0/3",
+// that describes the zones after the last transition.
+func (b *LocationBuilder) SetRule(tz string) {
+	b.extend = tz
+}
+
+// Build checks the zones and transitions and returns the Location
+// they describe. The LocationBuilder may be used again afterwards;
+// the Location does not share its tables.
+func (b *LocationBuilder) Build() (*Location, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.zone) == 0 {
+		return nil, errors.New("LocationBuilder.Build: no zones")
+	}
+	if len(b.zone) > 256 {
+		return nil, errors.New("LocationBuilder.Build: too many zones")
+	}
+
+	// 转换时间必须严格递增，lookup 的二分查找依赖这一点
+	for i := range b.tx {
+		if i > 0 && b.tx[i].when <= b.tx[i-1].when {
+			return nil, errors.New("LocationBuilder.Build: transitions out of order")
+		}
+	}
+	if b.extend != "" {
+		if _, _, _, _, _, ok := tzset(b.extend, alpha, 0); !ok {
+			return nil, errors.New("LocationBuilder.Build: invalid rule " + b.extend)
+		}
+	}
+
+	zones := make([]zone, len(b.zone))
+	copy(zones, b.zone)
+
+	// Start with a transition to zone 0 at the beginning of time,
+	// so that lookups before the first transition do not have to
+	// guess the zone as they do for tzfiles.
+	tx := make([]zoneTrans, 0, len(b.tx)+1)
+	if len(b.tx) == 0 || b.tx[0].when > alpha {
+		tx = append(tx, zoneTrans{when: alpha, index: 0})
+	}
+	tx = append(tx, b.tx...)
+
+	l := &Location{name: b.name, zone: zones, tx: tx, extend: b.extend}
+	l.resetCache()
+	return l, nil
+}
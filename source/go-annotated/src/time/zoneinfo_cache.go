@@ -0,0 +1,288 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// recentSize is the number of zone windows, besides the one for
+// the time the Location was created, that lookup remembers, unless
+// SetCacheOptions says otherwise.
+// 每个 Location 额外缓存的区间个数
+const recentSize = 8
+
+// cacheBucketShift divides times into buckets, those that share
+// their bits above it, about a year of them. Each bucket has one
+// entry of the cache, and consecutive buckets have consecutive ones.
+// 按时间分桶（约一年），每桶对应一个缓存条目
+const cacheBucketShift = 25 // 2^25 seconds, 388 days
+
+// extendCacheYears is how many years after the last transition of a
+// Location the windows of its extend string are tabulated for the
+// cache. Later times are computed on every lookup.
+const extendCacheYears = 100
+
+// A zoneWindow records the result of a lookup: the zone in effect
+// for all times in [start, end).
+type zoneWindow struct {
+	name       string
+	offset     int
+	isDST      bool
+	start, end int64
+}
+
+// A tableWindow is a window of the table of a zoneCache, with its
+// zone given by its index in Location.zone.
+type tableWindow struct {
+	start, end int64
+	zone       int32
+}
+
+// A zoneCache is the lookup cache of a Location.
+// Once stored in Location.cache, only its table and entries
+// change, with atomic operations; resetting the cache builds a new
+// zoneCache and swaps it in.
+// 缓存整体原子替换；区间条目逐个原子更新，命中和未命中都不分配内存
+type zoneCache struct {
+	// now is the window for the time the Location was created.
+	// It is never evicted. The zero window matches no time.
+	now zoneWindow
+
+	// table is nil or a *[]tableWindow holding, in order, every
+	// window of the Location from the start of time to
+	// extendCacheYears after its last transition, built on the first
+	// cache miss. It is never changed once stored.
+	table unsafe.Pointer
+
+	// entries holds, for each bucket, the window most recently found
+	// by searching table for a time in it, as its index in table plus
+	// one; 0 is an empty entry. Its length is a power of two, and
+	// buckets that many apart share an entry, the last one searched
+	// replacing the other.
+	entries []uint32
+
+	// off disables the cache: it holds no windows, not even now.
+	off bool
+
+	// stats, if not nil, counts the lookups that consult the cache.
+	// Like the size of entries and off, it is carried over when the
+	// cache is reset.
+	stats *cacheCounters
+}
+
+// cacheCounters are the counts reported by Location.CacheStats.
+type cacheCounters struct {
+	hits, misses, evictions uint64
+}
+
+// makeZoneCache returns an empty cache remembering size windows,
+// rounded up to a power of two.
+func makeZoneCache(size int) *zoneCache {
+	n := 0
+	if size > 0 {
+		n = 1
+		for n < size {
+			n *= 2
+		}
+	}
+	return &zoneCache{entries: make([]uint32, n)}
+}
+
+// newZoneCache returns an empty cache with the options of old, or
+// the default ones if old is nil.
+func newZoneCache(old *zoneCache) *zoneCache {
+	if old == nil {
+		return makeZoneCache(recentSize)
+	}
+	c := makeZoneCache(len(old.entries))
+	c.off, c.stats = old.off, old.stats
+	return c
+}
+
+// count counts a lookup that hit or missed c, if c counts them.
+func (c *zoneCache) count(hit bool) {
+	if c == nil || c.stats == nil {
+		return
+	}
+	if hit {
+		atomic.AddUint64(&c.stats.hits, 1)
+	} else {
+		atomic.AddUint64(&c.stats.misses, 1)
+	}
+}
+
+// loadCache returns the current lookup cache of l, or nil.
+func (l *Location) loadCache() *zoneCache {
+	return (*zoneCache)(atomic.LoadPointer(&l.cache))
+}
+
+// resetCache replaces the lookup cache of l with one holding only
+// the zone in effect right now, since that will be the most common
+// lookup. It must be called whenever the zone data of l changes.
+func (l *Location) resetCache() {
+	c := newZoneCache(l.loadCache())
+	if len(l.zone) > 0 && !c.off {
+		sec, _, _ := now()
+		if lz := l.lazy; lz == nil || lz.decoded() || sec >= lz.last.when {
+			// Otherwise leave the table to be decoded by the first lookup.
+			name, offset, isDST, start, end := l.lookupTx(sec)
+			c.now = zoneWindow{name, offset, isDST, start, end}
+		}
+	}
+	atomic.StorePointer(&l.cache, unsafe.Pointer(c))
+}
+
+// entry returns the entry of c for the bucket of sec.
+func (c *zoneCache) entry(sec int64) *uint32 {
+	return &c.entries[uint64(sec>>cacheBucketShift)&uint64(len(c.entries)-1)]
+}
+
+// find returns the window of c containing sec, or nil.
+func (c *zoneCache) find(l *Location, sec int64) (name string, offset int, isDST bool, start, end int64, ok bool) {
+	if c == nil || c.off {
+		return
+	}
+	if w := &c.now; w.start <= sec && sec < w.end {
+		return w.name, w.offset, w.isDST, w.start, w.end, true
+	}
+	table := (*[]tableWindow)(atomic.LoadPointer(&c.table))
+	if table == nil || len(c.entries) == 0 {
+		return
+	}
+	e := atomic.LoadUint32(c.entry(sec))
+	if e == 0 {
+		return
+	}
+	// A bucket spans a few windows at most: try the one remembered
+	// and, if sec is outside it, the next one in that direction.
+	ws := *table
+	i := int(e - 1)
+	w := &ws[i]
+	if sec < w.start {
+		if i == 0 {
+			return
+		}
+		w = &ws[i-1]
+	} else if sec >= w.end {
+		if i+1 == len(ws) {
+			return
+		}
+		w = &ws[i+1]
+	}
+	if sec < w.start || w.end <= sec {
+		return
+	}
+	z := &l.zone[w.zone]
+	return z.name, z.offset, z.isDST, w.start, w.end, true
+}
+
+// search looks up sec in the table of c, building it first if needed,
+// and remembers the window it finds, as lookup does for a time that
+// missed the cache. ok is false if the table has no window for sec,
+// and for a nil or disabled cache.
+func (c *zoneCache) search(l *Location, sec int64) (name string, offset int, isDST bool, start, end int64, ok bool) {
+	if c == nil || c.off || len(c.entries) == 0 {
+		return
+	}
+	table := (*[]tableWindow)(atomic.LoadPointer(&c.table))
+	if table == nil {
+		t := l.windowTable()
+		table = &t
+		if !atomic.CompareAndSwapPointer(&c.table, nil, unsafe.Pointer(table)) {
+			table = (*[]tableWindow)(atomic.LoadPointer(&c.table))
+		}
+	}
+
+	// Binary search for the window with the largest start <= sec.
+	ws := *table
+	lo, hi := 0, len(ws)
+	for hi-lo > 1 {
+		m := lo + (hi-lo)/2
+		if sec < ws[m].start {
+			hi = m
+		} else {
+			lo = m
+		}
+	}
+	w := &ws[lo]
+	if sec < w.start || w.end <= sec {
+		return
+	}
+	c.remember(sec, lo)
+	z := &l.zone[w.zone]
+	return z.name, z.offset, z.isDST, w.start, w.end, true
+}
+
+// remember stores window i of the table of c, containing sec, in the
+// entry of the bucket of sec.
+//
+// Concurrent calls may replace each other's windows; that only costs
+// a later search, never a wrong answer.
+// 并发更新时可能互相覆盖，不会返回错误的结果
+func (c *zoneCache) remember(sec int64, i int) {
+	e := c.entry(sec)
+	if old := atomic.SwapUint32(e, uint32(i+1)); old != 0 && old != uint32(i+1) && c.stats != nil {
+		atomic.AddUint64(&c.stats.evictions, 1)
+	}
+}
+
+// windowTable returns the windows of l in order: the one before the
+// first transition, those starting at each transition and, after the
+// last, the windows of the extend string for extendCacheYears, as far
+// as their zones are among those of l.
+func (l *Location) windowTable() []tableWindow {
+	tx := l.transitions()
+	ws := make([]tableWindow, 0, len(tx)+1)
+	end := int64(omega)
+	if len(tx) > 0 {
+		end = tx[0].when
+	}
+	ws = append(ws, tableWindow{alpha, end, int32(l.lookupFirstZone())})
+	for i := range tx {
+		end := int64(omega)
+		if i+1 < len(tx) {
+			end = tx[i+1].when
+		}
+		ws = append(ws, tableWindow{tx[i].when, end, int32(tx[i].index)})
+	}
+	if len(tx) == 0 || l.extend == "" {
+		return ws
+	}
+
+	// Replace the last window by those of the extend string, as
+	// lookupTx does, starting no earlier than the last transition.
+	last := tx[len(tx)-1].when
+	limit := last + extendCacheYears*365*secondsPerDay
+	ext := ws[:len(ws)-1]
+	for sec := last; sec < limit; {
+		name, offset, start, end, isDST, ok := tzset(l.extend, last, sec)
+		if !ok {
+			return ws
+		}
+		z := -1
+		for i := range l.zone {
+			if zz := &l.zone[i]; zz.name == name && zz.offset == offset && zz.isDST == isDST {
+				z = i
+				break
+			}
+		}
+		if z < 0 {
+			// Later times are left to lookupTx.
+			break
+		}
+		if start < sec {
+			start = sec
+		}
+		ext = append(ext, tableWindow{start, end, int32(z)})
+		sec = end
+	}
+	if len(ext) < len(ws) {
+		// Not even the first window of the extend string.
+		return ws
+	}
+	return ext
+}
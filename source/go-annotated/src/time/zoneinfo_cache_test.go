@@ -0,0 +1,160 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time_test
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"testing"
+	. "time"
+)
+
+// loadTestZone returns the zone name read from testdata/zoneinfo, so
+// that benchmarks do not depend on the database of the host. Each call
+// returns a new Location, whose cache options may be set freely.
+func loadTestZone(tb testing.TB, name string) *Location {
+	data, err := ioutil.ReadFile("testdata/zoneinfo/" + name)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	l, err := LoadLocationFromTZData(name, data)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return l
+}
+
+// spreadTimes returns n times in random order, drawn from the given
+// number of years starting in 1971, a few in each. With DST, each year
+// has two zone windows.
+func spreadTimes(n, years int) []Time {
+	r := rand.New(rand.NewSource(1))
+	ts := make([]Time, n)
+	for i := range ts {
+		year := 1971 + r.Intn(years)
+		ts[i] = Date(year, Month(1+r.Intn(12)), 1+r.Intn(28), r.Intn(24), 0, 0, 0, UTC)
+	}
+	return ts
+}
+
+var sinkOffset int
+
+// benchmarkLookup converts ts to l in turn and asks for the zone.
+func benchmarkLookup(b *testing.B, l *Location, ts []Time) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, off := ts[i%len(ts)].In(l).Zone()
+		sinkOffset += off
+	}
+}
+
+// BenchmarkLookupSpread measures the lookups of times spread over
+// a few years, which a cache of one window keeps missing, and over
+// sixty years, which no small cache holds, for several cache sizes.
+func BenchmarkLookupSpread(b *testing.B) {
+	for _, w := range []struct {
+		name  string
+		years int
+	}{
+		{"3years", 3},
+		{"60years", 60},
+	} {
+		ts := spreadTimes(1024, w.years)
+		for _, o := range []struct {
+			name string
+			opts CacheOptions
+		}{
+			{"Disabled", CacheOptions{Disabled: true}},
+			{"Size=1", CacheOptions{Size: 1}},
+			{"Size=8", CacheOptions{Size: 8}},
+			{"Size=64", CacheOptions{Size: 64}},
+		} {
+			b.Run(w.name+"/"+o.name, func(b *testing.B) {
+				l := loadTestZone(b, "America/New_York")
+				l.SetCacheOptions(o.opts)
+				benchmarkLookup(b, l, ts)
+			})
+		}
+	}
+}
+
+// missTimes returns times that take turns in three zone windows, so
+// that a cache of one window misses on every lookup.
+func missTimes() []Time {
+	return []Time{
+		Date(1975, March, 1, 0, 0, 0, 0, UTC),
+		Date(1990, July, 1, 0, 0, 0, 0, UTC),
+		Date(2005, November, 15, 0, 0, 0, 0, UTC),
+		// Before the first transition.
+		Date(1800, January, 1, 0, 0, 0, 0, UTC),
+		// After the last transition, from the extend string, and
+		// after the windows tabulated for it.
+		Date(2040, July, 1, 0, 0, 0, 0, UTC),
+		Date(2300, July, 1, 0, 0, 0, 0, UTC),
+	}
+}
+
+func TestLookupAllocs(t *testing.T) {
+	l := loadTestZone(t, "America/New_York")
+	l.SetCacheOptions(CacheOptions{Size: 1, Stats: true})
+	ts := missTimes()
+	i := 0
+	allocs := testing.AllocsPerRun(100, func() {
+		name, _ := ts[i%len(ts)].In(l).Zone()
+		i++
+		if name == "" {
+			t.Fatal("no zone name")
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("lookups missing the cache allocate %v times", allocs)
+	}
+	if s := l.CacheStats(); s.Misses == 0 {
+		t.Errorf("CacheStats = %+v, want misses", s)
+	}
+}
+
+func TestDebugLookupUncached(t *testing.T) {
+	l := loadTestZone(t, "America/New_York")
+	l.SetCacheOptions(CacheOptions{Stats: true})
+	for _, tt := range missTimes() {
+		name, offset, _, _, _ := DebugLookup(l, tt.Unix())
+		if wname, woffset := tt.In(l).Zone(); name != wname || offset != woffset {
+			t.Errorf("DebugLookup(%v) = %s %d, want %s %d", tt, name, offset, wname, woffset)
+		}
+	}
+	before := l.CacheStats()
+	for _, tt := range missTimes() {
+		DebugLookup(l, tt.Unix())
+	}
+	if after := l.CacheStats(); after != before {
+		t.Errorf("DebugLookup changed CacheStats from %+v to %+v", before, after)
+	}
+}
+
+// BenchmarkLookupMiss measures lookups that miss the cache every time,
+// with and without the counting of CacheStats.
+func BenchmarkLookupMiss(b *testing.B) {
+	for _, stats := range []bool{false, true} {
+		name := "NoStats"
+		if stats {
+			name = "Stats"
+		}
+		b.Run(name, func(b *testing.B) {
+			l := loadTestZone(b, "America/New_York")
+			l.SetCacheOptions(CacheOptions{Size: 1, Stats: stats})
+			benchmarkLookup(b, l, missTimes())
+		})
+	}
+}
+
+// BenchmarkZoneHit measures Time.Zone for times in one zone window,
+// which the cache answers.
+func BenchmarkZoneHit(b *testing.B) {
+	l := loadTestZone(b, "Europe/London")
+	t := Date(2018, July, 1, 12, 0, 0, 0, UTC)
+	benchmarkLookup(b, l, []Time{t, t.Add(Hour), t.Add(Minute)})
+}
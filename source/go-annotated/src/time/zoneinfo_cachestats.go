@@ -0,0 +1,107 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// CacheOptions tunes the cache a Location keeps of the zones its
+// lookups find, such as the zone in effect for the Hour of a Time.
+// Besides the zone in effect when the Location was loaded, the cache
+// remembers the zones of the most recent lookups outside it, which
+// suits programs that convert times near each other; programs that
+// convert times spread over history may want more, or none at all.
+// 调整 Location 的查询缓存：缓存大小、关闭缓存、统计命中率
+type CacheOptions struct {
+	// Size is how many recent zones the cache remembers, rounded up
+	// to a power of two. 0 means the default, 8; the most is 1024.
+	// Each is the zone last looked up for a bucket of about a year,
+	// buckets Size apart sharing one, so a lookup compares the time
+	// with two at most, whatever the Size.
+	Size int
+
+	// Disabled turns the cache off: every lookup searches the
+	// transitions of the Location.
+	Disabled bool
+
+	// Stats turns on the counting of hits, misses and evictions
+	// that CacheStats reports. Counting is off by default, as it
+	// slows lookups running concurrently on many goroutines.
+	Stats bool
+}
+
+// maxCacheSize is the largest CacheOptions.Size.
+const maxCacheSize = 1024
+
+// CacheStats counts the lookups of a Location, since CacheOptions.Stats
+// was turned on.
+type CacheStats struct {
+	Hits      uint64 // lookups answered by the cache
+	Misses    uint64 // lookups that searched the transitions
+	Evictions uint64 // zones dropped from a full cache to make room
+}
+
+// SetCacheOptions replaces the cache of l by an empty one with the
+// options o. The options of Local apply to the zone it has now, until
+// it is reloaded; see ReloadLocal. UTC and the Locations that
+// FixedZone shares between its callers, which have a single zone and
+// need no tuning, are left as they are.
+// 设置 Location 的缓存选项，FixedZone 共享的 Location 不受影响
+//
+// Turning Stats on when it is already on keeps the counts; turning
+// it off discards them.
+func (l *Location) SetCacheOptions(o CacheOptions) {
+	l = l.get()
+	if l == &utcLoc || l.shared {
+		// UTC needs no cache, and shared Locations must not change.
+		return
+	}
+	old := l.loadCache()
+	size := o.Size
+	if size <= 0 {
+		size = recentSize
+	}
+	if size > maxCacheSize {
+		size = maxCacheSize
+	}
+	c := makeZoneCache(size)
+	c.off = o.Disabled
+	if o.Stats {
+		if old != nil && old.stats != nil {
+			c.stats = old.stats
+		} else {
+			c.stats = new(cacheCounters)
+		}
+	}
+	// Let resetCache fill in the zone in effect now.
+	atomic.StorePointer(&l.cache, unsafe.Pointer(c))
+	l.resetCache()
+}
+
+// CacheOptions returns the options of the cache of l.
+func (l *Location) CacheOptions() CacheOptions {
+	c := l.get().loadCache()
+	if c == nil {
+		return CacheOptions{Size: recentSize}
+	}
+	return CacheOptions{Size: len(c.entries), Disabled: c.off, Stats: c.stats != nil}
+}
+
+// CacheStats returns the counts of the lookups of l, which are zero
+// unless CacheOptions.Stats is on. Times in UTC need no lookup and are
+// not counted.
+func (l *Location) CacheStats() CacheStats {
+	c := l.get().loadCache()
+	if c == nil || c.stats == nil {
+		return CacheStats{}
+	}
+	return CacheStats{
+		Hits:      atomic.LoadUint64(&c.stats.hits),
+		Misses:    atomic.LoadUint64(&c.stats.misses),
+		Evictions: atomic.LoadUint64(&c.stats.evictions),
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// Checksum returns a hash of the rules of l: what Equal compares,
+// namely its zones, its transitions, the rule for times after the
+// last transition and its leap seconds. Equal Locations have the same
+// checksum, whatever their names, in every process and on every
+// platform, so nodes of a distributed system can compare checksums
+// to find out whether they have the same data for a zone, and key
+// caches of computed times on them.
+// 时区规则的稳定哈希，可用于检测不同节点的 tzdata 是否一致
+//
+// The hash is 64-bit FNV-1a over a fixed encoding of the rules. It is
+// not cryptographic: it detects divergent data, not forged data.
+func (l *Location) Checksum() uint64 {
+	l = l.get()
+	h := uint64(fnvOffset)
+	h = fnvInt(h, int64(len(l.zone)))
+	for i := range l.zone {
+		z := &l.zone[i]
+		h = fnvString(h, z.name)
+		h = fnvInt(h, int64(z.offset))
+		h = fnvBool(h, z.isDST)
+	}
+	// As for Equal, the standard/wall and UTC/local indicators do not
+	// count.
+	tx := l.transitions()
+	h = fnvInt(h, int64(len(tx)))
+	for i := range tx {
+		h = fnvInt(h, tx[i].when)
+		h = fnvInt(h, int64(tx[i].index))
+	}
+	h = fnvString(h, l.extend)
+	h = fnvInt(h, int64(len(l.leap)))
+	for _, ls := range l.leap {
+		h = fnvInt(h, ls.when)
+		h = fnvInt(h, ls.corr)
+	}
+	return h
+}
+
+// fnvString adds s to h, preceded by its length so that consecutive
+// strings cannot run together.
+func fnvString(h uint64, s string) uint64 {
+	h = fnvInt(h, int64(len(s)))
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime
+	}
+	return h
+}
@@ -0,0 +1,155 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"errors"
+	"sync"
+)
+
+// A zoneDecompressor is a compression format registered with
+// RegisterZoneDecompressor.
+type zoneDecompressor struct {
+	suffix     string
+	magic      string
+	decompress func(data []byte) ([]byte, error)
+}
+
+var (
+	zoneDecompressorsMu sync.Mutex
+	zoneDecompressors   []*zoneDecompressor
+)
+
+// RegisterZoneDecompressor lets LoadLocation read time zone data
+// compressed in a format that package time cannot decompress itself,
+// so that embedded systems can ship a compressed database, a fraction
+// of the size of zoneinfo.zip. Package time/tzcompress registers gzip;
+// other formats, such as zstd, can be registered with a decompressor
+// from outside the standard library:
+//	time.RegisterZoneDecompressor(".zst", "\x28\xb5\x2f\xfd", decodeZstd)
+// 注册解压函数，使 LoadLocation 能读取压缩过的时区数据库
+//
+// A file named in ZONEINFO whose name ends in suffix, such as
+// "zoneinfo.zip.gz", is read whole and decompressed with decompress;
+// the result must be an uncompressed zip file, as zoneinfo.zip is. It
+// is decompressed once and kept in memory while the file is unchanged.
+// Zone data from a source registered with RegisterZoneSource that
+// begins with magic, the bytes that start every file in the format,
+// is decompressed before it is parsed. If suffix is empty, only such
+// data is decompressed.
+//
+// decompress may be called concurrently from multiple goroutines. It
+// should not return more than 10 MB, the most LoadLocation reads from
+// a file. RegisterZoneDecompressor panics if magic is empty or
+// decompress is nil. Formats registered later take precedence.
+func RegisterZoneDecompressor(suffix, magic string, decompress func(data []byte) ([]byte, error)) {
+	if magic == "" || decompress == nil {
+		panic("time: RegisterZoneDecompressor called with empty magic or nil decompress")
+	}
+	zoneDecompressorsMu.Lock()
+	defer zoneDecompressorsMu.Unlock()
+	// Copy so that readers holding the old slice are unaffected.
+	ds := make([]*zoneDecompressor, 0, len(zoneDecompressors)+1)
+	ds = append(ds, &zoneDecompressor{suffix, magic, decompress})
+	zoneDecompressors = append(ds, zoneDecompressors...)
+	ClearLocationCache()
+}
+
+func registeredZoneDecompressors() []*zoneDecompressor {
+	zoneDecompressorsMu.Lock()
+	defer zoneDecompressorsMu.Unlock()
+	return zoneDecompressors
+}
+
+// zoneDecompressorFor returns the format registered for files with
+// the name of file, or nil if there is none.
+func zoneDecompressorFor(file string) *zoneDecompressor {
+	for _, d := range registeredZoneDecompressors() {
+		if d.suffix != "" && len(file) > len(d.suffix) && file[len(file)-len(d.suffix):] == d.suffix {
+			return d
+		}
+	}
+	return nil
+}
+
+// isZipSource reports whether source names a zip file, either
+// uncompressed or compressed in a registered format.
+func isZipSource(source string) bool {
+	return len(source) > 4 && source[len(source)-4:] == ".zip" || zoneDecompressorFor(source) != nil
+}
+
+// decompressZoneData returns data decompressed, if it begins with the
+// magic of a registered format, and data itself otherwise.
+func decompressZoneData(data []byte) ([]byte, error) {
+	if len(data) >= 4 && string(data[:4]) == "TZif" {
+		return data, nil
+	}
+	for _, d := range registeredZoneDecompressors() {
+		if len(data) >= len(d.magic) && string(data[:len(d.magic)]) == d.magic {
+			return d.decompress(data)
+		}
+	}
+	return data, nil
+}
+
+// compressedTailSize is how much of the end of a compressed zip file
+// readCompressedZipDir compares, with its size, to tell whether the
+// file has changed since it was decompressed. Formats such as gzip end
+// with a checksum of the data.
+const compressedTailSize = 32
+
+// readCompressedZipDir is readZipDir for the open zip file fd, named
+// zipfile, compressed in the format d. The data of the directory is
+// the decompressed file.
+func readCompressedZipDir(fd uintptr, zipfile string, d *zoneDecompressor) (*zipDir, error) {
+	size, err := fileSize(fd)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxFileSize {
+		return nil, fileSizeError(zipfile)
+	}
+	buf := make([]byte, size)
+	n := len(buf)
+	if n > compressedTailSize {
+		n = compressedTailSize
+	}
+	if err := preadn(fd, buf[len(buf)-n:], -n); err != nil {
+		return nil, err
+	}
+	tail := string(appendInt(nil, int(size), 0)) + ":" + string(buf[len(buf)-n:])
+
+	zipDirs.Lock()
+	dir := zipDirs.m[zipfile]
+	zipDirs.Unlock()
+	if dir != nil && dir.tail == tail {
+		return dir, nil
+	}
+
+	if err := preadn(fd, buf, 0); err != nil {
+		return nil, err
+	}
+	data, err := d.decompress(buf)
+	if err != nil {
+		return nil, errors.New("cannot decompress " + zipfile + ": " + err.Error())
+	}
+	if len(data) > maxFileSize {
+		return nil, fileSizeError(zipfile)
+	}
+	if len(data) < ztailsize || get4(data[len(data)-ztailsize:]) != zecheader {
+		return nil, errors.New("corrupt zip file " + zipfile)
+	}
+	t := data[len(data)-ztailsize:]
+	count, dirSize, off := get2(t[10:]), get4(t[12:]), get4(t[16:])
+	if off < 0 || dirSize < 0 || off+dirSize > len(data) {
+		return nil, errors.New("corrupt zip file " + zipfile)
+	}
+	dir = &zipDir{tail: tail, names: make([]string, 0, count), files: make(map[string]zipEntry, count), data: data}
+	if err := dir.readEntries(data[off:off+dirSize], count, zipfile); err != nil {
+		return nil, err
+	}
+	cacheZipDir(zipfile, dir)
+	return dir, nil
+}
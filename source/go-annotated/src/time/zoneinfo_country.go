@@ -0,0 +1,133 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"errors"
+	"sync"
+)
+
+// The zone.tab file of the IANA Time Zone database lists, for each
+// country, the zones in use there, and iso3166.tab the names of the
+// countries. Unlike zone1970.tab, which ZoneMetadata reads, zone.tab
+// has a line for every country a zone serves, so that "MC" lists
+// "Europe/Monaco" rather than only "FR" listing "Europe/Paris".
+var countryTab struct {
+	once     sync.Once
+	zones    map[string][]string // by country code, in file order
+	zonesErr error
+	names    map[string]string
+	namesErr error
+}
+
+// ZonesForCountry returns the names of the zones in use in the
+// country with the given ISO 3166 alpha-2 code, such as "DE", as
+// listed in zone.tab: for "DE", "Europe/Berlin" and "Europe/Busingen".
+// Sign-up forms can use it to offer only the zones of the user's
+// country. The code may be in either case.
+// 返回某个国家使用的时区，注册表单可以只列出该国的时区供用户选择
+//
+// The table is read once, from the first of the sources LoadLocation
+// uses that has it.
+func ZonesForCountry(code string) ([]string, error) {
+	countryTab.once.Do(loadCountryTab)
+	if countryTab.zonesErr != nil {
+		return nil, countryTab.zonesErr
+	}
+	zones, ok := countryTab.zones[upperCountry(code)]
+	if !ok {
+		return nil, errors.New("time: no zones listed for country " + quote(code))
+	}
+	return append([]string(nil), zones...), nil
+}
+
+// CountryName returns the name of the country with the given ISO 3166
+// alpha-2 code, as listed in iso3166.tab, such as "Germany" for "DE".
+// The code may be in either case.
+func CountryName(code string) (string, error) {
+	countryTab.once.Do(loadCountryTab)
+	if countryTab.namesErr != nil {
+		return "", countryTab.namesErr
+	}
+	name, ok := countryTab.names[upperCountry(code)]
+	if !ok {
+		return "", errors.New("time: unknown country " + quote(code))
+	}
+	return name, nil
+}
+
+// upperCountry returns the country code s in upper case.
+func upperCountry(s string) string {
+	if len(s) != 2 {
+		return s
+	}
+	b := []byte(s)
+	for i, c := range b {
+		if 'a' <= c && c <= 'z' {
+			b[i] = c - ('a' - 'A')
+		}
+	}
+	return string(b)
+}
+
+// loadCountryTab reads and parses zone.tab and iso3166.tab into
+// countryTab.
+func loadCountryTab() {
+	if data, err := readZoneTabFile("zone.tab"); err != nil {
+		countryTab.zonesErr = err
+	} else {
+		countryTab.zones, countryTab.zonesErr = parseCountryZones(data)
+	}
+	if data, err := readZoneTabFile("iso3166.tab"); err != nil {
+		countryTab.namesErr = err
+	} else {
+		countryTab.names, countryTab.namesErr = parseCountryNames(data)
+	}
+}
+
+// parseCountryZones parses the contents of zone.tab. Each line that
+// is not a comment has tab-separated fields:
+//	code coordinates TZ [comments]
+func parseCountryZones(data []byte) (map[string][]string, error) {
+	zones := make(map[string][]string)
+	for len(data) > 0 {
+		var line []byte
+		line, data = cutByte(data, '\n')
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		var code, name []byte
+		code, line = cutByte(line, '\t')
+		_, line = cutByte(line, '\t')
+		name, _ = cutByte(line, '\t')
+		if len(code) != 2 || len(name) == 0 {
+			return nil, errors.New("time: malformed zone.tab")
+		}
+		zones[string(code)] = append(zones[string(code)], string(name))
+	}
+	return zones, nil
+}
+
+// parseCountryNames parses the contents of iso3166.tab. Each line
+// that is not a comment has tab-separated fields:
+//	code name
+func parseCountryNames(data []byte) (map[string]string, error) {
+	names := make(map[string]string)
+	for len(data) > 0 {
+		var line []byte
+		line, data = cutByte(data, '\n')
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		code, name := cutByte(line, '\t')
+		if len(code) != 2 || len(name) == 0 {
+			return nil, errors.New("time: malformed iso3166.tab")
+		}
+		names[string(code)] = string(name)
+	}
+	return names, nil
+}
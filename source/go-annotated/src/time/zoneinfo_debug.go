@@ -0,0 +1,66 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// The functions in this file are not called from package time.
+// Package time/timedebug reaches them through go:linkname so that
+// it can show what LoadLocation built without widening the API of
+// Location. Each one works on a copy of the data; the debugging
+// package never sees a pointer into a Location.
+// 供 time/timedebug 通过 go:linkname 调用，只读取数据，不暴露内部指针
+
+// debugZones calls f for every zone of l, in index order.
+func debugZones(l *Location, f func(name string, offset int, isDST bool)) {
+	l = l.get()
+	for i := range l.zone {
+		z := &l.zone[i]
+		f(z.name, z.offset, z.isDST)
+	}
+}
+
+// debugTransitions calls f for every transition of l, in time order.
+func debugTransitions(l *Location, f func(when int64, index int, isstd, isutc bool)) {
+	l = l.get()
+	txs := l.transitions()
+	for i := range txs {
+		tx := &txs[i]
+		f(tx.when, int(tx.index), tx.isstd, tx.isutc)
+	}
+}
+
+// debugCache reports the window of the lookup cache that holds the
+// zone in effect when l was created.
+// zone is the index of the cached zone, or -1 if the cache is empty.
+func debugCache(l *Location) (start, end int64, zone int) {
+	l = l.get()
+	zone = -1
+	c := l.loadCache()
+	if c == nil || c.now.start >= c.now.end {
+		return 0, 0, zone
+	}
+	for i := range l.zone {
+		if z := &l.zone[i]; z.name == c.now.name && z.offset == c.now.offset && z.isDST == c.now.isDST {
+			zone = i
+			break
+		}
+	}
+	return c.now.start, c.now.end, zone
+}
+
+// debugExtend returns the POSIX TZ rule used after the last transition of l.
+func debugExtend(l *Location) string {
+	return l.get().extend
+}
+
+// debugLookup is lookup, for callers outside the package. It leaves
+// the cache, CacheStats and the metrics as they were, so that looking
+// at a Location does not change what is seen.
+func debugLookup(l *Location, sec int64) (name string, offset int, isDST bool, start, end int64) {
+	l = l.get()
+	if len(l.zone) == 0 {
+		return "UTC", 0, false, alpha, omega
+	}
+	return l.lookupTx(sec)
+}
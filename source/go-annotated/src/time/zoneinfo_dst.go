@@ -0,0 +1,62 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "errors"
+
+// A DSTRule says when daylight saving time begins or ends each year,
+// in the "Mm.w.d/time" form of a POSIX TZ string: on the Week'th
+// Weekday of Month, at Time past midnight local time.
+// 夏令时规则，例如 "三月的第二个星期日 2:00"
+type DSTRule struct {
+	Month   Month
+	Week    int      // 1 to 5; 5 means the last Weekday of Month
+	Weekday Weekday  // 星期几
+	Time    Duration // wall clock time of the change, in whole seconds
+}
+
+// FixedZoneWithDST returns a Location that observes standard time with
+// the given name and offset (seconds east of UTC) except between the
+// start and end of daylight saving time each year, when it observes
+// daylight saving time with the given name and offset.
+// The start rule is in standard time and the end rule in daylight
+// saving time. If end comes before start in the year, as in the
+// southern hemisphere, daylight saving time spans the new year.
+// 与 FixedZone 不同，返回的 Location 每年按照规则切换夏令时
+//
+// The name of the Location is the equivalent POSIX TZ string, such as
+// "EST5EDT,M3.2.0,M11.1.0", which LoadLocation also accepts.
+func FixedZoneWithDST(stdName string, stdOffset int, dstName string, dstOffset int, start, end DSTRule) (*Location, error) {
+	b := make([]byte, 0, 48)
+	var err error
+	if b, err = appendFixedZone(b, &zone{stdName, stdOffset, false}); err != nil {
+		return nil, errors.New("time: invalid standard time zone " + stdName)
+	}
+	if b, err = appendFixedZone(b, &zone{dstName, dstOffset, true}); err != nil {
+		return nil, errors.New("time: invalid daylight saving time zone " + dstName)
+	}
+	for _, r := range [2]*DSTRule{&start, &end} {
+		if r.Month < January || r.Month > December || r.Week < 1 || r.Week > 5 ||
+			r.Weekday < Sunday || r.Weekday > Saturday || r.Time%Second != 0 ||
+			r.Time <= -168*Hour || r.Time >= 168*Hour {
+			return nil, errors.New("time: invalid daylight saving rule")
+		}
+		b = append(b, ",M"...)
+		b = appendInt(b, int(r.Month), 0)
+		b = append(b, '.')
+		b = appendInt(b, r.Week, 0)
+		b = append(b, '.')
+		b = appendInt(b, int(r.Weekday), 0)
+		b = append(b, '/')
+		b = appendTZOffset(b, int(r.Time/Second))
+	}
+
+	s := string(b)
+	l, ok := tzsetLocation(s, s)
+	if !ok {
+		return nil, errors.New("time: invalid daylight saving rule")
+	}
+	return l, nil
+}
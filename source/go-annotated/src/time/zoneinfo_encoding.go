@@ -0,0 +1,444 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+const (
+	locationBinaryVersionV1 byte = iota + 1
+	locationBinaryVersionV2      // adds the leap second table
+)
+
+// Flags stored with each transition in the binary encoding.
+const (
+	txFlagStd byte = 1 << iota
+	txFlagUTC
+)
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// 将完整的时区表和转换表编码，可以放进缓存或者在进程之间传递，
+// 解码时不需要再读取 tzfile
+//
+// The encoding records the name, the zones, the transitions,
+// the POSIX TZ rule used for times after the last transition and
+// the leap second table.
+// Marshaling Local records the current contents of the local
+// time zone under the name "Local".
+func (l *Location) MarshalBinary() ([]byte, error) {
+	l = l.get()
+	txs := l.transitions()
+
+	if len(l.name) > 0xffff || len(l.extend) > 0xffff {
+		return nil, errors.New("Location.MarshalBinary: name too long")
+	}
+	if len(l.zone) > 0xffff || uint64(len(txs)) > 0xffffffff || uint64(len(l.leap)) > 0xffffffff {
+		return nil, errors.New("Location.MarshalBinary: too many zones")
+	}
+
+	n := /*version*/ 1 + /*name*/ 2 + len(l.name) + /*extend*/ 2 + len(l.extend) +
+		/*zone count*/ 2 + /*tx count*/ 4 + len(txs)*(8+1+1) +
+		/*leap count*/ 4 + len(l.leap)*(8+4)
+	for i := range l.zone {
+		n += 1 + len(l.zone[i].name) + 4 + 1
+	}
+
+	enc := make([]byte, 0, n)
+	enc = append(enc, locationBinaryVersionV2)
+	enc = appendString16(enc, l.name)
+	enc = appendString16(enc, l.extend)
+
+	enc = append(enc, byte(len(l.zone)>>8), byte(len(l.zone)))
+	for i := range l.zone {
+		z := &l.zone[i]
+		if len(z.name) > 0xff {
+			return nil, errors.New("Location.MarshalBinary: zone name too long")
+		}
+		enc = append(enc, byte(len(z.name)))
+		enc = append(enc, z.name...)
+		off := int32(z.offset)
+		enc = append(enc, byte(off>>24), byte(off>>16), byte(off>>8), byte(off))
+		if z.isDST {
+			enc = append(enc, 1)
+		} else {
+			enc = append(enc, 0)
+		}
+	}
+
+	nt := uint32(len(txs))
+	enc = append(enc, byte(nt>>24), byte(nt>>16), byte(nt>>8), byte(nt))
+	for i := range txs {
+		tx := &txs[i]
+		w := tx.when
+		enc = append(enc,
+			byte(w>>56), byte(w>>48), byte(w>>40), byte(w>>32),
+			byte(w>>24), byte(w>>16), byte(w>>8), byte(w))
+		var flags byte
+		if tx.isstd {
+			flags |= txFlagStd
+		}
+		if tx.isutc {
+			flags |= txFlagUTC
+		}
+		enc = append(enc, tx.index, flags)
+	}
+
+	nl := uint32(len(l.leap))
+	enc = append(enc, byte(nl>>24), byte(nl>>16), byte(nl>>8), byte(nl))
+	for i := range l.leap {
+		w, c := l.leap[i].when, int32(l.leap[i].corr)
+		enc = append(enc,
+			byte(w>>56), byte(w>>48), byte(w>>40), byte(w>>32),
+			byte(w>>24), byte(w>>16), byte(w>>8), byte(w),
+			byte(c>>24), byte(c>>16), byte(c>>8), byte(c))
+	}
+
+	return enc, nil
+}
+
+// appendString16 appends s to b preceded by its length as a
+// big-endian 16-bit value.
+func appendString16(b []byte, s string) []byte {
+	b = append(b, byte(len(s)>>8), byte(len(s)))
+	return append(b, s...)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// 从 MarshalBinary 的结果重建 Location
+//
+// UnmarshalBinary refuses to overwrite UTC and Local, which are
+// shared by every Time in the program, and the Locations that
+// FixedZone shares between its callers.
+func (l *Location) UnmarshalBinary(data []byte) error {
+	if l == nil || l == &utcLoc || l == &localLoc {
+		return errors.New("Location.UnmarshalBinary: cannot overwrite UTC or Local")
+	}
+	if l.shared {
+		return errors.New("Location.UnmarshalBinary: cannot overwrite a shared Location")
+	}
+	if len(data) == 0 {
+		return errors.New("Location.UnmarshalBinary: no data")
+	}
+	version := data[0]
+	if version != locationBinaryVersionV1 && version != locationBinaryVersionV2 {
+		return errors.New("Location.UnmarshalBinary: unsupported version")
+	}
+
+	d := dataIO{data[1:], false}
+	name := d.string16()
+	extend := d.string16()
+
+	nz := d.big2()
+	zones := make([]zone, nz)
+	for i := range zones {
+		nn, _ := d.byte()
+		zones[i].name = string(d.read(int(nn)))
+		off, _ := d.big4()
+		zones[i].offset = int(int32(off))
+		dst, _ := d.byte()
+		zones[i].isDST = dst != 0
+	}
+
+	nt, _ := d.big4()
+	if d.error || uint64(nt) > uint64(len(d.p))/(8+1+1) {
+		return errors.New("Location.UnmarshalBinary: invalid length")
+	}
+	tx := make([]zoneTrans, nt)
+	for i := range tx {
+		w, _ := d.big8()
+		tx[i].when = int64(w)
+		tx[i].index, _ = d.byte()
+		flags, _ := d.byte()
+		tx[i].isstd = flags&txFlagStd != 0
+		tx[i].isutc = flags&txFlagUTC != 0
+	}
+
+	var leap []leapSecond
+	if version >= locationBinaryVersionV2 {
+		nl, _ := d.big4()
+		if d.error || uint64(nl) > uint64(len(d.p))/(8+4) {
+			return errors.New("Location.UnmarshalBinary: invalid length")
+		}
+		if nl > 0 {
+			leap = make([]leapSecond, nl)
+		}
+		for i := range leap {
+			w, _ := d.big8()
+			c, _ := d.big4()
+			leap[i] = leapSecond{int64(w), int64(int32(c))}
+			if i > 0 && leap[i].when <= leap[i-1].when {
+				return errors.New("Location.UnmarshalBinary: leap seconds out of order")
+			}
+		}
+	}
+
+	if d.error || len(d.p) != 0 {
+		return errors.New("Location.UnmarshalBinary: invalid length")
+	}
+
+	// 校验数据，避免 lookup 时越界
+	for i := range tx {
+		if int(tx[i].index) >= len(zones) {
+			return errors.New("Location.UnmarshalBinary: invalid zone index")
+		}
+		if i > 0 && tx[i].when < tx[i-1].when {
+			return errors.New("Location.UnmarshalBinary: transitions out of order")
+		}
+	}
+	if extend != "" {
+		if _, _, _, _, _, ok := tzset(extend, alpha, 0); !ok {
+			return errors.New("Location.UnmarshalBinary: invalid extend rule")
+		}
+	}
+
+	*l = Location{name: name, zone: zones, tx: tx, extend: extend, leap: leap}
+	l.resetCache()
+
+	return nil
+}
+
+// big2 reads a big-endian 16-bit value.
+func (d *dataIO) big2() int {
+	p := d.read(2)
+	if len(p) < 2 {
+		return 0
+	}
+	return int(p[0])<<8 | int(p[1])
+}
+
+// string16 reads a string preceded by its length as a
+// big-endian 16-bit value.
+func (d *dataIO) string16() string {
+	return string(d.read(d.big2()))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// 按照时区名编码，例如 "America/New_York"
+//
+// The Location is encoded as the quoted name that LoadLocation
+// accepts. A Location created by FixedZone has no such name and is
+// encoded as a POSIX TZ string giving its abbreviation and offset,
+// such as "IST-5:30" or "<+0530>-5:30".
+func (l *Location) MarshalJSON() ([]byte, error) {
+	l = l.get()
+	b := make([]byte, 0, len(l.name)+16)
+	b = append(b, '"')
+	if z, ok := l.fixedZone(); ok && !(z.name == "UTC" && z.offset == 0) {
+		var err error
+		if b, err = appendFixedZone(b, z); err != nil {
+			return nil, err
+		}
+	} else if len(l.zone) == 0 {
+		b = append(b, "UTC"...)
+	} else {
+		b = appendQuoted(b, l.name)
+	}
+	b = append(b, '"')
+	return b, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The Location is expected to be a quoted string accepted by
+// LoadLocation, or a fixed zone as written by MarshalJSON.
+func (l *Location) UnmarshalJSON(data []byte) error {
+	// Ignore null, like in the main JSON package.
+	if string(data) == "null" {
+		return nil
+	}
+	if l == nil || l == &utcLoc || l == &localLoc {
+		return errors.New("Location.UnmarshalJSON: cannot overwrite UTC or Local")
+	}
+	if l.shared {
+		return errors.New("Location.UnmarshalJSON: cannot overwrite a shared Location")
+	}
+	name, ok := unquote(data)
+	if !ok {
+		return errors.New("Location.UnmarshalJSON: input is not a JSON string")
+	}
+
+	// 固定时区直接重建，保留原来的缩写
+	if abbrev, offset, ok := parseFixedZone(name); ok {
+		*l = *fixedZone(abbrev, offset)
+		return nil
+	}
+
+	loc, err := LoadLocation(name)
+	if err != nil {
+		return err
+	}
+	// Copy field by field: loc may be Local, whose cache
+	// other goroutines can be replacing.
+	src := loc.get()
+	*l = Location{
+		name:   src.name,
+		zone:   src.zone,
+		tx:     src.tx,
+		lazy:   src.lazy,
+		extend: src.extend,
+		leap:   src.leap,
+		cache:  atomic.LoadPointer(&src.cache),
+	}
+	return nil
+}
+
+// fixedZone reports whether l was created by FixedZone,
+// and if so returns its only zone.
+func (l *Location) fixedZone() (*zone, bool) {
+	if len(l.zone) != 1 || l.extend != "" || l.lazy != nil || len(l.tx) > 1 || l.zone[0].name != l.name {
+		return nil, false
+	}
+	if len(l.tx) == 1 && (l.tx[0].when != alpha || l.tx[0].index != 0) {
+		return nil, false
+	}
+	return &l.zone[0], true
+}
+
+// appendFixedZone appends the POSIX TZ string for z to b.
+// The name is written bare when tzsetName would accept it,
+// and between angle brackets otherwise.
+func appendFixedZone(b []byte, z *zone) ([]byte, error) {
+	if !isAlphaName(z.name) {
+		for i := 0; i < len(z.name); i++ {
+			if c := z.name[i]; c == '>' || c == '"' || c == '\\' || c < ' ' {
+				return nil, errors.New("Location.MarshalJSON: invalid zone name")
+			}
+		}
+	}
+
+	// POSIX 的偏移量方向与 Go 相反，正数表示 UTC 以西
+	off := -z.offset
+	if off <= -24*7*secondsPerHour || off >= 24*7*secondsPerHour {
+		return nil, errors.New("Location.MarshalJSON: zone offset out of range")
+	}
+
+	if isAlphaName(z.name) {
+		b = append(b, z.name...)
+	} else {
+		b = append(b, '<')
+		b = append(b, z.name...)
+		b = append(b, '>')
+	}
+	return appendTZOffset(b, off), nil
+}
+
+// appendTZOffset appends a number of seconds to b in the
+// [-]hh[:mm[:ss]] form used by POSIX TZ strings.
+func appendTZOffset(b []byte, off int) []byte {
+	if off < 0 {
+		b = append(b, '-')
+		off = -off
+	}
+	b = appendInt(b, off/secondsPerHour, 0)
+	if off%secondsPerHour != 0 {
+		b = append(b, ':')
+		b = appendInt(b, off/secondsPerMinute%60, 2)
+		if off%secondsPerMinute != 0 {
+			b = append(b, ':')
+			b = appendInt(b, off%60, 2)
+		}
+	}
+	return b
+}
+
+// parseFixedZone parses a POSIX TZ string with a single zone and
+// no daylight saving time, such as the ones written by appendFixedZone.
+// It returns the zone name and its offset in seconds east of UTC.
+func parseFixedZone(s string) (name string, offset int, ok bool) {
+	name, rest, ok := tzsetName(s)
+	if !ok || (s[0] != '<' && !isAlphaName(name)) {
+		return "", 0, false
+	}
+	off, rest, ok := tzsetOffset(rest)
+	if !ok || rest != "" {
+		return "", 0, false
+	}
+	return name, -off, true
+}
+
+// isAlphaName reports whether s is a zone name that may appear
+// in a POSIX TZ string without angle brackets.
+func isAlphaName(s string) bool {
+	if len(s) < 3 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i] | 0x20; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// appendQuoted appends s to b, escaping the characters that
+// may not appear unescaped in a JSON string.
+func appendQuoted(b []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"' || c == '\\':
+			b = append(b, '\\', c)
+		case c < ' ':
+			b = append(b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+		default:
+			b = append(b, c)
+		}
+	}
+	return b
+}
+
+// unquote returns the contents of the JSON string literal data.
+// Only the escapes that can appear in a time zone name, which is
+// always ASCII, are supported; encoding/json escapes the angle
+// brackets of a fixed zone as \u003c and \u003e.
+// Duplicates functionality in encoding/json, but avoids dependency.
+func unquote(data []byte) (string, bool) {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return "", false
+	}
+	data = data[1 : len(data)-1]
+	b := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if c == '"' || c < ' ' {
+			return "", false
+		}
+		if c != '\\' {
+			b = append(b, c)
+			continue
+		}
+		if i++; i == len(data) {
+			return "", false
+		}
+		switch data[i] {
+		case '"', '\\', '/':
+			b = append(b, data[i])
+		case 'u':
+			if i+4 >= len(data) {
+				return "", false
+			}
+			var r int
+			for _, h := range data[i+1 : i+5] {
+				switch {
+				case '0' <= h && h <= '9':
+					r = r<<4 | int(h-'0')
+				case 'a' <= h|0x20 && h|0x20 <= 'f':
+					r = r<<4 | int(h|0x20-'a'+10)
+				default:
+					return "", false
+				}
+			}
+			if r >= 0x80 {
+				return "", false
+			}
+			b = append(b, byte(r))
+			i += 4
+		default:
+			return "", false
+		}
+	}
+	return string(b), true
+}
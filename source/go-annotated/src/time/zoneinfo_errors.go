@@ -0,0 +1,128 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"errors"
+	"syscall"
+)
+
+// Errors reported by LoadLocation, wrapped in a *LoadError.
+// 通过 errors.Is 区分"没有这个时区"和"本机没有时区数据库"
+var (
+	// ErrUnknownZone means that a time zone database was found
+	// but it has no zone with the requested name.
+	ErrUnknownZone = errors.New("unknown time zone")
+
+	// ErrNoTZData means that no time zone database could be found,
+	// as on systems without one installed.
+	ErrNoTZData = errors.New("no time zone database found")
+
+	// ErrCorruptTZData means that the data for the zone was found
+	// but is not a valid IANA Time Zone database file.
+	// LoadLocationFromTZData returns it unwrapped.
+	ErrCorruptTZData = errors.New("malformed time zone information")
+)
+
+// A LoadError records why LoadLocation could not load a zone.
+type LoadError struct {
+	Name   string   // zone name passed to LoadLocation
+	Source string   // source that reported Err, if any
+	Tried  []string // sources consulted, in order
+	Err    error
+
+	// Suggestions holds the known zone names closest to Name,
+	// closest first, when Err is ErrUnknownZone.
+	Suggestions []string
+}
+
+func (e *LoadError) Error() string {
+	switch {
+	case e.Source != "":
+		return "time: loading " + e.Name + " from " + e.Source + ": " + e.Err.Error()
+	case e.Err == ErrUnknownZone && len(e.Suggestions) > 0:
+		// As in `unknown time zone Foo/Bar (did you mean "Foo/Baz"?)`.
+		s := "time: " + e.Err.Error() + " " + e.Name + " (did you mean "
+		for i, z := range e.Suggestions {
+			if i > 0 {
+				s += " or "
+			}
+			s += `"` + z + `"`
+		}
+		return s + "?)"
+	case e.Err == ErrUnknownZone || e.Err == errLocation:
+		// As in "unknown time zone Foo/Bar".
+		return "time: " + e.Err.Error() + " " + e.Name
+	}
+	return "time: loading " + e.Name + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error, such as ErrUnknownZone.
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// Labels used as the source of zones that are not read from files.
+const (
+	zoneSourceLabel   = "ZoneSource"  // sources added by RegisterZoneSource
+	embeddedZoneLabel = "time/tzdata" // the database embedded by time/tzdata
+)
+
+// A zoneSearch collects the outcome of looking for a zone in
+// several sources, to build the error if none of them has it.
+type zoneSearch struct {
+	err   LoadError
+	found bool // some source has a database, even if not the zone
+}
+
+// fail records that source could not provide the zone.
+func (s *zoneSearch) fail(source string, err error) {
+	s.err.Tried = append(s.err.Tried, source)
+	switch err {
+	case ErrUnknownZone:
+		s.found = true
+		return
+	case syscall.ENOENT, syscall.ENOTDIR, syscall.EISDIR:
+		// Not found; but was there a database to look in?
+		if source != zoneSourceLabel && source != embeddedZoneLabel {
+			if fd, err := open(source); err == nil {
+				closefd(fd)
+				s.found = true
+			}
+		} else {
+			s.found = true
+		}
+		return
+	}
+	if s.err.Err == nil {
+		s.err.Source = source
+		s.err.Err = err
+	}
+}
+
+// error returns the error for a search in which every source failed.
+func (s *zoneSearch) error(name string) *LoadError {
+	e := s.err
+	e.Name = name
+	if e.Err == nil {
+		if s.found {
+			e.Err = ErrUnknownZone
+		} else {
+			e.Err = ErrNoTZData
+		}
+	}
+	return &e
+}
+
+// isLasting reports whether err, from loadLocationUncached, will be
+// the same next time unless the time zone database changes, so that
+// LoadLocation may remember it.
+func isLasting(err error) bool {
+	e, ok := err.(*LoadError)
+	if !ok {
+		return false
+	}
+	return e.Err == ErrUnknownZone || e.Err == ErrNoTZData || e.Err == ErrCorruptTZData
+}
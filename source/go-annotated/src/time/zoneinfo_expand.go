@@ -0,0 +1,87 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// ExpandRule returns a copy of l in which the transitions that the
+// POSIX TZ rule of l gives after its last transition, such as the
+// changes to and from daylight saving time of each year, are listed
+// explicitly up to the start of year toYear+1 in UTC. Slim zone data,
+// the default of zic since 2020, stops listing transitions once a
+// rule can describe them; the copy is the "fat" data that exporters
+// and readers that cannot evaluate rules need, as zic -b fat writes
+// it, for example through AppendTZif.
+// 把 POSIX TZ 规则展开成显式的转换记录，供不能解析规则的旧程序使用
+//
+// The copy keeps the rule for times after toYear, and reports the same
+// zones as l at every instant. If l has no rule, or no transitions for
+// the rule to follow, ExpandRule returns l.
+func (l *Location) ExpandRule(toYear int) *Location {
+	l = l.get()
+	tx := l.transitions()
+	if l.extend == "" || len(tx) == 0 {
+		return l
+	}
+	limit := Date(toYear+1, January, 1, 0, 0, 0, 0, UTC).Unix()
+
+	zones := make([]zone, len(l.zone))
+	copy(zones, l.zone)
+	zoneIndex := func(name string, offset int, isDST bool) int {
+		for i, z := range zones {
+			if z.name == name && z.offset == offset && z.isDST == isDST {
+				return i
+			}
+		}
+		if len(zones) == 256 {
+			return -1
+		}
+		zones = append(zones, zone{name, offset, isDST})
+		return len(zones) - 1
+	}
+
+	ntx := make([]zoneTrans, len(tx))
+	copy(ntx, tx)
+	// From the last transition on, lookup answers from the rule
+	// alone, even if it disagrees with the zone of that transition.
+	last := &ntx[len(ntx)-1]
+	initEnd := last.when
+	name, offset, _, end, isDST, ok := tzset(l.extend, initEnd, initEnd)
+	if !ok {
+		return l
+	}
+	cur := zoneIndex(name, offset, isDST)
+	if cur < 0 {
+		return l
+	}
+	last.index = uint8(cur)
+	for end < limit && end != omega {
+		sec := end
+		name, offset, _, end, isDST, ok = tzset(l.extend, initEnd, sec)
+		if !ok || end <= sec {
+			break
+		}
+		i := zoneIndex(name, offset, isDST)
+		if i < 0 {
+			break
+		}
+		if i != cur {
+			// The rule reports each year as a zone of its own;
+			// keep only the real changes.
+			ntx = append(ntx, zoneTrans{when: sec, index: uint8(i)})
+			cur = i
+		}
+	}
+
+	c := &Location{
+		name:   l.name,
+		zone:   zones,
+		tx:     ntx,
+		extend: l.extend,
+		leap:   l.leap,
+		alias:  l.alias,
+		source: l.source,
+	}
+	c.resetCache()
+	return c
+}
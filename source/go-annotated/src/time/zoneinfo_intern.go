@@ -0,0 +1,96 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "sync"
+
+// abbrevs interns the zone abbreviations read from tzfiles, such as
+// "CET" and "CEST", so that all Locations share one copy of each
+// rather than holding their own from every load.
+// 时区缩写字符串全局驻留，所有 Location 共用一份
+var abbrevs struct {
+	sync.RWMutex
+	m map[string]string
+}
+
+// maxAbbrevs bounds abbrevs against tzfiles with made-up names.
+// The IANA database uses a few hundred abbreviations.
+const maxAbbrevs = 4096
+
+// internAbbrev returns the abbreviation at the start of p, up to the
+// first NUL, as byteString does, but taken from abbrevs when seen
+// before, without allocating.
+func internAbbrev(p []byte) string {
+	for i := 0; i < len(p); i++ {
+		if p[i] == 0 {
+			p = p[:i]
+			break
+		}
+	}
+	abbrevs.RLock()
+	s, ok := abbrevs.m[string(p)]
+	abbrevs.RUnlock()
+	if ok {
+		return s
+	}
+
+	abbrevs.Lock()
+	defer abbrevs.Unlock()
+	if s, ok := abbrevs.m[string(p)]; ok {
+		return s
+	}
+	s = string(p)
+	if len(abbrevs.m) < maxAbbrevs {
+		if abbrevs.m == nil {
+			abbrevs.m = make(map[string]string)
+		}
+		abbrevs.m[s] = s
+	}
+	return s
+}
+
+// fixedZones interns the Locations returned by FixedZone, by name and
+// offset, as parsers call it for every time with a numeric offset.
+// 固定偏移的 Location 全局驻留，解析大量带偏移的时间时不再逐个分配
+var fixedZones struct {
+	sync.RWMutex
+	m map[fixedZoneKey]*Location
+}
+
+type fixedZoneKey struct {
+	name   string
+	offset int
+}
+
+// maxFixedZones bounds fixedZones against input with made-up names.
+// There are fewer than 3000 offsets of whole minutes in a day.
+const maxFixedZones = 4096
+
+// internFixedZone returns the Location FixedZone(name, offset), taken
+// from fixedZones when made before.
+func internFixedZone(name string, offset int) *Location {
+	k := fixedZoneKey{name, offset}
+	fixedZones.RLock()
+	l, ok := fixedZones.m[k]
+	fixedZones.RUnlock()
+	if ok {
+		return l
+	}
+
+	fixedZones.Lock()
+	defer fixedZones.Unlock()
+	if l, ok := fixedZones.m[k]; ok {
+		return l
+	}
+	l = fixedZone(name, offset)
+	if len(fixedZones.m) < maxFixedZones {
+		if fixedZones.m == nil {
+			fixedZones.m = make(map[fixedZoneKey]*Location)
+		}
+		l.shared = true
+		fixedZones.m[k] = l
+	}
+	return l
+}
@@ -0,0 +1,68 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time_test
+
+import (
+	"testing"
+	. "time"
+)
+
+func TestFixedZoneShared(t *testing.T) {
+	l := FixedZone("IST", 5*60*60+30*60)
+	if l2 := FixedZone("IST", 5*60*60+30*60); l2 != l {
+		t.Fatal("FixedZone did not intern the Location")
+	}
+	data, err := FixedZone("EST", -5*60*60).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.UnmarshalBinary(data); err == nil {
+		t.Error("UnmarshalBinary overwrote a shared Location")
+	}
+	if err := l.UnmarshalJSON([]byte(`"EST+5"`)); err == nil {
+		t.Error("UnmarshalJSON overwrote a shared Location")
+	}
+	l.SetCacheOptions(CacheOptions{Disabled: true, Stats: true})
+	if o := l.CacheOptions(); o.Disabled || o.Stats {
+		t.Errorf("SetCacheOptions changed a shared Location: %+v", o)
+	}
+	if name, off := Unix(0, 0).In(FixedZone("IST", 5*60*60+30*60)).Zone(); name != "IST" || off != 5*60*60+30*60 {
+		t.Errorf("FixedZone(IST, 5:30) gives zone %s %d", name, off)
+	}
+}
+
+func TestLoadLocationNotShared(t *testing.T) {
+	l1, err := LoadLocation("UTC+05:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l2, err := LoadLocation("UTC+05:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l1 == l2 {
+		t.Fatal("LoadLocation returned the same Location twice")
+	}
+	data, err := FixedZone("EST", -5*60*60).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l1.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	l1.SetCacheOptions(CacheOptions{Stats: true})
+	if o := l2.CacheOptions(); o.Stats {
+		t.Errorf("SetCacheOptions on one Location changed another: %+v", o)
+	}
+	l3, err := LoadLocation("UTC+05:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range []*Location{l2, l3} {
+		if _, off := Unix(0, 0).In(l).Zone(); off != 5*60*60+30*60 {
+			t.Errorf("after UnmarshalBinary of another Location, %v has offset %d", l, off)
+		}
+	}
+}
@@ -0,0 +1,528 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "errors"
+
+// Java's java.time.zone.ZoneRules describes a zone by its offsets
+// alone: the instants at which the standard offset changes, those at
+// which the wall offset changes, and the "last rules" that give the
+// transitions of each year after those. It has no abbreviations.
+// Its serialized form, used in Java object streams and in the tzdb.dat
+// file of the JDK, is a type byte, 1, followed by the data written by
+// ZoneRules.writeExternal:
+//	int32 n, n transition instants, n+1 standard offsets
+//	int32 m, m transition instants, m+1 wall offsets
+//	byte  k, k ZoneOffsetTransitionRules
+// Instants take 3 bytes if they are a quarter hour from 1825 to 2300,
+// and a 255 byte and an int64 otherwise; offsets take a byte counting
+// quarter hours, or a 127 byte and an int32 of seconds.
+// 与 Java ZoneRules 序列化格式互相转换，便于 JVM 系统交换自定义时区
+const javaZoneRules = 1
+
+// Bounds of the compact form of Java instants.
+const (
+	javaEpochSecMin = -4575744000 // 1825-01-01T00:00Z
+	javaEpochSecMax = 10413792000 // 2300-01-01T00:00Z
+)
+
+// A javaRule is a java.time.zone.ZoneOffsetTransitionRule. Its offsets
+// are in seconds east of UTC.
+type javaRule struct {
+	month  int // 1 to 12
+	dom    int // day of month, or from the end of the month if negative
+	dow    int // 1 for Monday to 7 for Sunday, or 0 for the day dom
+	time   int // seconds after midnight, 86400 for the end of the day
+	def    int // javaUTC, javaWall or javaStandard
+	std    int
+	before int
+	after  int
+}
+
+// Java's TimeDefinition, the clock a rule's time is read on.
+const (
+	javaUTC = iota
+	javaWall
+	javaStandard
+)
+
+// MarshalJavaZoneRules returns l in the serialized form of Java's
+// ZoneRules, for exchanging zone definitions with Java programs.
+// Zones are converted to their offsets: the standard offset of a zone
+// in daylight saving time is that of the nearest standard time zone
+// before it, or after it if there is none. The POSIX TZ rule of l, if
+// it has daylight saving time, becomes two last rules; it is an error
+// if Java cannot express it, as for rules on a day of the year after
+// February or at times that do not fit in a day in UTC or wall time.
+func (l *Location) MarshalJavaZoneRules() ([]byte, error) {
+	l = l.get()
+	tx := l.transitions()
+
+	// The zones in effect, starting with that of the beginning of time.
+	type period struct {
+		when      int64
+		wall, std int
+	}
+	first := l.lookupFirstZone()
+	if len(tx) > 0 && tx[0].when == alpha {
+		first = int(tx[0].index)
+		tx = tx[1:]
+	}
+	ps := make([]period, 0, len(tx)+1)
+	zs := make([]*zone, 0, len(tx)+1)
+	if len(l.zone) > 0 {
+		zs = append(zs, &l.zone[first])
+		ps = append(ps, period{alpha, l.zone[first].offset, 0})
+	} else {
+		zs = append(zs, &zone{"UTC", 0, false})
+		ps = append(ps, period{alpha, 0, 0})
+	}
+	for _, t := range tx {
+		z := &l.zone[t.index]
+		zs = append(zs, z)
+		ps = append(ps, period{t.when, z.offset, 0})
+	}
+	for i, z := range zs {
+		ps[i].std = z.offset
+		if !z.isDST {
+			continue
+		}
+		ps[i].std = z.offset - secondsPerHour
+		found := false
+		for j := i - 1; j >= 0 && !found; j-- {
+			if !zs[j].isDST {
+				ps[i].std, found = zs[j].offset, true
+			}
+		}
+		for j := i + 1; j < len(zs) && !found; j++ {
+			if !zs[j].isDST {
+				ps[i].std, found = zs[j].offset, true
+			}
+		}
+	}
+
+	// Java's ZoneOffset is limited to ±18 hours.
+	for _, p := range ps {
+		if p.wall < -18*secondsPerHour || p.wall > 18*secondsPerHour ||
+			p.std < -18*secondsPerHour || p.std > 18*secondsPerHour {
+			return nil, errors.New("time: zone offset out of range for Java")
+		}
+	}
+
+	var rules []javaRule
+	if l.extend != "" {
+		var err error
+		if rules, err = javaRulesFromTZ(l.extend); err != nil {
+			return nil, err
+		}
+	}
+
+	b := []byte{javaZoneRules}
+	for _, wall := range [2]bool{false, true} {
+		var whens []int64
+		var offsets []int
+		off := func(p period) int {
+			if wall {
+				return p.wall
+			}
+			return p.std
+		}
+		offsets = append(offsets, off(ps[0]))
+		for _, p := range ps[1:] {
+			if o := off(p); o != offsets[len(offsets)-1] {
+				whens = append(whens, p.when)
+				offsets = append(offsets, o)
+			}
+		}
+		b = appendJavaInt(b, uint32(len(whens)))
+		for _, when := range whens {
+			b = appendJavaEpochSec(b, when)
+		}
+		for _, o := range offsets {
+			b = appendJavaOffset(b, o)
+		}
+	}
+	b = append(b, byte(len(rules)))
+	for i := range rules {
+		b = appendJavaRule(b, &rules[i])
+	}
+	return b, nil
+}
+
+// javaRulesFromTZ returns the last rules equivalent to the POSIX TZ
+// string tz: none if it has no daylight saving time, and otherwise the
+// rules for the start and end of daylight saving time, in the order
+// they happen in the year.
+func javaRulesFromTZ(tz string) ([]javaRule, error) {
+	bad := errors.New("time: invalid POSIX TZ string " + quote(tz))
+	s := tz
+	_, s, ok := tzsetName(s)
+	if !ok {
+		return nil, bad
+	}
+	stdOffset, s, ok := tzsetOffset(s)
+	if !ok {
+		return nil, bad
+	}
+	stdOffset = -stdOffset
+	if s == "" || s[0] == ',' {
+		return nil, nil
+	}
+	if _, s, ok = tzsetName(s); !ok {
+		return nil, bad
+	}
+	dstOffset := stdOffset + secondsPerHour
+	if s != "" && s[0] != ',' {
+		if dstOffset, s, ok = tzsetOffset(s); !ok {
+			return nil, bad
+		}
+		dstOffset = -dstOffset
+	}
+	if s == "" {
+		// Default DST rules per tzcode.
+		s = ",M3.2.0,M11.1.0"
+	}
+
+	var rs [2]rule
+	for i := range rs {
+		if s == "" || s[0] != ',' {
+			return nil, bad
+		}
+		if rs[i], s, ok = tzsetRule(s[1:]); !ok {
+			return nil, bad
+		}
+	}
+	if s != "" {
+		return nil, bad
+	}
+
+	start := javaRule{std: stdOffset, before: stdOffset, after: dstOffset}
+	end := javaRule{std: stdOffset, before: dstOffset, after: stdOffset}
+	for i, jr := range [2]*javaRule{&start, &end} {
+		r := rs[i]
+		switch r.kind {
+		case ruleMonthWeekDay:
+			jr.month = r.mon
+			jr.dom = (r.week-1)*7 + 1
+			if r.week == 5 {
+				jr.dom = -1
+			}
+			jr.dow = r.day
+			if jr.dow == 0 {
+				jr.dow = 7
+			}
+		case ruleJulian, ruleDOY:
+			// Both count from 1 January; only Julian days skip
+			// 29 February, so days of the year are the same up to it.
+			day := r.day
+			if r.kind == ruleDOY {
+				if day >= 31+28 {
+					return nil, errors.New("time: Java rules cannot express the day of year of " + quote(tz))
+				}
+				day++
+			}
+			jr.month = 1
+			for day > daysIn(Month(jr.month), 1) {
+				day -= daysIn(Month(jr.month), 1)
+				jr.month++
+			}
+			jr.dom = day
+		}
+
+		// POSIX rule times are wall clock times before the change.
+		switch t := r.time; {
+		case 0 <= t && t <= secondsPerDay:
+			jr.time, jr.def = t, javaWall
+		case 0 <= t-jr.before && t-jr.before <= secondsPerDay:
+			jr.time, jr.def = t-jr.before, javaUTC
+		default:
+			return nil, errors.New("time: Java rules cannot express the transition time of " + quote(tz))
+		}
+	}
+	if end.month < start.month {
+		return []javaRule{end, start}, nil
+	}
+	return []javaRule{start, end}, nil
+}
+
+func appendJavaInt(b []byte, n uint32) []byte {
+	return append(b, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+}
+
+func appendJavaEpochSec(b []byte, sec int64) []byte {
+	if javaEpochSecMin <= sec && sec < javaEpochSecMax && sec%900 == 0 {
+		n := (sec - javaEpochSecMin) / 900
+		return append(b, byte(n>>16), byte(n>>8), byte(n))
+	}
+	b = append(b, 255)
+	b = appendJavaInt(b, uint32(uint64(sec)>>32))
+	return appendJavaInt(b, uint32(sec))
+}
+
+func appendJavaOffset(b []byte, offset int) []byte {
+	if offset%900 == 0 {
+		return append(b, byte(int8(offset/900)))
+	}
+	b = append(b, 127)
+	return appendJavaInt(b, uint32(int32(offset)))
+}
+
+// appendJavaRule appends r as ZoneOffsetTransitionRule.writeExternal
+// does.
+func appendJavaRule(b []byte, r *javaRule) []byte {
+	timeByte := 31
+	if r.time%secondsPerHour == 0 {
+		timeByte = r.time / secondsPerHour
+	}
+	stdByte := 255
+	if r.std%900 == 0 {
+		stdByte = r.std/900 + 128
+	}
+	diffByte := func(off int) int {
+		switch d := off - r.std; d {
+		case 0, 1800, 3600:
+			return d / 1800
+		}
+		return 3
+	}
+	beforeByte, afterByte := diffByte(r.before), diffByte(r.after)
+
+	n := uint32(r.month)<<28 | uint32(r.dom+32)<<22 | uint32(r.dow)<<19 |
+		uint32(timeByte)<<14 | uint32(r.def)<<12 | uint32(stdByte)<<4 |
+		uint32(beforeByte)<<2 | uint32(afterByte)
+	b = appendJavaInt(b, n)
+	if timeByte == 31 {
+		b = appendJavaInt(b, uint32(r.time))
+	}
+	if stdByte == 255 {
+		b = appendJavaInt(b, uint32(int32(r.std)))
+	}
+	if beforeByte == 3 {
+		b = appendJavaInt(b, uint32(int32(r.before)))
+	}
+	if afterByte == 3 {
+		b = appendJavaInt(b, uint32(int32(r.after)))
+	}
+	return b
+}
+
+// LoadLocationFromJavaZoneRules returns a Location with the given name
+// from data in the serialized form of Java's ZoneRules, as written by
+// MarshalJavaZoneRules. Java records no abbreviations: zones are named
+// after their offset, such as "+01", and are daylight saving time when
+// the wall offset differs from the standard one. The last rules must
+// be none, or two that a POSIX TZ string can express.
+func LoadLocationFromJavaZoneRules(name string, data []byte) (*Location, error) {
+	bad := errors.New("time: invalid Java ZoneRules data")
+	d := dataIO{data, false}
+	if kind, _ := d.byte(); kind != javaZoneRules {
+		return nil, bad
+	}
+
+	var whens [2][]int64
+	var offsets [2][]int
+	for i := range whens {
+		n, _ := d.big4()
+		if d.error || uint64(n) > uint64(len(d.p)) {
+			return nil, bad
+		}
+		whens[i] = make([]int64, n)
+		for j := range whens[i] {
+			whens[i][j] = readJavaEpochSec(&d)
+		}
+		offsets[i] = make([]int, n+1)
+		for j := range offsets[i] {
+			offsets[i][j] = readJavaOffset(&d)
+		}
+	}
+	nrules, _ := d.byte()
+	rules := make([]javaRule, nrules)
+	for i := range rules {
+		readJavaRule(&d, &rules[i])
+	}
+	if d.error || len(d.p) != 0 {
+		return nil, bad
+	}
+
+	// Walk both lists of transitions at once; each instant in either
+	// may start a new zone.
+	l := &Location{name: name, source: LocationSource{Kind: SourceData}}
+	zoneIndex := func(std, wall int) int {
+		z := zone{offsetAbbrev(wall), wall, wall != std}
+		for i := range l.zone {
+			if l.zone[i] == z {
+				return i
+			}
+		}
+		l.zone = append(l.zone, z)
+		return len(l.zone) - 1
+	}
+	si, wi := 0, 0
+	cur := zoneIndex(offsets[0][0], offsets[1][0])
+	l.tx = append(l.tx, zoneTrans{when: alpha, index: uint8(cur)})
+	for si < len(whens[0]) || wi < len(whens[1]) {
+		var when int64
+		switch {
+		case wi == len(whens[1]) || si < len(whens[0]) && whens[0][si] < whens[1][wi]:
+			when = whens[0][si]
+		default:
+			when = whens[1][wi]
+		}
+		if when <= l.tx[len(l.tx)-1].when {
+			return nil, bad
+		}
+		for si < len(whens[0]) && whens[0][si] == when {
+			si++
+		}
+		for wi < len(whens[1]) && whens[1][wi] == when {
+			wi++
+		}
+		if z := zoneIndex(offsets[0][si], offsets[1][wi]); z != cur {
+			if len(l.zone) > 256 {
+				return nil, errors.New("time: too many zones in Java ZoneRules data")
+			}
+			l.tx = append(l.tx, zoneTrans{when: when, index: uint8(z)})
+			cur = z
+		}
+	}
+
+	if len(rules) > 0 {
+		tz, err := tzFromJavaRules(rules)
+		if err != nil {
+			return nil, err
+		}
+		l.extend = tz
+	}
+	l.resetCache()
+	return l, nil
+}
+
+func readJavaEpochSec(d *dataIO) int64 {
+	hi, _ := d.byte()
+	if hi == 255 {
+		n, _ := d.big8()
+		return int64(n)
+	}
+	p := d.read(2)
+	if len(p) < 2 {
+		return 0
+	}
+	return (int64(hi)<<16|int64(p[0])<<8|int64(p[1]))*900 + javaEpochSecMin
+}
+
+func readJavaOffset(d *dataIO) int {
+	b, _ := d.byte()
+	if b == 127 {
+		n, _ := d.big4()
+		return int(int32(n))
+	}
+	return int(int8(b)) * 900
+}
+
+// readJavaRule reads a rule written by appendJavaRule into r.
+func readJavaRule(d *dataIO, r *javaRule) {
+	n, _ := d.big4()
+	r.month = int(n >> 28)
+	r.dom = int(n>>22&63) - 32
+	r.dow = int(n >> 19 & 7)
+	r.time = int(n>>14&31) * secondsPerHour
+	r.def = int(n >> 12 & 3)
+	stdByte := int(n >> 4 & 255)
+	beforeByte, afterByte := int(n>>2&3), int(n&3)
+	if n>>14&31 == 31 {
+		t, _ := d.big4()
+		r.time = int(int32(t))
+	}
+	r.std = (stdByte - 128) * 900
+	if stdByte == 255 {
+		std, _ := d.big4()
+		r.std = int(int32(std))
+	}
+	r.before = r.std + beforeByte*1800
+	if beforeByte == 3 {
+		before, _ := d.big4()
+		r.before = int(int32(before))
+	}
+	r.after = r.std + afterByte*1800
+	if afterByte == 3 {
+		after, _ := d.big4()
+		r.after = int(int32(after))
+	}
+}
+
+// tzFromJavaRules returns the POSIX TZ string equivalent to the two
+// last rules rs.
+func tzFromJavaRules(rs []javaRule) (string, error) {
+	unsupported := errors.New("time: cannot express Java last rules as a POSIX TZ string")
+	if len(rs) != 2 {
+		return "", unsupported
+	}
+	start, end := &rs[0], &rs[1]
+	if start.after == start.std {
+		start, end = end, start
+	}
+	std, dst := start.std, start.after
+	if end.std != std || start.before != std || end.before != dst || end.after != std || dst == std {
+		return "", unsupported
+	}
+
+	b, err := appendFixedZone(nil, &zone{offsetAbbrev(std), std, false})
+	if err != nil {
+		return "", unsupported
+	}
+	if b, err = appendFixedZone(b, &zone{offsetAbbrev(dst), dst, true}); err != nil {
+		return "", unsupported
+	}
+	for _, r := range [2]*javaRule{start, end} {
+		b = append(b, ',')
+		switch {
+		case r.dow != 0 && r.dom == -1:
+			b = append(b, 'M')
+			b = appendInt(b, r.month, 0)
+			b = append(b, ".5."...)
+			b = appendInt(b, r.dow%7, 0)
+		case r.dow != 0 && 1 <= r.dom && r.dom <= 22 && (r.dom-1)%7 == 0:
+			b = append(b, 'M')
+			b = appendInt(b, r.month, 0)
+			b = append(b, '.')
+			b = appendInt(b, (r.dom-1)/7+1, 0)
+			b = append(b, '.')
+			b = appendInt(b, r.dow%7, 0)
+		case r.dow == 0 && r.month >= 1 && r.month <= 12:
+			// A fixed date, as a Julian day, which skips 29 February.
+			dom := r.dom
+			if dom < 0 {
+				dom += daysIn(Month(r.month), 1) + 1
+			}
+			if dom < 1 || dom > daysIn(Month(r.month), 1) {
+				return "", unsupported
+			}
+			for m := 1; m < r.month; m++ {
+				dom += daysIn(Month(m), 1)
+			}
+			b = append(b, 'J')
+			b = appendInt(b, dom, 0)
+		default:
+			return "", unsupported
+		}
+
+		// POSIX rule times are wall clock times before the change.
+		t := r.time
+		switch r.def {
+		case javaUTC:
+			t += r.before
+		case javaStandard:
+			t += r.before - r.std
+		}
+		b = append(b, '/')
+		b = appendTZOffset(b, t)
+	}
+
+	s := string(b)
+	if _, _, _, _, _, ok := tzset(s, alpha, 0); !ok {
+		return "", unsupported
+	}
+	return s, nil
+}
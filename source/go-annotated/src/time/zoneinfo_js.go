@@ -0,0 +1,112 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build js,wasm
+
+package time
+
+import (
+	"runtime"
+	"syscall"
+	"syscall/js"
+)
+
+// These are only found when running under Node.js; browsers have
+// no file system, so zones come from RegisterZoneSource or the
+// copy embedded by time/tzdata.
+var zoneSources = []string{
+	"/usr/share/zoneinfo/",
+	"/usr/share/lib/zoneinfo/",
+	"/usr/lib/locale/TZ/",
+	runtime.GOROOT() + "/lib/time/zoneinfo.zip",
+}
+
+// readLocal asks the host for the name of its time zone, such as
+// "Europe/Paris", and loads it from the databases LoadLocation uses,
+// including the sources added by RegisterZoneSource. Sources registered
+// from an init function are in place before Local is first used.
+// It returns the zone and a summary of the settings it was read from;
+// see initLocal.
+// 浏览器中通过 Intl 获取本地时区名，再从注册的数据源加载时区数据
+//
+// If the zone cannot be loaded, Local uses the current offset of
+// the host, without daylight saving time changes.
+func readLocal() (*Location, string) {
+	if tz, ok := syscall.Getenv("TZ"); ok {
+		// Node.js passes the environment through.
+		state := "TZ=" + tz
+		if tz != "" && tz[0] == ':' {
+			tz = tz[1:]
+		}
+		if tz == "" || tz == "UTC" {
+			return &Location{name: "UTC"}, state
+		}
+		if z, ok := loadLocal(tz); ok {
+			return z, state
+		}
+		if z, ok := tzsetLocation(tz, tz); ok {
+			return z, state
+		}
+	}
+
+	if name := jsZoneName(); name != "" {
+		if z, ok := loadLocal(name); ok {
+			return z, "host:" + name
+		}
+	}
+
+	// Fall back to the current offset of the host.
+	z := zone{}
+	d := js.Global().Get("Date").New()
+	offset := d.Call("getTimezoneOffset").Int() * -1
+	z.offset = offset * 60
+	// 没有时区名，按偏移量命名，例如 UTC+8 或 UTC-3:30
+	b := []byte("UTC+")
+	if offset < 0 {
+		b[3] = '-'
+		offset = -offset
+	}
+	b = appendInt(b, offset/60, 0)
+	if min := offset % 60; min != 0 {
+		b = append(b, ':')
+		b = appendInt(b, min, 2)
+	}
+	z.name = string(b)
+	l := &Location{name: "Local", zone: []zone{z}, tx: []zoneTrans{{alpha, 0, false, false}}}
+	l.resetCache()
+	return l, "host:" + z.name
+}
+
+// loadLocal returns the named zone, named "Local", and reports
+// whether it could be loaded.
+func loadLocal(name string) (*Location, bool) {
+	z, ok := loadLocalZone(name)
+	if !ok {
+		return nil, false
+	}
+	l := *z
+	l.name = "Local"
+	return &l, true
+}
+
+// jsZoneName returns the IANA name of the host time zone from
+// Intl.DateTimeFormat().resolvedOptions().timeZone, or "" if the
+// host does not provide one.
+func jsZoneName() (name string) {
+	defer func() {
+		// Invoke and Call panic if JavaScript throws.
+		if recover() != nil {
+			name = ""
+		}
+	}()
+	intl := js.Global().Get("Intl")
+	if intl.Type() != js.TypeObject {
+		return ""
+	}
+	tz := intl.Get("DateTimeFormat").Invoke().Call("resolvedOptions").Get("timeZone")
+	if tz.Type() != js.TypeString {
+		return ""
+	}
+	return tz.String()
+}
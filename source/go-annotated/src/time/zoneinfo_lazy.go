@@ -0,0 +1,152 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// lazyTxMin is the number of transitions from which
+// LoadLocationFromTZData defers decoding the transition table.
+// Shorter tables are decoded at once.
+const lazyTxMin = 32
+
+// A lazyTx is a transition table read from a tzfile but not yet
+// decoded. Many programs load a Location only to carry its name, or
+// to format the current time, which for most zones follows the last
+// transition and is computed from the extend string; they never need
+// the table. It is decoded by the first lookup of an earlier time.
+// 延迟解析转换表，直到第一次查询需要它为止
+type lazyTx struct {
+	last zoneTrans // the last transition, decoded
+
+	once sync.Once
+	done uint32 // set, atomically, once tx is decoded
+	tx   []zoneTrans
+
+	// The undecoded table, copied from the tzfile.
+	n    int          // number of transitions
+	is64 bool         // whether times are 8 bytes, rather than 4
+	data []byte       // times, then zone indexes, isstd and isutc
+	nstd int          // number of isstd indicators
+	nutc int          // number of isutc indicators
+	leap []leapSecond // as read from the tzfile, see leapToUnix
+}
+
+// newLazyTx returns a lazyTx for the given parts of a tzfile, checked
+// as for decodeTx. They are copied, as the tzfile may be released once
+// parsed, and so is leap, which the caller goes on to convert.
+func newLazyTx(txtimes []byte, is64 bool, txzones, isstd, isutc []byte, leap []leapSecond) *lazyTx {
+	n := len(txzones)
+	data := make([]byte, 0, len(txtimes)+n+len(isstd)+len(isutc))
+	data = append(data, txtimes...)
+	data = append(data, txzones...)
+	data = append(data, isstd...)
+	data = append(data, isutc...)
+	lz := &lazyTx{n: n, is64: is64, data: data, nstd: len(isstd), nutc: len(isutc)}
+	if len(leap) > 0 {
+		lz.leap = append([]leapSecond(nil), leap...)
+	}
+	size := 4
+	if is64 {
+		size = 8
+	}
+	var std, utc []byte
+	if len(isstd) >= n {
+		std = isstd[n-1:]
+	}
+	if len(isutc) >= n {
+		utc = isutc[n-1:]
+	}
+	lz.last = decodeTx(txtimes[(n-1)*size:], is64, txzones[n-1:], std, utc, leap)[0]
+	return lz
+}
+
+// decoded reports whether the table has been decoded.
+func (lz *lazyTx) decoded() bool {
+	return atomic.LoadUint32(&lz.done) != 0
+}
+
+// decode decodes the table, once.
+func (lz *lazyTx) decode() []zoneTrans {
+	lz.once.Do(func() {
+		size := 4
+		if lz.is64 {
+			size = 8
+		}
+		p := lz.data
+		txtimes, p := p[:lz.n*size], p[lz.n*size:]
+		txzones, p := p[:lz.n], p[lz.n:]
+		isstd, isutc := p[:lz.nstd], p[lz.nstd:lz.nstd+lz.nutc]
+		_, lz.tx, _ = shareTables(nil, decodeTx(txtimes, lz.is64, txzones, isstd, isutc, lz.leap), nil)
+		lz.data = nil
+		atomic.StoreUint32(&lz.done, 1)
+	})
+	return lz.tx
+}
+
+// decodeTx decodes a transition table of a tzfile, converting its
+// times to Unix time using leap, the leap second table as read from
+// the tzfile, if it has one. The caller checks that
+// txtimes holds len(txzones) times and that the zone indexes are valid.
+func decodeTx(txtimes []byte, is64 bool, txzones, isstd, isutc []byte, leap []leapSecond) []zoneTrans {
+	d := dataIO{txtimes, false}
+	tx := make([]zoneTrans, len(txzones))
+	for i := range tx {
+		var n int64
+		if !is64 {
+			n4, _ := d.big4()
+			n = int64(int32(n4))
+		} else {
+			n8, _ := d.big8()
+			n = int64(n8)
+		}
+		tx[i].when = n
+		tx[i].index = txzones[i]
+		if i < len(isstd) {
+			tx[i].isstd = isstd[i] != 0
+		}
+		if i < len(isutc) {
+			tx[i].isutc = isutc[i] != 0
+		}
+	}
+	if len(leap) > 0 {
+		// Transition times in such files count leap seconds too;
+		// convert them to the Unix time used by lookup.
+		for i := range tx {
+			tx[i].when = leapToUnix(leap, tx[i].when)
+		}
+	}
+	return tx
+}
+
+// transitions returns the transition table of l, decoding it first
+// if it was loaded lazily. The table must not be modified.
+func (l *Location) transitions() []zoneTrans {
+	if l.lazy != nil {
+		return l.lazy.decode()
+	}
+	return l.tx
+}
+
+// lookupLast is the part of lookupTx that does not need the
+// transition table: it handles times at or after the last transition
+// of a Location whose table has not been decoded yet. ok is false for
+// other times and Locations.
+func (l *Location) lookupLast(sec int64) (name string, offset int, isDST bool, start, end int64, ok bool) {
+	lz := l.lazy
+	if lz == nil || lz.decoded() || sec < lz.last.when {
+		return
+	}
+	zone := &l.zone[lz.last.index]
+	name, offset, isDST, start, end = zone.name, zone.offset, zone.isDST, lz.last.when, omega
+	if l.extend != "" {
+		if ename, eoffset, estart, eend, eisDST, ok := tzset(l.extend, start, sec); ok {
+			return ename, eoffset, eisDST, estart, eend, true
+		}
+	}
+	return name, offset, isDST, start, end, true
+}
@@ -0,0 +1,100 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// A leapSecond is a record of the leap second table of a Location:
+// starting at when, corr leap seconds have been inserted in total.
+// when is a Unix time, which does not count leap seconds.
+type leapSecond struct {
+	when int64
+	corr int64
+}
+
+// leapToUnix converts sec, a time in the scale of a tzfile with the
+// raw leap second table leap, to a Unix time. A leap second maps to
+// the second before it.
+func leapToUnix(leap []leapSecond, sec int64) int64 {
+	for i := len(leap) - 1; i >= 0; i-- {
+		if leap[i].when <= sec {
+			return sec - leap[i].corr
+		}
+	}
+	return sec
+}
+
+// leapTableToUnix converts the occurrence times of a raw leap second
+// table, as read from a tzfile, to Unix times.
+func leapTableToUnix(leap []leapSecond) {
+	// Each record occurs after the seconds inserted by the ones before.
+	for i := len(leap) - 1; i > 0; i-- {
+		leap[i].when -= leap[i-1].corr
+	}
+}
+
+// HasLeapSeconds reports whether l has a leap second table.
+// Only Locations loaded from the "right/" variants of the time zone
+// files, such as "right/UTC", have one.
+func (l *Location) HasLeapSeconds() bool {
+	return len(l.get().leap) > 0
+}
+
+// LeapSeconds returns the number of leap seconds inserted before t
+// according to the leap second table of l, or 0 if l has none.
+// 返回 t 之前累计插入的闰秒数
+func (l *Location) LeapSeconds(t Time) int {
+	return int(l.leapCorr(t.Unix()))
+}
+
+// leapCorr returns the leap second correction in effect at the
+// Unix time sec.
+func (l *Location) leapCorr(sec int64) int64 {
+	leap := l.get().leap
+	// Binary search for entry with largest time <= sec.
+	lo, hi := 0, len(leap)
+	for lo < hi {
+		m := lo + (hi-lo)/2
+		if leap[m].when <= sec {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	if lo == 0 {
+		return 0
+	}
+	return leap[lo-1].corr
+}
+
+// TAISeconds returns t as the number of seconds elapsed since
+// January 1, 1970 UTC counting the leap seconds in the table of l.
+// This is the time scale of the "right/" time zone files;
+// TAI itself is a further 10 seconds ahead.
+// 把 UTC 时间换算成计入闰秒的秒数
+func (l *Location) TAISeconds(t Time) int64 {
+	sec := t.Unix()
+	return sec + l.leapCorr(sec)
+}
+
+// FromTAISeconds returns the time corresponding to sec, a count of
+// seconds as returned by TAISeconds, in the location l.
+// Since Time cannot represent a leap second, an inserted leap
+// second (23:59:60) maps to the second before it.
+func (l *Location) FromTAISeconds(sec int64) Time {
+	leap := l.get().leap
+	// Find the first record not yet in effect at sec.
+	i := 0
+	for i < len(leap) && leap[i].when+leap[i].corr <= sec {
+		i++
+	}
+	unix := sec
+	if i > 0 {
+		unix -= leap[i-1].corr
+	}
+	if i < len(leap) && unix >= leap[i].when {
+		// sec is the leap second inserted by record i.
+		unix = leap[i].when - 1
+	}
+	return Unix(unix, 0).In(l)
+}
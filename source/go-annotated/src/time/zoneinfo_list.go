@@ -0,0 +1,216 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "syscall"
+
+// AvailableZones returns the sorted names of all time zones that
+// LoadLocation can find in the directories and zip files named by the
+// ZONEINFO environment variable, in the system time zone directories,
+// and in $GOROOT/lib/time/zoneinfo.zip.
+// 列出所有可以被 LoadLocation 加载的时区名，可用于构建时区选择器
+//
+// A file counts as a time zone if it is in the IANA Time Zone database
+// format. The "posix" and "right" variants of the database and the
+// "localtime" and "posixrules" files are not listed.
+//
+// AvailableZones returns an error only if no source could be read.
+func AvailableZones() ([]string, error) {
+	sources := append(zoneinfoDirs(), systemZoneSources()...)
+
+	seen := make(map[string]bool)
+	var firstErr error
+	ok := false
+	for _, source := range sources {
+		var names []string
+		var err error
+		if isZipSource(source) {
+			names, err = listZip(source)
+		} else if len(source) >= 6 && source[len(source)-6:] == "tzdata" && listTzdata != nil {
+			names, err = listTzdata(source)
+		} else {
+			names, err = listDir(trimSlash(source), "")
+		}
+		if err != nil {
+			if firstErr == nil && err != syscall.ENOENT {
+				firstErr = err
+			}
+			continue
+		}
+		ok = true
+		for _, name := range names {
+			seen[name] = true
+		}
+	}
+	if !ok {
+		if firstErr == nil {
+			firstErr = ErrNoTZData
+		}
+		return nil, firstErr
+	}
+
+	zones := make([]string, 0, len(seen))
+	for name := range seen {
+		zones = append(zones, name)
+	}
+	sortStrings(zones)
+	return zones, nil
+}
+
+// LocationsByAbbrev returns, in name order, every Location listed by
+// AvailableZones whose zone in effect at instant t has the abbreviation
+// abbrev, such as "CET" or "EST". It answers questions like "which
+// zones call themselves EST right now?", which Parse cannot, since it
+// only consults a single Location.
+// 根据时区缩写反查：在 t 时刻哪些 Location 使用这个缩写
+//
+// The result includes backward-compatible aliases such as "US/Eastern"
+// next to their canonical zones. Every call looks at all available zones,
+// so callers doing many lookups should cache the result.
+func LocationsByAbbrev(abbrev string, t Time) ([]*Location, error) {
+	names, err := AvailableZones()
+	if err != nil {
+		return nil, err
+	}
+	sec := t.unixSec()
+	var locs []*Location
+	for _, name := range names {
+		l, err := LoadLocation(name)
+		if err != nil {
+			// Listed but unreadable; nothing to report for it.
+			continue
+		}
+		if zname, _, _, _, _ := l.lookup(sec); zname == abbrev {
+			locs = append(locs, l)
+		}
+	}
+	return locs, nil
+}
+
+// skipZoneEntry reports whether the directory entry name at the top
+// level of a zoneinfo directory should not be listed.
+func skipZoneEntry(name string) bool {
+	switch name {
+	case "posix", "right", "localtime", "posixrules":
+		return true
+	}
+	return false
+}
+
+// listDir returns the names of the TZif files below root/dir,
+// relative to root.
+func listDir(root, dir string) ([]string, error) {
+	path := root
+	if dir != "" {
+		path += "/" + dir
+	}
+	entries, err := readDirNames(path)
+	if err != nil {
+		return nil, err
+	}
+	var zones []string
+	for _, e := range entries {
+		if dir == "" && skipZoneEntry(e) {
+			continue
+		}
+		name := e
+		if dir != "" {
+			name = dir + "/" + e
+		}
+		isDir, err := isDirectory(root + "/" + name)
+		if err != nil {
+			continue
+		}
+		if isDir {
+			sub, err := listDir(root, name)
+			if err == nil {
+				zones = append(zones, sub...)
+			}
+			continue
+		}
+		if isTZifFile(root + "/" + name) {
+			zones = append(zones, name)
+		}
+	}
+	return zones, nil
+}
+
+// isTZifFile reports whether the named file starts with the TZif magic.
+func isTZifFile(name string) bool {
+	fd, err := open(name)
+	if err != nil {
+		return false
+	}
+	defer closefd(fd)
+	var magic [4]byte
+	n, _ := read(fd, magic[:])
+	return n == 4 && string(magic[:]) == "TZif"
+}
+
+// listZip returns the names of the files in the given zip file,
+// uncompressed or compressed in a registered format. See readZipDir
+// for the layout.
+func listZip(zipfile string) ([]string, error) {
+	fd, err := open(zipfile)
+	if err != nil {
+		return nil, err
+	}
+	defer closefd(fd)
+
+	var dir *zipDir
+	if d := zoneDecompressorFor(zipfile); d != nil {
+		dir, err = readCompressedZipDir(fd, zipfile, d)
+	} else {
+		dir, err = readZipDir(fd, zipfile)
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(dir.names))
+	for _, name := range dir.names {
+		if name == "" || name[len(name)-1] == '/' {
+			continue // directory entry
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// trimSlash removes trailing slashes from a directory name.
+func trimSlash(dir string) string {
+	for len(dir) > 1 && dir[len(dir)-1] == '/' {
+		dir = dir[:len(dir)-1]
+	}
+	return dir
+}
+
+// sortStrings sorts a in increasing order.
+// It is a plain heapsort, to avoid depending on package sort.
+func sortStrings(a []string) {
+	siftDown := func(lo, hi int) {
+		root := lo
+		for {
+			child := 2*root + 1
+			if child >= hi {
+				return
+			}
+			if child+1 < hi && a[child] < a[child+1] {
+				child++
+			}
+			if a[root] >= a[child] {
+				return
+			}
+			a[root], a[child] = a[child], a[root]
+			root = child
+		}
+	}
+	for i := len(a)/2 - 1; i >= 0; i-- {
+		siftDown(i, len(a))
+	}
+	for i := len(a) - 1; i > 0; i-- {
+		a[0], a[i] = a[i], a[0]
+		siftDown(0, i)
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build nacl plan9 wasip1 windows
+
+package time
+
+import "syscall"
+
+// On these systems the time zone database is only available
+// as zoneinfo.zip, or directories cannot be read, as on WASI,
+// so directories are never listed.
+
+func readDirNames(dir string) ([]string, error) {
+	return nil, syscall.ENOENT
+}
+
+func isDirectory(name string) (bool, error) {
+	return false, syscall.ENOENT
+}
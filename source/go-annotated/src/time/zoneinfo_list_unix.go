@@ -0,0 +1,46 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build darwin dragonfly freebsd js,wasm linux netbsd openbsd solaris
+
+package time
+
+import "syscall"
+
+// readDirNames returns the names of the entries in dir,
+// excluding "." and "..".
+func readDirNames(dir string) ([]string, error) {
+	fd, err := syscall.Open(dir, syscall.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.Close(fd)
+
+	var (
+		buf   [8192]byte
+		names []string
+	)
+	for {
+		n, err := syscall.ReadDirent(fd, buf[:])
+		if err != nil {
+			return nil, err
+		}
+		if n <= 0 {
+			break
+		}
+		_, _, names = syscall.ParseDirent(buf[:n], -1, names)
+	}
+	return names, nil
+}
+
+// isDirectory reports whether name is a directory.
+// Symbolic links to directories are not followed, so that links such
+// as posix -> . cannot make AvailableZones loop forever.
+func isDirectory(name string) (bool, error) {
+	var st syscall.Stat_t
+	if err := syscall.Lstat(name, &st); err != nil {
+		return false, err
+	}
+	return uint32(st.Mode)&syscall.S_IFMT == syscall.S_IFDIR, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// WithoutLMT returns a copy of l in which times before its first
+// transition, when the zone database gives the local mean time of the
+// place, such as -4:56:02 "LMT" in America/New_York before 1883, use
+// the first standard time of l instead, such as -5:00 "EST", for
+// programs that want the offsets of modern times for old dates. Where
+// the first standard time is itself a mean time of its own, as the
+// +5:53:20 "HMT" of Asia/Kolkata, that is what they get.
+// 去掉标准时间出现之前的地方平均时（LMT），改用第一个标准时间的偏移
+//
+// Times after the first transition are unchanged. If l has no
+// transitions, or none to standard time, WithoutLMT returns l. For
+// Local, the copy has the zone Local has now.
+func (l *Location) WithoutLMT() *Location {
+	l = l.get()
+	tx := l.transitions()
+	std := -1
+	for _, t := range tx {
+		if !l.zone[t.index].isDST {
+			std = int(t.index)
+			break
+		}
+	}
+	if std < 0 {
+		return l
+	}
+
+	ntx := make([]zoneTrans, 0, len(tx)+1)
+	if int(tx[0].index) != std {
+		// The first transition is to daylight saving time.
+		ntx = append(ntx, zoneTrans{when: alpha, index: uint8(std)})
+	}
+	ntx = append(ntx, tx...)
+	ntx[0].when = alpha
+
+	c := &Location{
+		name:   l.name,
+		zone:   l.zone,
+		tx:     ntx,
+		extend: l.extend,
+		leap:   l.leap,
+		alias:  l.alias,
+		source: l.source,
+	}
+	c.resetCache()
+	return c
+}
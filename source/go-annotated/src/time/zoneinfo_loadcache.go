@@ -0,0 +1,140 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// locationCacheSize bounds the number of Locations kept by
+// LoadLocation. The IANA database has about 600 names, but POSIX TZ
+// strings are unlimited; once the cache is full, new names are
+// loaded every time.
+const locationCacheSize = 1024
+
+// locationMissCacheSize bounds the number of unknown names
+// remembered by LoadLocation. When it is reached the remembered
+// names are forgotten, so a stream of distinct bad names cannot
+// grow the cache without limit.
+const locationMissCacheSize = 1024
+
+// A locationCall is a LoadLocation in progress or completed.
+// Concurrent loads of the same name wait for a single call.
+type locationCall struct {
+	done chan struct{} // closed when loc and err are set
+	loc  *Location
+	err  error
+}
+
+// locationCache holds the Locations returned by LoadLocation, by name,
+// and the errors for names that could not be loaded.
+// 按时区名缓存 LoadLocation 的结果，避免每次都读取并解析 tzfile；
+// 找不到的时区名也会缓存，避免重复遍历所有数据源
+var locationCache struct {
+	sync.Mutex
+	loc   map[string]*Location
+	miss  map[string]error
+	calls map[string]*locationCall
+	gen   uint64 // incremented by ClearLocationCache
+}
+
+// loadLocationCached returns the Location with the given name from
+// the cache, loading it if needed. Concurrent callers asking for the
+// same name share one load. The cached Locations are never handed
+// out: each caller gets a copy, which it may change.
+func loadLocationCached(name string) (*Location, error) {
+	c := &locationCache
+	c.Lock()
+	if l, ok := c.loc[name]; ok {
+		c.Unlock()
+		countMetric(metricLoadHit)
+		return l.clone(), nil
+	}
+	if err, ok := c.miss[name]; ok {
+		c.Unlock()
+		countMetric(metricLoadHit)
+		return nil, err
+	}
+	if call, ok := c.calls[name]; ok {
+		c.Unlock()
+		countMetric(metricLoadHit)
+		<-call.done
+		return call.loc.clone(), call.err
+	}
+	countMetric(metricLoadMiss)
+	call := &locationCall{done: make(chan struct{})}
+	if c.calls == nil {
+		c.calls = make(map[string]*locationCall)
+	}
+	c.calls[name] = call
+	gen := c.gen
+	c.Unlock()
+
+	call.loc, call.err = loadLocationUncached(name)
+
+	c.Lock()
+	if c.calls[name] == call {
+		delete(c.calls, name)
+	}
+	// Do not cache a result loaded before ClearLocationCache:
+	// it may come from sources that have changed since.
+	if gen == c.gen {
+		switch {
+		case call.err == nil:
+			if len(c.loc) < locationCacheSize {
+				if c.loc == nil {
+					c.loc = make(map[string]*Location)
+				}
+				c.loc[name] = call.loc
+			}
+		case isLasting(call.err):
+			if c.miss == nil || len(c.miss) >= locationMissCacheSize {
+				c.miss = make(map[string]error)
+			}
+			c.miss[name] = call.err
+		}
+	}
+	c.Unlock()
+	close(call.done)
+	return call.loc.clone(), call.err
+}
+
+// clone returns a new Location with the data of l, sharing its
+// tables, which are never changed, and its lookup cache, which is
+// safe for concurrent use, until SetCacheOptions replaces it.
+// A nil l gives nil.
+func (l *Location) clone() *Location {
+	if l == nil {
+		return nil
+	}
+	return &Location{
+		name:   l.name,
+		zone:   l.zone,
+		tx:     l.tx,
+		lazy:   l.lazy,
+		extend: l.extend,
+		leap:   l.leap,
+		alias:  l.alias,
+		source: l.source,
+		cache:  atomic.LoadPointer(&l.cache),
+	}
+}
+
+// ClearLocationCache discards the Locations cached by LoadLocation,
+// and the names it failed to find, so that later calls read the time
+// zone database again, for example after it has been updated on disk.
+// Locations already returned are not affected.
+// 清空缓存，例如系统的时区数据库更新之后
+func ClearLocationCache() {
+	c := &locationCache
+	c.Lock()
+	c.loc = nil
+	c.miss = nil
+	c.calls = nil
+	c.gen++
+	c.Unlock()
+	clearSharedTables()
+}
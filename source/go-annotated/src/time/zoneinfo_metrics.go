@@ -0,0 +1,62 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "sync/atomic"
+
+// Counters of the zone caches and loaders, in the order of the fields
+// of time/timedebug.Metrics, which reads them.
+// 时区缓存和加载的计数器，由 time/timedebug 读取
+const (
+	metricLookupHit  = iota // lookup answered by the Location's cache
+	metricLookupMiss        // lookup that searched the transitions
+	metricLoadHit           // LoadLocation answered by the location cache
+	metricLoadMiss          // LoadLocation that loaded the zone
+	metricRead              // zone data read from a directory, zip, tzdata file or ZoneSource
+	metricParseError        // LoadLocationFromTZData failed
+	numMetrics
+)
+
+var metrics [numMetrics]uint64
+
+// lookupMetrics is nonzero if lookups are counted. They are the hot
+// path of every Time method that needs the zone, and an atomic add on
+// a shared counter would make concurrent lookups contend; loads are
+// rare enough to be counted always.
+var lookupMetrics uint32
+
+func countMetric(m int) {
+	atomic.AddUint64(&metrics[m], 1)
+}
+
+// countLookup counts a lookup that hit or missed the cache.
+func countLookup(hit bool) {
+	if atomic.LoadUint32(&lookupMetrics) == 0 {
+		return
+	}
+	if hit {
+		countMetric(metricLookupHit)
+	} else {
+		countMetric(metricLookupMiss)
+	}
+}
+
+// readMetrics copies the counters into m.
+// It is called from package time/timedebug through go:linkname.
+func readMetrics(m []uint64) {
+	for i := 0; i < len(m) && i < numMetrics; i++ {
+		m[i] = atomic.LoadUint64(&metrics[i])
+	}
+}
+
+// setLookupMetrics turns the counting of lookups on or off.
+// It is called from package time/timedebug through go:linkname.
+func setLookupMetrics(on bool) {
+	var v uint32
+	if on {
+		v = 1
+	}
+	atomic.StoreUint32(&lookupMetrics, v)
+}
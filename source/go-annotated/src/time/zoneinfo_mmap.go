@@ -0,0 +1,54 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "errors"
+
+var errMmap = errors.New("cannot map file")
+
+// mmapMinSize is the size from which tzfiles are mapped into memory
+// rather than read. Smaller files are cheaper to read than to map.
+const mmapMinSize = 64 << 10
+
+// readFileMapped is like readFile, but maps files of mmapMinSize bytes
+// or more into memory, read-only, instead of copying them to the heap.
+// If release is not nil, data is mapped, and must not be used after
+// release is called.
+func readFileMapped(name string) (data []byte, release func(), err error) {
+	fd, err := open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closefd(fd)
+	if size, err := fileSize(fd); err == nil && size >= mmapMinSize {
+		if size > maxFileSize {
+			return nil, nil, fileSizeError(name)
+		}
+		if b, err := mmapFd(fd, size); err == nil {
+			return b, func() { munmap(b) }, nil
+		}
+	}
+	data, err = readFd(fd, name)
+	return data, nil, err
+}
+
+// mapTzinfoFromDirOrZip is like loadTzinfoFromDirOrZip, but may return
+// data mapped into memory, read-only. If release is not nil, data must
+// not be used after release is called. Files in a zip file are copied
+// out of it, and have a nil release.
+func mapTzinfoFromDirOrZip(dir, name string) (data []byte, release func(), err error) {
+	if trace := loadTracer(); trace != nil {
+		start, zone := runtimeNano(), name
+		defer func() { trace(traceRead, zone, dir, len(data), runtimeNano()-start, err) }()
+	}
+	if isZipSource(dir) {
+		data, err = loadTzinfoFromZip(dir, name)
+		return data, nil, err
+	}
+	if dir != "" {
+		name = dir + "/" + name
+	}
+	return readFileMapped(name)
+}
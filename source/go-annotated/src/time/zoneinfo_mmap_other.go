@@ -0,0 +1,19 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package time
+
+// mmapFd always fails: files are read into memory instead.
+func mmapFd(fd uintptr, size int64) ([]byte, error) {
+	return nil, errMmap
+}
+
+func munmap(b []byte) {}
+
+// fileSize reports an unknown size, so that files are read.
+func fileSize(fd uintptr) (int64, error) {
+	return 0, errMmap
+}
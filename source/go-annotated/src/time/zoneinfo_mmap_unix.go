@@ -0,0 +1,36 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package time
+
+import "syscall"
+
+// mmapFd maps the whole of the open file fd, of the given size,
+// read-only into memory.
+// The file must not be truncated while it is mapped; the time zone
+// database is updated by replacing files, which leaves the mapped
+// ones intact.
+// 以只读方式把整个文件映射到内存，避免把内容复制到堆上
+func mmapFd(fd uintptr, size int64) ([]byte, error) {
+	if size <= 0 || int64(int(size)) != size {
+		return nil, errMmap
+	}
+	return syscall.Mmap(int(fd), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+// munmap unmaps memory returned by mmapFd.
+func munmap(b []byte) {
+	syscall.Munmap(b)
+}
+
+// fileSize returns the size of the open file fd.
+func fileSize(fd uintptr) (int64, error) {
+	var st syscall.Stat_t
+	if err := syscall.Fstat(int(fd), &st); err != nil {
+		return 0, err
+	}
+	return st.Size, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "sync"
+
+// A Transition describes a change of zone in a Location, such as the
+// start of daylight saving time.
+type Transition struct {
+	When Time // instant of the change, in the Location
+
+	// The zone before and after the change.
+	FromName, ToName     string // abbreviation, such as "CET"
+	FromOffset, ToOffset int    // seconds east of UTC
+	FromDST, ToDST       bool
+}
+
+// transitionAt returns the Transition of l at when.
+func (l *Location) transitionAt(when Time) Transition {
+	sec := when.unixSec()
+	tr := Transition{When: when}
+	tr.FromName, tr.FromOffset, tr.FromDST, _, _ = l.lookup(sec - 1)
+	tr.ToName, tr.ToOffset, tr.ToDST, _, _ = l.lookup(sec)
+	return tr
+}
+
+// A transitionWatch delivers the transitions of a Location to a channel.
+type transitionWatch struct {
+	l       *Location
+	ch      chan<- Transition
+	lead    Duration
+	timer   *Timer
+	next    Time // the transition the timer is set for
+	stopped bool
+}
+
+// transitionWatches holds the channels registered by NotifyTransitions.
+var transitionWatches struct {
+	sync.Mutex
+	m map[*Location]map[chan<- Transition]*transitionWatch
+}
+
+// NotifyTransitions causes the upcoming transitions of l to be sent
+// on ch, lead before each happens, so that services can prepare for
+// a daylight saving time change, for example by pausing jobs. With
+// a lead of zero they are sent at the transition.
+// 在 l 的每次时区转换（例如夏令时切换）之前 lead 时间向 ch 发送通知
+//
+// As with os/signal, sends do not block: the caller must make ch
+// big enough, or keep reading it, not to miss transitions. Calling
+// NotifyTransitions again with the same l and ch replaces the lead.
+// StopTransitions undoes NotifyTransitions.
+//
+// A transition less than lead away when NotifyTransitions is called,
+// or when the previous one is sent, is sent at once.
+func (l *Location) NotifyTransitions(ch chan<- Transition, lead Duration) {
+	if ch == nil {
+		panic("time: NotifyTransitions using nil channel")
+	}
+	l = l.get()
+	w := &transitionWatch{l: l, ch: ch, lead: lead}
+
+	tw := &transitionWatches
+	tw.Lock()
+	defer tw.Unlock()
+	if old := tw.m[l][ch]; old != nil {
+		old.stop()
+	}
+	if tw.m == nil {
+		tw.m = make(map[*Location]map[chan<- Transition]*transitionWatch)
+	}
+	if tw.m[l] == nil {
+		tw.m[l] = make(map[chan<- Transition]*transitionWatch)
+	}
+	tw.m[l][ch] = w
+	w.schedule(Now())
+}
+
+// StopTransitions stops the notifications to ch requested by
+// NotifyTransitions for l. When it returns, no more transitions
+// will be sent on ch for l.
+func (l *Location) StopTransitions(ch chan<- Transition) {
+	l = l.get()
+	tw := &transitionWatches
+	tw.Lock()
+	defer tw.Unlock()
+	if w := tw.m[l][ch]; w != nil {
+		w.stop()
+		delete(tw.m[l], ch)
+		if len(tw.m[l]) == 0 {
+			delete(tw.m, l)
+		}
+	}
+}
+
+// schedule arms the timer for the first transition after t.
+// transitionWatches must be locked.
+func (w *transitionWatch) schedule(t Time) {
+	next, ok := w.l.NextTransition(t)
+	if !ok {
+		w.timer = nil
+		return
+	}
+	w.next = next
+	w.timer = AfterFunc(Until(next.Add(-w.lead)), w.fire)
+}
+
+// stop stops w. transitionWatches must be locked.
+func (w *transitionWatch) stop() {
+	w.stopped = true
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+}
+
+func (w *transitionWatch) fire() {
+	tw := &transitionWatches
+	tw.Lock()
+	defer tw.Unlock()
+	if w.stopped {
+		return
+	}
+	select {
+	case w.ch <- w.l.transitionAt(w.next):
+	default:
+	}
+	w.schedule(w.next)
+}
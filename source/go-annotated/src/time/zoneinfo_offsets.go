@@ -0,0 +1,60 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// A ZoneOffset is one zone of a Location: an abbreviation, an offset
+// and whether it is daylight saving time, as Time.Zone reports them.
+type ZoneOffset struct {
+	Name   string // abbreviation, such as "CET"
+	Offset int    // seconds east of UTC
+	IsDST  bool
+}
+
+// OffsetsInRange returns the distinct zones of l in effect at some
+// time in [from, to), in the order they first appear. A window with
+// a single zone has no transition in it, so a day whose result has
+// two zones of different offsets is an hour shorter or longer than
+// 24 hours. If to is not after from, the result is the zone in
+// effect at from.
+// 返回一段时间内 l 用到的所有偏移，无需逐小时探测即可知道某天是否有 23 或 25 小时
+func (l *Location) OffsetsInRange(from, to Time) []ZoneOffset {
+	l = l.get()
+	sec, last := from.unixSec(), to.unixSec()
+	if to.nsec() != 0 {
+		// to is excluded, but the second it falls in is not.
+		last++
+	}
+
+	var zones []ZoneOffset
+	for {
+		name, offset, isDST, _, end := l.lookup(sec)
+		z := ZoneOffset{name, offset, isDST}
+		seen := false
+		for _, zz := range zones {
+			if zz == z {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			zones = append(zones, z)
+		}
+		if end >= last || end <= sec {
+			return zones
+		}
+		sec = end
+	}
+}
+
+// ZoneDiff returns how far the wall clock of b is ahead of that of a
+// at the instant t: t.In(b) reads as t.In(a) plus the result, as in
+//	ZoneDiff(newYork, london, t) // 5h, or 4h for some weeks of the year
+// 返回时刻 t 时 b 的本地时间比 a 快多少，用于"那边现在几点"之类的功能
+func ZoneDiff(a, b *Location, t Time) Duration {
+	sec := t.unixSec()
+	_, offA, _, _, _ := a.get().lookup(sec)
+	_, offB, _, _, _ := b.get().lookup(sec)
+	return Duration(offB-offA) * Second
+}
@@ -0,0 +1,64 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "sync"
+
+var (
+	systemSourcesMu  sync.Mutex
+	systemSources    []string // set by SetSystemZoneSources
+	systemSourcesSet bool
+)
+
+// SystemZoneSources returns the places where LoadLocation looks for
+// the system time zone database, in order, after $ZONEINFO and the
+// sources added by RegisterZoneSource: directories such as
+// /usr/share/zoneinfo/, zip files such as
+// $GOROOT/lib/time/zoneinfo.zip, and on Android tzdata files. The
+// result is a copy, which the caller may change and pass to
+// SetSystemZoneSources.
+// 返回系统时区数据库的查找顺序，可以修改后交给 SetSystemZoneSources
+func SystemZoneSources() []string {
+	sources := currentZoneSources()
+	return append([]string(nil), sources...)
+}
+
+// SetSystemZoneSources sets the places where LoadLocation looks for
+// the system time zone database, in order, for programs that must use
+// a particular copy of the database: for example, to prefer the zip
+// file of $GOROOT to the files of the host, or to use nothing but a
+// copy shipped with the program. An entry is a directory, or a zip
+// file as in $ZONEINFO. An empty list drops the system database
+// altogether, leaving $ZONEINFO, the registered sources and the
+// embedded database of time/tzdata; nil restores the default of the
+// platform.
+// 设置系统时区数据库的查找顺序，可以调整、删除或插入路径
+//
+// SetSystemZoneSources clears the cache of LoadLocation. Local, once
+// loaded, is not affected until it is reloaded; see ReloadLocal. In
+// sandbox mode, no system source is consulted, whatever the list.
+func SetSystemZoneSources(sources []string) {
+	systemSourcesMu.Lock()
+	if sources == nil {
+		systemSources, systemSourcesSet = nil, false
+	} else {
+		systemSources = append(make([]string, 0, len(sources)), sources...)
+		systemSourcesSet = true
+	}
+	systemSourcesMu.Unlock()
+	ClearLocationCache()
+}
+
+// currentZoneSources returns the system time zone database locations
+// set by SetSystemZoneSources, or those of the platform. The result
+// must not be modified.
+func currentZoneSources() []string {
+	systemSourcesMu.Lock()
+	defer systemSourcesMu.Unlock()
+	if systemSourcesSet {
+		return systemSources[:len(systemSources):len(systemSources)]
+	}
+	return zoneSources[:len(zoneSources):len(zoneSources)]
+}
@@ -0,0 +1,14 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "runtime"
+
+// Windows has no IANA database of its own; the only system source is
+// the copy in GOROOT.
+// Windows 没有自带的 IANA 时区数据库，只能使用 GOROOT 中的副本
+var zoneSources = []string{
+	runtime.GOROOT() + "/lib/time/zoneinfo.zip",
+}
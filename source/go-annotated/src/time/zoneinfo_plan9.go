@@ -0,0 +1,133 @@
+// Copyright 2011 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Parse Plan 9 timezone(2) files.
+// Plan 9 的 timezone 格式：标准时区和夏令时区的名称与偏移，以及转换时刻表
+
+package time
+
+import (
+	"runtime"
+	"syscall"
+)
+
+// Plan 9 has no IANA database of its own; LoadLocation finds one in
+// $ZONEINFO, a registered source, Go's zoneinfo.zip or time/tzdata.
+var zoneSources = []string{
+	runtime.GOROOT() + "/lib/time/zoneinfo.zip",
+}
+
+// fields splits s around runs of white space.
+// Copied from strings to avoid a dependency.
+func fields(s string) []string {
+	var a []string
+	start := -1
+	for i := 0; i < len(s); i++ {
+		switch {
+		case isSpace(s[i]):
+			if start >= 0 {
+				a = append(a, s[start:i])
+				start = -1
+			}
+		case start < 0:
+			start = i
+		}
+	}
+	if start >= 0 {
+		a = append(a, s[start:])
+	}
+	return a
+}
+
+// loadZoneDataPlan9 parses the timezone(2) format: the name and
+// offset of standard time, those of daylight saving time, then the
+// times at which daylight saving time starts and ends, in pairs.
+func loadZoneDataPlan9(s string) (l *Location, err error) {
+	f := fields(s)
+	if len(f) < 4 {
+		if len(f) == 2 && f[0] == "GMT" {
+			return &Location{name: "UTC"}, nil
+		}
+		return nil, badData
+	}
+
+	var zones [2]zone
+
+	// standard timezone offset
+	o, err := atoi(f[1])
+	if err != nil {
+		return nil, badData
+	}
+	zones[0] = zone{name: f[0], offset: o, isDST: false}
+
+	// alternate timezone offset
+	o, err = atoi(f[3])
+	if err != nil {
+		return nil, badData
+	}
+	zones[1] = zone{name: f[2], offset: o, isDST: true}
+
+	// transition time pairs
+	var tx []zoneTrans
+	f = f[4:]
+	for i := 0; i < len(f); i++ {
+		zi := 0
+		if i%2 == 0 {
+			zi = 1
+		}
+		t, err := atoi(f[i])
+		if err != nil {
+			return nil, badData
+		}
+		t -= zones[0].offset
+		tx = append(tx, zoneTrans{when: int64(t), index: uint8(zi)})
+	}
+
+	// Committed to succeed.
+	l = &Location{name: "Local", zone: zones[:], tx: tx}
+	l.resetCache()
+	return l, nil
+}
+
+// readLocal honors $TZ first, as on other systems, looking for the
+// zone where LoadLocation does or reading it as a POSIX TZ string.
+// Without it, Local comes from the native $timezone variable or
+// /adm/timezone/local, and is UTC if neither can be read. It returns
+// the zone and a summary of the settings it was read from; see
+// initLocal.
+func readLocal() (*Location, string) {
+	tz, ok := syscall.Getenv("TZ")
+	if ok && tz != "" && tz[0] == ':' {
+		tz = tz[1:]
+	}
+	if ok && tz != "" {
+		if tz == "UTC" {
+			return &Location{name: "UTC"}, "TZ=" + tz
+		}
+		if z, ok := loadLocalZone(tz); ok {
+			return z, "TZ=" + tz
+		}
+		if z, ok := tzsetLocation(tz, tz); ok {
+			return z, "TZ=" + tz
+		}
+	}
+
+	// Plan 9 keeps environment variables in /env.
+	if t, ok := syscall.Getenv("timezone"); ok {
+		if z, err := loadZoneDataPlan9(t); err == nil {
+			z.source = LocationSource{Kind: SourceSystem, Dir: "/env/timezone"}
+			return z, "timezone=" + t
+		}
+	} else if !zoneSandboxed() {
+		if b, err := readFile("/adm/timezone/local"); err == nil {
+			if z, err := loadZoneDataPlan9(string(b)); err == nil {
+				z.source = LocationSource{Kind: SourceSystem, Dir: "/adm/timezone/local"}
+				return z, "local:" + string(b)
+			}
+		}
+	}
+
+	// Fall back to UTC.
+	return &Location{name: "UTC"}, "UTC"
+}
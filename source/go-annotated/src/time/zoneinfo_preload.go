@@ -0,0 +1,139 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"runtime"
+	"sync"
+)
+
+// PreloadZones loads the named time zones, concurrently, into the
+// cache used by LoadLocation, decoding their tables in full, so that
+// later calls to LoadLocation and lookups in the zones do no I/O.
+// Services that must answer quickly can call it at startup to take
+// reading the time zone database out of the request path.
+// 启动时预先并发加载时区，避免在处理请求时读取时区数据库
+//
+// It returns the errors of the names that could not be loaded, as
+// LoadLocation reports them, by name; nil means all were loaded.
+func PreloadZones(names ...string) map[string]error {
+	var (
+		mu   sync.Mutex
+		errs map[string]error
+	)
+	loadZones(names, func(name string, l *Location, err error) {
+		if err != nil {
+			mu.Lock()
+			if errs == nil {
+				errs = make(map[string]error)
+			}
+			errs[name] = err
+			mu.Unlock()
+			return
+		}
+		l.get().transitions()
+	})
+	return errs
+}
+
+// LoadLocations loads the named time zones, concurrently, as
+// LoadLocation does, for services that build a map of zones, by tenant
+// or by user, at startup. Loads from one zoneinfo.zip share a single
+// read of its directory.
+// 批量并发加载时区，返回按名称索引的 Location
+//
+// The map holds the Locations that were loaded, by name. If some
+// could not be, the error is a *LoadLocationsError giving the error of
+// each of them.
+func LoadLocations(names ...string) (map[string]*Location, error) {
+	var (
+		mu   sync.Mutex
+		locs = make(map[string]*Location, len(names))
+		e    *LoadLocationsError
+	)
+	loadZones(names, func(name string, l *Location, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err == nil {
+			locs[name] = l
+			return
+		}
+		if e == nil {
+			e = &LoadLocationsError{Errs: make(map[string]error)}
+		}
+		e.Errs[name] = err
+	})
+	if e == nil {
+		return locs, nil
+	}
+	for _, name := range names {
+		if _, ok := e.Errs[name]; ok && !containsName(e.Names, name) {
+			e.Names = append(e.Names, name)
+		}
+	}
+	return locs, e
+}
+
+// A LoadLocationsError reports the names that LoadLocations could not
+// load.
+type LoadLocationsError struct {
+	Names []string         // in the order they were given
+	Errs  map[string]error // by name, as LoadLocation reports them
+}
+
+func (e *LoadLocationsError) Error() string {
+	s := "time: LoadLocations: " + e.Errs[e.Names[0]].Error()
+	if n := len(e.Names) - 1; n > 0 {
+		s += " (and " + string(appendInt(nil, n, 0)) + " more)"
+	}
+	return s
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// loadZones loads the named zones with LoadLocation, on as many
+// goroutines as GOMAXPROCS, and calls done with the result of each,
+// on the goroutine that loaded it.
+func loadZones(names []string, done func(name string, l *Location, err error)) {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(names) {
+		workers = len(names)
+	}
+
+	var wg sync.WaitGroup
+	work := make(chan string)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range work {
+				l, err := LoadLocation(name)
+				done(name, l, err)
+			}
+		}()
+	}
+	for _, name := range names {
+		work <- name
+	}
+	close(work)
+	wg.Wait()
+}
+
+// PreloadAll is PreloadZones for all the zones listed by AvailableZones.
+// The error is that of AvailableZones, in which case nothing is loaded.
+func PreloadAll() (map[string]error, error) {
+	names, err := AvailableZones()
+	if err != nil {
+		return nil, err
+	}
+	return PreloadZones(names...), nil
+}
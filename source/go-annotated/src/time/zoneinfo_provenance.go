@@ -0,0 +1,95 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "runtime"
+
+// A SourceKind says where the data of a Location came from.
+type SourceKind int
+
+const (
+	SourceUnknown    SourceKind = iota // UTC, or built by the program, such as by Location.UnmarshalBinary
+	SourceZoneinfo                     // a directory or zip file listed in $ZONEINFO
+	SourceSystem                       // a system time zone directory or file, such as /usr/share/zoneinfo or /etc/localtime
+	SourceGOROOT                       // $GOROOT/lib/time/zoneinfo.zip
+	SourceZoneSource                   // a ZoneSource registered with RegisterZoneSource
+	SourceEmbedded                     // the copy of the database embedded by time/tzdata
+	SourceData                         // data passed to LoadLocationFromTZData
+	SourceRule                         // a POSIX TZ string, or FixedZone
+)
+
+var sourceKinds = [...]string{
+	SourceUnknown:    "unknown",
+	SourceZoneinfo:   "$ZONEINFO",
+	SourceSystem:     "system",
+	SourceGOROOT:     "$GOROOT",
+	SourceZoneSource: "ZoneSource",
+	SourceEmbedded:   "time/tzdata",
+	SourceData:       "LoadLocationFromTZData",
+	SourceRule:       "rule",
+}
+
+func (k SourceKind) String() string {
+	if 0 <= k && int(k) < len(sourceKinds) {
+		return sourceKinds[k]
+	}
+	return "SourceKind(" + string(appendInt(nil, int(k), 0)) + ")"
+}
+
+// A LocationSource records where the data of a Location came from.
+type LocationSource struct {
+	Kind SourceKind
+
+	// Dir is the directory, zip file or tzdata file the data was
+	// read from, and File the file read in it, such as
+	// "/usr/share/zoneinfo/" and "Europe/Paris". For a file read on
+	// its own, such as /etc/localtime, Dir is the file and File is
+	// empty. Both are empty for kinds that read no file.
+	Dir  string
+	File string
+}
+
+// Path returns the file the data was read from: File in Dir, or Dir.
+// For a zip or tzdata file, the file is in an archive, and the path
+// joins them as "/go/lib/time/zoneinfo.zip/Europe/Paris".
+func (s LocationSource) Path() string {
+	switch {
+	case s.File == "":
+		return s.Dir
+	case s.Dir == "" || s.Dir[len(s.Dir)-1] == '/':
+		return s.Dir + s.File
+	}
+	return s.Dir + "/" + s.File
+}
+
+func (s LocationSource) String() string {
+	if p := s.Path(); p != "" {
+		return s.Kind.String() + " " + p
+	}
+	return s.Kind.String()
+}
+
+// Source reports where the data of l came from, to find out why a
+// host gets a zone wrong: an old ZONEINFO zip, say, or a stale copy
+// of /etc/localtime.
+// 报告 Location 的数据来自哪里：ZONEINFO、系统目录、GOROOT 的 zip、内嵌数据库等
+func (l *Location) Source() LocationSource {
+	return l.get().source
+}
+
+// zoneDirSource returns the LocationSource of zone data read from
+// file in dir, one of the sources of zoneinfoDirs or systemZoneSources.
+func zoneDirSource(dir, file string) LocationSource {
+	kind := SourceSystem
+	for _, z := range zoneinfo {
+		if z == dir {
+			kind = SourceZoneinfo
+		}
+	}
+	if kind == SourceSystem && dir == runtime.GOROOT()+"/lib/time/zoneinfo.zip" {
+		kind = SourceGOROOT
+	}
+	return LocationSource{kind, dir, file}
+}
@@ -0,0 +1,618 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Parse "zoneinfo" time zone file.
+// This is a fairly standard file format used on OS X, Linux, BSD, Sun, and others.
+// See tzfile(5), http://en.wikipedia.org/wiki/Zoneinfo,
+// and ftp://munnari.oz.au/pub/oldtz/
+
+package time
+
+import (
+	"errors"
+	"io"
+)
+
+// registerLoadFromEmbeddedTZData is called by the time/tzdata package,
+// if it is imported.
+func registerLoadFromEmbeddedTZData(f func(string) (string, error)) {
+	loadFromEmbeddedTZData = f
+}
+
+// loadFromEmbeddedTZData is used to load a specific tzdata file
+// from tzdata information embedded in the binary itself.
+// This is set when the time/tzdata package is imported,
+// via registerLoadFromEmbeddedTzdata.
+var loadFromEmbeddedTZData func(zipname string) (string, error)
+
+// maxFileSize is the max permitted size of files read by readFile.
+// As reference, the zoneinfo.zip distributed by Go is ~350 KB,
+// so 10MB is overkill.
+const maxFileSize = 10 << 20
+
+type fileSizeError string
+
+func (f fileSizeError) Error() string {
+	return "time: file " + string(f) + " is too large"
+}
+
+// Copies of io.Seek* constants to avoid importing "io":
+const (
+	seekStart   = 0
+	seekCurrent = 1
+	seekEnd     = 2
+)
+
+// Simple I/O interface to binary blob of data.
+type dataIO struct {
+	p     []byte
+	error bool
+}
+
+func (d *dataIO) read(n int) []byte {
+	if len(d.p) < n {
+		d.p = nil
+		d.error = true
+		return nil
+	}
+	p := d.p[0:n]
+	d.p = d.p[n:]
+	return p
+}
+
+func (d *dataIO) big4() (n uint32, ok bool) {
+	p := d.read(4)
+	if len(p) < 4 {
+		d.error = true
+		return 0, false
+	}
+	return uint32(p[0])<<24 | uint32(p[1])<<16 | uint32(p[2])<<8 | uint32(p[3]), true
+}
+
+func (d *dataIO) big8() (n uint64, ok bool) {
+	n1, ok1 := d.big4()
+	n2, ok2 := d.big4()
+	if !ok1 || !ok2 {
+		d.error = true
+		return 0, false
+	}
+	return (uint64(n1) << 32) | uint64(n2), true
+}
+
+func (d *dataIO) byte() (n byte, ok bool) {
+	p := d.read(1)
+	if len(p) < 1 {
+		d.error = true
+		return 0, false
+	}
+	return p[0], true
+}
+
+// rest returns the rest of the data in the buffer.
+func (d *dataIO) rest() []byte {
+	r := d.p
+	d.p = nil
+	return r
+}
+
+// Make a string by stopping at the first NUL
+func byteString(p []byte) string {
+	for i := 0; i < len(p); i++ {
+		if p[i] == 0 {
+			return string(p[0:i])
+		}
+	}
+	return string(p)
+}
+
+var badData = ErrCorruptTZData
+
+// LoadLocationFromTZData returns a Location with the given name
+// initialized from the IANA Time Zone database-formatted data.
+// The data should be in the format of a standard IANA time zone file
+// (for example, the content of /etc/localtime on Unix systems).
+func LoadLocationFromTZData(name string, data []byte) (l *Location, err error) {
+	if trace := loadTracer(); trace != nil {
+		start := runtimeNano()
+		defer func() { trace(traceParse, name, "", len(data), runtimeNano()-start, err) }()
+	}
+	defer func() {
+		if err != nil {
+			countMetric(metricParseError)
+		}
+	}()
+
+	d := dataIO{data, false}
+
+	// 4-byte magic "TZif"
+	if magic := d.read(4); string(magic) != "TZif" {
+		return nil, badData
+	}
+
+	// 1-byte version, then 15 bytes of padding
+	var version int
+	var p []byte
+	if p = d.read(16); len(p) != 16 {
+		return nil, badData
+	} else {
+		switch p[0] {
+		case 0:
+			version = 1
+		case '2':
+			version = 2
+		case '3':
+			version = 3
+		default:
+			return nil, badData
+		}
+	}
+
+	// six big-endian 32-bit integers:
+	//	number of UTC/local indicators
+	//	number of standard/wall indicators
+	//	number of leap seconds
+	//	number of transition times
+	//	number of local time zones
+	//	number of characters of time zone abbrev strings
+	const (
+		NUTCLocal = iota
+		NStdWall
+		NLeap
+		NTime
+		NZone
+		NChar
+	)
+	var n [6]int
+	for i := 0; i < 6; i++ {
+		nn, ok := d.big4()
+		if !ok {
+			return nil, badData
+		}
+		n[i] = int(nn)
+	}
+
+	// If we have version 2 or 3, then the data is first written out
+	// in a 32-bit format, then written out again in a 64-bit format.
+	// Skip the 32-bit format and read the 64-bit one, as it can
+	// describe a broader range of dates.
+	// 版本 2、3 的文件先写一遍 32 位数据，再写一遍 64 位数据，只读后者
+
+	is64 := false
+	if version > 1 {
+		// Skip the 32-bit data.
+		skip := n[NTime]*4 +
+			n[NTime] +
+			n[NZone]*6 +
+			n[NChar] +
+			n[NLeap]*8 +
+			n[NStdWall] +
+			n[NUTCLocal]
+		// Skip the version 2 header that we just read.
+		skip += 4 + 16
+		d.read(skip)
+
+		is64 = true
+
+		// Read the counts again, they can differ.
+		for i := 0; i < 6; i++ {
+			nn, ok := d.big4()
+			if !ok {
+				return nil, badData
+			}
+			n[i] = int(nn)
+		}
+	}
+
+	size := 4
+	if is64 {
+		size = 8
+	}
+
+	// Transition times.
+	txtimes := dataIO{d.read(n[NTime] * size), false}
+
+	// Time zone indices for transition times.
+	txzones := d.read(n[NTime])
+
+	// Zone info structures
+	zonedata := dataIO{d.read(n[NZone] * 6), false}
+
+	// Time zone abbreviations.
+	abbrev := d.read(n[NChar])
+
+	// Leap-second time pairs
+	leapdata := dataIO{d.read(n[NLeap] * (size + 4)), false}
+
+	// Whether tx times associated with local time types
+	// are specified as standard time or wall time.
+	isstd := d.read(n[NStdWall])
+
+	// Whether tx times associated with local time types
+	// are specified as UTC or local time.
+	isutc := d.read(n[NUTCLocal])
+
+	if d.error { // ran out of data
+		return nil, badData
+	}
+
+	// The version 2 and 3 footer is a POSIX TZ string between
+	// newlines, used for times after the last transition.
+	// 文件末尾的 POSIX TZ 规则，用于最后一次转换之后的时间
+	var extend string
+	rest := d.rest()
+	if len(rest) > 2 && rest[0] == '\n' && rest[len(rest)-1] == '\n' {
+		extend = string(rest[1 : len(rest)-1])
+	}
+
+	// Now we can build up a useful data structure.
+	// First the zone information.
+	//	utcoff[4] isdst[1] nameindex[1]
+	if n[NZone] == 0 {
+		// Reject tzdata files with no zones. There's nothing useful
+		// in them, and the fake transition added below for files
+		// without transitions would refer to a missing zone.
+		return nil, badData
+	}
+	zone := make([]zone, n[NZone])
+	for i := range zone {
+		var ok bool
+		var n uint32
+		if n, ok = zonedata.big4(); !ok {
+			return nil, badData
+		}
+		zone[i].offset = int(int32(n))
+		var b byte
+		if b, ok = zonedata.byte(); !ok {
+			return nil, badData
+		}
+		zone[i].isDST = b != 0
+		if b, ok = zonedata.byte(); !ok || int(b) >= len(abbrev) {
+			return nil, badData
+		}
+		zone[i].name = internAbbrev(abbrev[b:])
+	}
+
+	// Now the transition time info.
+	// The zone indexes are checked now; the times are decoded now
+	// only for short tables, see lazyTx.
+	if len(txtimes.p) != n[NTime]*size {
+		return nil, badData
+	}
+	for _, z := range txzones {
+		if int(z) >= len(zone) {
+			return nil, badData
+		}
+	}
+
+	// Leap second records, present in the "right/" files.
+	//	occurrence[4 or 8] correction[4]
+	// 闰秒记录，只有 right/ 下的文件才有
+	leap := make([]leapSecond, n[NLeap])
+	for i := range leap {
+		var when int64
+		if !is64 {
+			if n4, ok := leapdata.big4(); !ok {
+				return nil, badData
+			} else {
+				when = int64(int32(n4))
+			}
+		} else {
+			if n8, ok := leapdata.big8(); !ok {
+				return nil, badData
+			} else {
+				when = int64(n8)
+			}
+		}
+		corr, ok := leapdata.big4()
+		if !ok || i > 0 && when <= leap[i-1].when {
+			return nil, badData
+		}
+		leap[i] = leapSecond{when, int64(int32(corr))}
+	}
+	var tx []zoneTrans
+	var lazy *lazyTx
+	if len(txzones) >= lazyTxMin {
+		lazy = newLazyTx(txtimes.p, is64, txzones, isstd, isutc, leap)
+	} else {
+		tx = decodeTx(txtimes.p, is64, txzones, isstd, isutc, leap)
+	}
+	leapTableToUnix(leap)
+
+	if len(tx) == 0 && lazy == nil {
+		// Build fake transition to cover all time.
+		// This happens in fixed locations like "Etc/GMT0".
+		tx = append(tx, zoneTrans{when: alpha, index: 0})
+	}
+
+	// Committed to succeed.
+	zone, tx, leap = shareTables(zone, tx, leap)
+	l = &Location{zone: zone, tx: tx, name: name, extend: extend, leap: leap, lazy: lazy}
+	l.source.Kind = SourceData
+
+	l.resetCache()
+
+	return l, nil
+}
+
+// loadTzinfoFromDirOrZip returns the contents of the file with the given name
+// in dir. dir can either be an uncompressed zip file, or a directory.
+func loadTzinfoFromDirOrZip(dir, name string) ([]byte, error) {
+	data, release, err := mapTzinfoFromDirOrZip(dir, name)
+	if release != nil {
+		data = append([]byte(nil), data...)
+		release()
+	}
+	return data, err
+}
+
+// There are 500+ zoneinfo files. Rather than distribute them all
+// individually, we ship them in an uncompressed zip file.
+// Used this way, the zip file format serves as a commonly readable
+// container for the individual small files. We choose zip over tar
+// because zip files have a contiguous table of contents, making
+// individual file lookups faster, and because the per-file overhead
+// in a zip file is considerably less than tar's 512 bytes.
+
+// get4 returns the little-endian 32-bit value in b.
+func get4(b []byte) int {
+	if len(b) < 4 {
+		return 0
+	}
+	return int(b[0]) | int(b[1])<<8 | int(b[2])<<16 | int(b[3])<<24
+}
+
+// get2 returns the little-endian 16-bit value in b.
+func get2(b []byte) int {
+	if len(b) < 2 {
+		return 0
+	}
+	return int(b[0]) | int(b[1])<<8
+}
+
+// loadTzinfoFromZip returns the contents of the file with the given name
+// in the given uncompressed zip file, or zip file compressed in a format
+// registered with RegisterZoneDecompressor. The result is always a copy,
+// even when the zip file is mapped into memory.
+func loadTzinfoFromZip(zipfile, name string) ([]byte, error) {
+	fd, err := open(zipfile)
+	if err != nil {
+		return nil, err
+	}
+	defer closefd(fd)
+
+	const (
+		zheadersize = 30
+		zheader     = 0x04034b50
+	)
+
+	var dir *zipDir
+	if d := zoneDecompressorFor(zipfile); d != nil {
+		dir, err = readCompressedZipDir(fd, zipfile, d)
+	} else {
+		dir, err = readZipDir(fd, zipfile)
+	}
+	if err != nil {
+		return nil, err
+	}
+	e, ok := dir.files[name]
+	if !ok {
+		return nil, ErrUnknownZone
+	}
+	if e.meth != 0 {
+		return nil, errors.New("unsupported compression for " + name + " in " + zipfile)
+	}
+
+	// zip per-file header layout:
+	//	0	magic[4]
+	//	4	extvers[1]
+	//	5	extos[1]
+	//	6	flags[2]
+	//	8	meth[2]
+	//	10	modtime[2]
+	//	12	moddate[2]
+	//	14	crc[4]
+	//	18	csize[4]
+	//	22	uncsize[4]
+	//	26	namelen[2]
+	//	28	xlen[2]
+	//	30	name[namelen]
+	//	30+namelen+xlen - file data
+	//
+	namelen := len(name)
+	if dir.data != nil {
+		// Copy the data out of the mapping of the zip file: a Location
+		// keeps it, and the file may be rewritten under the mapping.
+		buf := dir.data
+		if e.off < 0 || e.off+zheadersize+namelen > len(buf) {
+			return nil, errors.New("corrupt zip file " + zipfile)
+		}
+		buf = buf[e.off:]
+		if get4(buf) != zheader ||
+			get2(buf[8:]) != e.meth ||
+			get2(buf[26:]) != namelen ||
+			string(buf[30:30+namelen]) != name {
+			return nil, errors.New("corrupt zip file " + zipfile)
+		}
+		start := 30 + namelen + get2(buf[28:])
+		if e.size < 0 || start+e.size > len(buf) {
+			return nil, errors.New("corrupt zip file " + zipfile)
+		}
+		return append([]byte(nil), buf[start:start+e.size]...), nil
+	}
+	buf := make([]byte, zheadersize+namelen)
+	if err := preadn(fd, buf, e.off); err != nil ||
+		get4(buf) != zheader ||
+		get2(buf[8:]) != e.meth ||
+		get2(buf[26:]) != namelen ||
+		string(buf[30:30+namelen]) != name {
+		return nil, errors.New("corrupt zip file " + zipfile)
+	}
+	xlen := get2(buf[28:])
+
+	buf = make([]byte, e.size)
+	if err := preadn(fd, buf, e.off+30+namelen+xlen); err != nil {
+		return nil, errors.New("corrupt zip file " + zipfile)
+	}
+	return buf, nil
+}
+
+// loadTzinfoFromTzdata returns the time zone information of the time zone
+// with the given name, from a tzdata database file as they are typically
+// found on android.
+var loadTzinfoFromTzdata func(file, name string) ([]byte, error)
+
+// listTzdata returns the names of the time zones in a tzdata
+// database file. Like loadTzinfoFromTzdata, it is set on android.
+var listTzdata func(file string) ([]string, error)
+
+// tzdataVersion returns the version recorded in the header of a
+// tzdata database file, such as "2018e". It is set on android.
+var tzdataVersion func(file string) (string, error)
+
+// loadTzinfo returns the time zone information of the time zone
+// with the given name, from a given source. A source may be a
+// timezone database directory, tzdata database file or an uncompressed
+// zip file, containing the contents of such a directory.
+// Large files may be mapped into memory: if release is not nil, data
+// must not be used after release is called.
+func loadTzinfo(name string, source string) (data []byte, release func(), err error) {
+	if len(source) >= 6 && source[len(source)-6:] == "tzdata" && loadTzinfoFromTzdata != nil {
+		if trace := loadTracer(); trace != nil {
+			start := runtimeNano()
+			defer func() { trace(traceRead, name, source, len(data), runtimeNano()-start, err) }()
+		}
+		data, err = loadTzinfoFromTzdata(source, name)
+		return data, nil, err
+	}
+	return mapTzinfoFromDirOrZip(source, name)
+}
+
+// loadLocation returns the Location with the given name from one of
+// the specified sources. See loadTzinfo for a list of supported sources.
+// The first timezone data matching the given name that is successfully loaded
+// and parsed is returned as a Location.
+// If there is none, the error is a *LoadError.
+func loadLocation(name string, sources []string) (*Location, error) {
+	var s zoneSearch
+	if z := s.loadFrom(name, sources); z != nil {
+		return z, nil
+	}
+	if z := s.loadEmbedded(name); z != nil {
+		return z, nil
+	}
+	return nil, s.error(name)
+}
+
+// loadFrom returns the Location with the given name from the first
+// of sources that has it, recording in s why the others did not.
+func (s *zoneSearch) loadFrom(name string, sources []string) *Location {
+	for _, source := range sources {
+		zoneData, release, err := loadTzinfo(name, source)
+		countMetric(metricRead)
+		if err == nil {
+			var z *Location
+			z, err = LoadLocationFromTZData(name, zoneData)
+			if release != nil {
+				release()
+			}
+			if err == nil {
+				z.source = zoneDirSource(source, name)
+				return z
+			}
+		}
+		s.fail(source, err)
+	}
+	return nil
+}
+
+// loadEmbedded returns the Location with the given name from the
+// copy of the database embedded by time/tzdata, if any.
+func (s *zoneSearch) loadEmbedded(name string) *Location {
+	// Last resort: the copy of the database embedded by time/tzdata.
+	// 最后尝试 time/tzdata 内嵌的时区数据库
+	if loadFromEmbeddedTZData == nil {
+		return nil
+	}
+	zoneData, err := loadFromEmbeddedTZData(name)
+	if err == nil {
+		var z *Location
+		if z, err = LoadLocationFromTZData(name, []byte(zoneData)); err == nil {
+			z.source = LocationSource{Kind: SourceEmbedded, File: name}
+			return z
+		}
+	}
+	s.fail(embeddedZoneLabel, err)
+	return nil
+}
+
+// readFile reads and returns the content of the named file.
+// It is a trivial implementation of ioutil.ReadFile, reimplemented
+// here to avoid depending on io/ioutil or os.
+// It returns an error if name exceeds maxFileSize bytes.
+func readFile(name string) ([]byte, error) {
+	f, err := open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer closefd(f)
+	return readFd(f, name)
+}
+
+// readFd reads and returns the content of the open file f, named name.
+func readFd(f uintptr, name string) ([]byte, error) {
+	var (
+		buf [4096]byte
+		ret []byte
+		n   int
+		err error
+	)
+	for {
+		n, err = read(f, buf[:])
+		if n > 0 {
+			ret = append(ret, buf[:n]...)
+		}
+		if n == 0 || err != nil {
+			break
+		}
+		if len(ret) > maxFileSize {
+			return nil, fileSizeError(name)
+		}
+	}
+	return ret, err
+}
+
+// errZoneDataTooLarge is returned by LoadLocationFromReader when r
+// yields more than maxFileSize bytes.
+var errZoneDataTooLarge = errors.New("time: zone data is too large")
+
+// LoadLocationFromReader returns a Location with the given name
+// initialized from the IANA Time Zone database-formatted data read
+// from r until EOF, such as a network stream or a database blob.
+// It is like LoadLocationFromTZData but does not need the data up front.
+// 从任意 io.Reader 读取 TZif 数据并构建 Location
+//
+// An error from r other than io.EOF is returned as is.
+// Readers yielding more than 10 MB are rejected.
+func LoadLocationFromReader(name string, r io.Reader) (*Location, error) {
+	var (
+		buf [4096]byte
+		ret []byte
+	)
+	for {
+		n, err := r.Read(buf[:])
+		if n > 0 {
+			ret = append(ret, buf[:n]...)
+			if len(ret) > maxFileSize {
+				return nil, errZoneDataTooLarge
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return LoadLocationFromTZData(name, ret)
+}
@@ -0,0 +1,135 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// localReloaded, if not nil, is the *Location that replaced the data
+// of localLoc after the system time zone changed, or that SetLocal
+// installed. Location.get returns it in place of &localLoc, so a Time
+// in Local switches to the new data as a whole.
+var localReloaded unsafe.Pointer
+
+// initLocal sets up localLoc, on the first use of Local, with the
+// Location that readLocal returns. Each system provides readLocal,
+// which also returns a summary of the settings it read the zone
+// from, such as the TZ variable and the contents of /etc/localtime,
+// that changes when they do.
+func initLocal() {
+	z, state := readLocal()
+	localLoc = *z
+	localState = state
+}
+
+// localState is the state returned by readLocal for the data
+// currently in Local. After initLocal, it is guarded by localStateMu.
+var (
+	localStateMu sync.Mutex
+	localState   string
+)
+
+// localReloadInterval is how often the watcher started by
+// EnableLocalAutoReload checks the system time zone.
+const localReloadInterval = 10 * Second
+
+var localWatchOnce sync.Once
+
+// EnableLocalAutoReload starts watching the system time zone, so that
+// Local follows changes to /etc/localtime or to the TZ environment
+// variable made while the program runs. Changes are noticed within
+// about ten seconds. Without it, Local keeps the zone in effect when
+// it was first used, which suits most programs but not long-running
+// daemons whose administrator changes the system zone.
+// 开启后台检查，系统时区改变后自动重新加载 Local，适合长期运行的守护进程
+//
+// Times already created in Local use the new data from then on.
+// Calling EnableLocalAutoReload more than once has no further effect.
+func EnableLocalAutoReload() {
+	localWatchOnce.Do(func() {
+		localOnce.Do(initLocal)
+		go watchLocal()
+	})
+}
+
+// watchLocal polls the system time zone forever.
+func watchLocal() {
+	for {
+		Sleep(localReloadInterval)
+		reloadLocal(false)
+	}
+}
+
+// ReloadLocal reads the system time zone again, from the TZ
+// environment variable and the settings of the system, such as
+// /etc/localtime, and makes it the local time zone, for programs that
+// change TZ with os.Setenv or know that the system zone has changed.
+// Times already in Local use the new data from then on. It also undoes
+// SetLocal.
+// 立即重新读取系统时区（TZ 环境变量和 /etc/localtime）并替换 Local
+//
+// It reports whether the settings differ from those Local was last
+// read from.
+func ReloadLocal() bool {
+	localOnce.Do(initLocal)
+	return reloadLocal(true)
+}
+
+// ReinitLocal sets up Local again as on its first use, for tests
+// that change TZ with os.Setenv, or the sources of time zone data, and
+// need Local to follow. The new zone replaces the old atomically:
+// every Time in Local, on every goroutine, reports either the old zone
+// or the new one, never a mixture of the two. Like ReloadLocal, it
+// undoes SetLocal.
+// 重新初始化 Local，测试中修改 TZ 后无需重启进程
+func ReinitLocal() {
+	ReloadLocal()
+}
+
+// reloadLocal reads the system time zone and, if its settings changed
+// or force is set, publishes it in localReloaded. It reports whether
+// the settings changed.
+func reloadLocal(force bool) bool {
+	localStateMu.Lock()
+	defer localStateMu.Unlock()
+	loc, s := readLocal()
+	changed := s != localState
+	if changed || force {
+		localState = s
+		atomic.StorePointer(&localReloaded, unsafe.Pointer(loc))
+	}
+	return changed
+}
+
+// SetLocal makes the zone of loc the local time zone, for programs
+// that let the user choose the zone in which times are displayed.
+// Unlike assigning to Local, which races with its first use, it is
+// safe to call at any time and from any goroutine; Times already in
+// Local, and those made later by Time.Local, Now and Unix, use the
+// zone of loc from then on. Local keeps its name, "Local".
+// 安全地替换本地时区，已经在 Local 中的 Time 也会使用新的时区
+//
+// A later change of the system time zone noticed by
+// EnableLocalAutoReload, and ReloadLocal, replace the zone set by
+// SetLocal.
+// SetLocal(nil) sets UTC, as a nil *Location means UTC.
+func SetLocal(loc *Location) {
+	l := loc.get()
+	c := &Location{
+		name:   "Local",
+		zone:   l.zone,
+		tx:     l.tx,
+		lazy:   l.lazy,
+		extend: l.extend,
+		leap:   l.leap,
+		source: l.source,
+		cache:  atomic.LoadPointer(&l.cache),
+	}
+	localOnce.Do(initLocal)
+	atomic.StorePointer(&localReloaded, unsafe.Pointer(c))
+}
@@ -0,0 +1,153 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "errors"
+
+// A LocalTimeKind says how often a wall clock time occurs in a
+// Location: once, never because a transition skipped it, as 2:30am
+// on the day daylight saving time starts, or twice because a
+// transition repeated it, as 1:30am on the day it ends.
+// 墙上时间在某个时区中出现的次数：一次、被跳过、或重复两次
+type LocalTimeKind int
+
+const (
+	LocalTimeUnique   LocalTimeKind = iota // occurs exactly once
+	LocalTimeSkipped                       // falls in a gap
+	LocalTimeRepeated                      // falls in an overlap
+)
+
+func (k LocalTimeKind) String() string {
+	switch k {
+	case LocalTimeUnique:
+		return "unique"
+	case LocalTimeSkipped:
+		return "skipped"
+	case LocalTimeRepeated:
+		return "repeated"
+	}
+	return "%!LocalTimeKind(" + string(appendInt64(nil, int64(k))) + ")"
+}
+
+// A ResolvePolicy tells ResolveLocal what to do with a wall clock
+// time that is skipped or repeated.
+type ResolvePolicy int
+
+const (
+	// ResolveEarlier uses the offset in effect before the transition.
+	// A repeated time resolves to its first occurrence; a skipped
+	// time is moved forward by the length of the gap, so 2:30am
+	// becomes 3:30am when the clocks go from 2am to 3am.
+	ResolveEarlier ResolvePolicy = iota
+
+	// ResolveLater uses the offset in effect after the transition.
+	// A repeated time resolves to its second occurrence; a skipped
+	// time is moved back by the length of the gap, so 2:30am
+	// becomes 1:30am when the clocks go from 2am to 3am.
+	ResolveLater
+
+	// ResolveStrict rejects skipped and repeated times with
+	// ErrSkippedLocalTime and ErrRepeatedLocalTime.
+	ResolveStrict
+)
+
+// Errors returned by ResolveLocal with ResolveStrict.
+var (
+	ErrSkippedLocalTime  = errors.New("time: local time skipped by a zone transition")
+	ErrRepeatedLocalTime = errors.New("time: local time repeated by a zone transition")
+)
+
+// ResolveLocal is like Date but reports whether the wall clock time
+// was skipped or repeated by a transition in loc, such as a change
+// to or from daylight saving time, and resolves it according to
+// policy instead of arbitrarily. With ResolveStrict and a skipped or
+// repeated time, it returns the zero Time and an error.
+//
+// The values are normalized as for Date, and the kind reported is
+// that of the normalized time.
+//
+// ResolveLocal panics if loc is nil.
+func ResolveLocal(year int, month Month, day, hour, min, sec, nsec int, loc *Location, policy ResolvePolicy) (Time, LocalTimeKind, error) {
+	if loc == nil {
+		panic("time: missing Location in call to ResolveLocal")
+	}
+
+	// The wall time read as UTC, normalized as Date does.
+	w := Date(year, month, day, hour, min, sec, nsec, UTC)
+	unix := w.unixSec()
+
+	// Every instant showing this wall time is within a day or so of
+	// unix, as no offset is larger than that. Walk the zone periods
+	// of that window, keeping the offsets that give this wall time
+	// within their own period.
+	const window = 26 * secondsPerHour
+	var (
+		offsets [2]int // matching offsets, earliest instant first
+		n       int
+		before  int // for a gap: the offset before it
+		after   int // for a gap: the offset after it
+		gap     bool
+		prevOff int
+		prevEnd int64
+		first   = true
+	)
+	for at, i := unix-window, 0; i < 16; i++ {
+		_, offset, _, start, end := loc.lookup(at)
+		utc := unix - int64(offset)
+		if start <= utc && utc < end && n < len(offsets) {
+			offsets[n] = offset
+			n++
+		}
+		if !first && n == 0 && unix-int64(prevOff) >= prevEnd && utc < start {
+			// The wall time is past the end of the previous period
+			// on its clock but before the start of this one on its
+			// clock: the transition at start skipped it.
+			gap, before, after = true, prevOff, offset
+		}
+		first = false
+		prevOff, prevEnd = offset, end
+		if end >= unix+window || end == omega {
+			break
+		}
+		at = end
+	}
+
+	var kind LocalTimeKind
+	var offset int
+	switch {
+	case n == 2:
+		kind = LocalTimeRepeated
+		offset = offsets[0]
+		if policy == ResolveLater {
+			offset = offsets[1]
+		}
+	case n == 1:
+		kind = LocalTimeUnique
+		offset = offsets[0]
+	case gap:
+		kind = LocalTimeSkipped
+		offset = before
+		if policy == ResolveLater {
+			offset = after
+		}
+	default:
+		// Should not happen: every wall time either appears or is
+		// skipped. Fall back to what Date does.
+		t := Date(year, month, day, hour, min, sec, nsec, loc)
+		return t, LocalTimeUnique, nil
+	}
+
+	if policy == ResolveStrict {
+		switch kind {
+		case LocalTimeSkipped:
+			return Time{}, kind, ErrSkippedLocalTime
+		case LocalTimeRepeated:
+			return Time{}, kind, ErrRepeatedLocalTime
+		}
+	}
+	t := unixTime(unix-int64(offset), w.nsec())
+	t.setLoc(loc)
+	return t, kind, nil
+}
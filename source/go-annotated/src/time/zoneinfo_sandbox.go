@@ -0,0 +1,54 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "sync/atomic"
+
+// zoneSandbox is 1 when time zone data may not be read from files.
+// The timesandbox build tag sets it at startup.
+var zoneSandbox int32
+
+// SetZoneSandbox sets whether the package may read time zone data
+// from the file system. In sandbox mode LoadLocation consults only
+// the sources added by RegisterZoneSource and the database embedded
+// by package time/tzdata, and never opens a file, for programs
+// running under seccomp or similar filters where an unexpected open
+// is fatal. AvailableZones and ZoneMetadata report that no database
+// was found.
+// 沙箱模式：只使用内存中的时区数据，不访问文件系统
+//
+// The local time zone is normally read when it is first used.
+// In sandbox mode it comes from $TZ alone, as a POSIX TZ string or
+// a zone in a registered source or the embedded database, and is UTC
+// otherwise. To be sure that no file is opened, call SetZoneSandbox
+// before Local is first used, or build with the timesandbox build
+// tag, which turns sandbox mode on from the start.
+//
+// Turning sandbox mode on or off clears the cache of LoadLocation, so
+// that zones it found or failed to find in one mode are looked up
+// again in the other.
+func SetZoneSandbox(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	if atomic.SwapInt32(&zoneSandbox, v) != v {
+		ClearLocationCache()
+	}
+}
+
+// zoneSandboxed reports whether sandbox mode is on.
+func zoneSandboxed() bool {
+	return atomic.LoadInt32(&zoneSandbox) != 0
+}
+
+// systemZoneSources returns the system time zone database
+// locations, or none in sandbox mode.
+func systemZoneSources() []string {
+	if zoneSandboxed() {
+		return nil
+	}
+	return currentZoneSources()
+}
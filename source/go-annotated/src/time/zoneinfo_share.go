@@ -0,0 +1,199 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "sync"
+
+// Many zones have the same tables: links such as "Asia/Calcutta" and
+// "Asia/Kolkata" are the same file, and zones that have followed the
+// same rules since 1970 have the same transitions. LoadLocationFromTZData
+// looks up the tables it parsed in sharedTables by a hash of their
+// content and uses the copy already there, so that a program loading
+// every zone keeps one copy of each distinct table.
+// 相同内容的时区表只保留一份，按内容哈希共享
+//
+// The tables of a Location are never modified once it is built, which
+// makes sharing them safe.
+var sharedTables struct {
+	sync.Mutex
+	zones map[uint64][][]zone
+	txs   map[uint64][][]zoneTrans
+	leaps map[uint64][][]leapSecond
+	n     int // tables held, across the three maps
+}
+
+// maxSharedTables bounds sharedTables, so that a program parsing many
+// different tzfiles with LoadLocationFromTZData does not hold on to
+// all of them. The IANA database has fewer than 1000 distinct tables.
+const maxSharedTables = 4096
+
+// FNV-1a, 64 bits.
+const (
+	fnvOffset = 14695981039346656037
+	fnvPrime  = 1099511628211
+)
+
+func fnvInt(h uint64, v int64) uint64 {
+	for i := uint(0); i < 64; i += 8 {
+		h ^= uint64(v>>i) & 0xff
+		h *= fnvPrime
+	}
+	return h
+}
+
+func fnvBool(h uint64, b bool) uint64 {
+	if b {
+		return fnvInt(h, 1)
+	}
+	return fnvInt(h, 0)
+}
+
+func hashZones(zs []zone) uint64 {
+	h := uint64(fnvOffset)
+	for i := range zs {
+		z := &zs[i]
+		for j := 0; j < len(z.name); j++ {
+			h ^= uint64(z.name[j])
+			h *= fnvPrime
+		}
+		h = fnvInt(h, int64(z.offset))
+		h = fnvBool(h, z.isDST)
+	}
+	return h
+}
+
+func hashTxs(txs []zoneTrans) uint64 {
+	h := uint64(fnvOffset)
+	for i := range txs {
+		tx := &txs[i]
+		h = fnvInt(h, tx.when)
+		h = fnvInt(h, int64(tx.index))
+		h = fnvBool(h, tx.isstd)
+		h = fnvBool(h, tx.isutc)
+	}
+	return h
+}
+
+func hashLeaps(ls []leapSecond) uint64 {
+	h := uint64(fnvOffset)
+	for _, l := range ls {
+		h = fnvInt(h, l.when)
+		h = fnvInt(h, l.corr)
+	}
+	return h
+}
+
+func equalZones(a, b []zone) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalTxs(a, b []zoneTrans) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalLeaps(a, b []leapSecond) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// shareTables returns the shared copies of the given tables, adding
+// those not seen before to sharedTables. Empty tables are returned
+// as they are.
+func shareTables(zs []zone, txs []zoneTrans, ls []leapSecond) ([]zone, []zoneTrans, []leapSecond) {
+	var hz, ht, hl uint64
+	if len(zs) > 0 {
+		hz = hashZones(zs)
+	}
+	if len(txs) > 0 {
+		ht = hashTxs(txs)
+	}
+	if len(ls) > 0 {
+		hl = hashLeaps(ls)
+	}
+
+	sharedTables.Lock()
+	defer sharedTables.Unlock()
+	if sharedTables.zones == nil {
+		sharedTables.zones = make(map[uint64][][]zone)
+		sharedTables.txs = make(map[uint64][][]zoneTrans)
+		sharedTables.leaps = make(map[uint64][][]leapSecond)
+	}
+	full := sharedTables.n >= maxSharedTables
+
+	if len(zs) > 0 {
+		found := false
+		for _, s := range sharedTables.zones[hz] {
+			if equalZones(s, zs) {
+				zs, found = s, true
+				break
+			}
+		}
+		if !found && !full {
+			sharedTables.zones[hz] = append(sharedTables.zones[hz], zs)
+			sharedTables.n++
+		}
+	}
+	if len(txs) > 0 {
+		found := false
+		for _, s := range sharedTables.txs[ht] {
+			if equalTxs(s, txs) {
+				txs, found = s, true
+				break
+			}
+		}
+		if !found && !full {
+			sharedTables.txs[ht] = append(sharedTables.txs[ht], txs)
+			sharedTables.n++
+		}
+	}
+	if len(ls) > 0 {
+		found := false
+		for _, s := range sharedTables.leaps[hl] {
+			if equalLeaps(s, ls) {
+				ls, found = s, true
+				break
+			}
+		}
+		if !found && !full {
+			sharedTables.leaps[hl] = append(sharedTables.leaps[hl], ls)
+			sharedTables.n++
+		}
+	}
+	return zs, txs, ls
+}
+
+// clearSharedTables empties sharedTables, so that the tables of a
+// replaced database are not held once no Location uses them.
+func clearSharedTables() {
+	sharedTables.Lock()
+	sharedTables.zones = nil
+	sharedTables.txs = nil
+	sharedTables.leaps = nil
+	sharedTables.n = 0
+	sharedTables.Unlock()
+}
@@ -0,0 +1,87 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "sync"
+
+// A ZoneSource provides time zone data to LoadLocation, for example
+// from an internal service or a database.
+// 自定义的时区数据来源
+type ZoneSource interface {
+	// ZoneData returns the contents of the IANA Time Zone database
+	// file (TZif) for the named zone, such as "America/New_York".
+	// If the source does not know the zone, it returns nil, nil.
+	// ZoneData may be called concurrently from multiple goroutines.
+	ZoneData(name string) ([]byte, error)
+}
+
+var (
+	zoneSourcesMu         sync.Mutex
+	registeredZoneSources []ZoneSource
+)
+
+// RegisterZoneSource adds src to the sources that LoadLocation
+// consults. Registered sources are tried in the order they were
+// registered, after the ZONEINFO environment variable and before
+// the system time zone database.
+// 注册的数据源优先于系统时区数据库
+//
+// The local time zone is looked up in the same sources, on every
+// platform, when it is named by $TZ or by the host.
+//
+// RegisterZoneSource is usually called from an init function.
+// Locations already returned are not affected, but it clears the
+// cache of LoadLocation so that later calls consult src.
+func RegisterZoneSource(src ZoneSource) {
+	if src == nil {
+		panic("time: RegisterZoneSource called with nil source")
+	}
+	zoneSourcesMu.Lock()
+	defer zoneSourcesMu.Unlock()
+	// Copy so that loaders holding the old slice are unaffected.
+	srcs := make([]ZoneSource, len(registeredZoneSources), len(registeredZoneSources)+1)
+	copy(srcs, registeredZoneSources)
+	registeredZoneSources = append(srcs, src)
+	ClearLocationCache()
+}
+
+// loadFromZoneSources returns the Location with the given name from
+// the first registered source that has it, recording in s why the
+// others did not.
+func (s *zoneSearch) loadFromZoneSources(name string) *Location {
+	zoneSourcesMu.Lock()
+	srcs := registeredZoneSources
+	zoneSourcesMu.Unlock()
+
+	for _, src := range srcs {
+		data, err := readZoneSource(src, name)
+		countMetric(metricRead)
+		if err == nil && data == nil {
+			err = ErrUnknownZone
+		}
+		if err == nil {
+			data, err = decompressZoneData(data)
+		}
+		if err == nil {
+			var z *Location
+			if z, err = LoadLocationFromTZData(name, data); err == nil {
+				z.source = LocationSource{Kind: SourceZoneSource, File: name}
+				return z
+			}
+		}
+		s.fail(zoneSourceLabel, err)
+	}
+	return nil
+}
+
+// readZoneSource calls src.ZoneData, reporting the read to the
+// load tracer.
+func readZoneSource(src ZoneSource, name string) (data []byte, err error) {
+	if trace := loadTracer(); trace != nil {
+		start := runtimeNano()
+		defer func() { trace(traceRead, name, zoneSourceLabel, len(data), runtimeNano()-start, err) }()
+	}
+	return src.ZoneData(name)
+}
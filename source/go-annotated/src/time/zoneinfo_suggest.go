@@ -0,0 +1,190 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "sync"
+
+// maxSuggestions is the number of zone names suggested
+// for an unknown zone.
+const maxSuggestions = 3
+
+// maxSuggestName bounds the length of the unknown names for which
+// zones are suggested. The longest IANA names are about 30 bytes;
+// longer names are not misspellings worth the comparisons.
+const maxSuggestName = 64
+
+// suggestList holds the zone names that suggestZones compares with,
+// listed once per generation of the LoadLocation cache, so that a
+// stream of distinct unknown names does not walk the database for
+// each of them.
+var suggestList struct {
+	sync.Mutex
+	gen   uint64
+	valid bool
+	zones []string
+}
+
+// suggestZoneNames returns the names listed by AvailableZones since
+// the LoadLocation cache was last cleared, or nil if there are none.
+func suggestZoneNames() []string {
+	c := &locationCache
+	c.Lock()
+	gen := c.gen
+	c.Unlock()
+
+	s := &suggestList
+	s.Lock()
+	defer s.Unlock()
+	if !s.valid || s.gen != gen {
+		zones, err := AvailableZones()
+		if err != nil {
+			zones = nil
+		}
+		s.zones, s.gen, s.valid = zones, gen, true
+	}
+	return s.zones
+}
+
+// suggestZones returns the names listed by AvailableZones that are
+// closest to name, closest first, for an unknown zone name that may
+// be misspelled, such as "America/Los_Angles".
+// 时区名拼错时，给出最接近的几个时区名
+//
+// Names are compared ignoring case, by edit distance. A name with
+// no slash is also compared with the last component of each zone,
+// so "Kolkata" suggests "Asia/Kolkata". Only names at most three
+// edits, and a third of the length of name, away are suggested.
+// Names longer than maxSuggestName get no suggestions.
+func suggestZones(name string) []string {
+	if len(name) > maxSuggestName {
+		return nil
+	}
+	limit := len(name) / 3
+	if limit > 3 {
+		limit = 3
+	}
+	zones := suggestZoneNames()
+	hasSlash := false
+	for i := 0; i < len(name); i++ {
+		if name[i] == '/' {
+			hasSlash = true
+			break
+		}
+	}
+
+	var row []int
+	var best []string
+	var dist []int
+	for _, z := range zones {
+		var d int
+		d, row = editDistance(name, z, limit, row)
+		if !hasSlash {
+			if i := lastSlash(z); i >= 0 {
+				var d1 int
+				if d1, row = editDistance(name, z[i+1:], limit, row); d1 < d {
+					d = d1
+				}
+			}
+		}
+		if d > limit || (len(best) == maxSuggestions && d >= dist[len(dist)-1]) {
+			continue
+		}
+		// Insert in order of distance; zones is sorted,
+		// so ties stay in name order.
+		i := len(best)
+		for i > 0 && dist[i-1] > d {
+			i--
+		}
+		if len(best) < maxSuggestions {
+			best = append(best, "")
+			dist = append(dist, 0)
+		}
+		copy(best[i+1:], best[i:])
+		copy(dist[i+1:], dist[i:])
+		best[i], dist[i] = z, d
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b,
+// ignoring ASCII case, or max+1 if the distance is greater than max.
+// It uses row as scratch space, and returns it, grown if needed, for
+// the next call.
+//
+// Only the cells of the table within max of its diagonal are
+// computed, and the comparison stops at the first row whose cells
+// all exceed max, so that most zones cost a few steps.
+func editDistance(a, b string, max int, row []int) (int, []int) {
+	if len(a)-len(b) > max || len(b)-len(a) > max {
+		return max + 1, row
+	}
+	n := len(b) + 1
+	if cap(row) < 2*n {
+		row = make([]int, 2*n)
+	}
+	row = row[:2*n]
+	prev, cur := row[:n], row[n:]
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		// Cells outside the band hold max+1, which is no more than
+		// their distance and enough to rule out paths through them.
+		lo, hi := i-max, i+max
+		if lo < 1 {
+			lo = 1
+			cur[0] = i
+		} else {
+			cur[lo-1] = max + 1
+		}
+		if hi > len(b) {
+			hi = len(b)
+		}
+		least := cur[lo-1]
+		for j := lo; j <= hi; j++ {
+			v := prev[j-1]
+			if lowerASCII(a[i-1]) != lowerASCII(b[j-1]) {
+				v++
+			}
+			if w := prev[j] + 1; w < v {
+				v = w
+			}
+			if w := cur[j-1] + 1; w < v {
+				v = w
+			}
+			cur[j] = v
+			if v < least {
+				least = v
+			}
+		}
+		if hi < len(b) {
+			cur[hi+1] = max + 1
+		}
+		if least > max {
+			return max + 1, row
+		}
+		prev, cur = cur, prev
+	}
+	if d := prev[len(b)]; d <= max {
+		return d, row
+	}
+	return max + 1, row
+}
+
+func lowerASCII(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// lastSlash returns the index of the last '/' in s, or -1.
+func lastSlash(s string) int {
+	i := len(s) - 1
+	for i >= 0 && s[i] != '/' {
+		i--
+	}
+	return i
+}
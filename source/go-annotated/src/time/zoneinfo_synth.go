@@ -0,0 +1,102 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// Zones that are a fixed offset from UTC need no data: LoadLocation
+// builds them when no time zone database has them, as on minimal
+// containers, so that they load everywhere.
+// 固定偏移的时区不需要时区数据库，找不到文件时直接构造
+
+// utcZoneNames are the names of the database for UTC, and the
+// abbreviation each uses.
+var utcZoneNames = map[string]string{
+	"Etc/UTC": "UTC", "Etc/UCT": "UTC", "Etc/Universal": "UTC", "Etc/Zulu": "UTC",
+	"UCT": "UTC", "Universal": "UTC", "Zulu": "UTC",
+	"Etc/GMT": "GMT", "Etc/GMT0": "GMT", "Etc/GMT+0": "GMT", "Etc/GMT-0": "GMT", "Etc/Greenwich": "GMT",
+	"GMT": "GMT", "GMT0": "GMT", "GMT+0": "GMT", "GMT-0": "GMT", "Greenwich": "GMT",
+}
+
+// syntheticZone returns the Location for name if it is one of the
+// fixed-offset zones that can be built without data:
+//	- the names of UTC, such as "Etc/UTC" and "Etc/GMT";
+//	- "Etc/GMT-14" to "Etc/GMT+12", in which, as in POSIX TZ strings,
+//	  the sign is that of the offset west of UTC: Etc/GMT+5 is five
+//	  hours behind UTC, and its abbreviation is "-05";
+//	- "UTC+05:30", "UTC-03:00" and the like, from UTC-12:00 to
+//	  UTC+14:00, in which the sign is that of the offset east of UTC,
+//	  as in ISO 8601 and RFC 3339. As a TZ string, "UTC+05:30" would
+//	  be five and a half hours behind UTC, which is not what anybody
+//	  writing it means.
+func syntheticZone(name string) (*Location, bool) {
+	abbrev, offset, ok := syntheticOffset(name)
+	if !ok {
+		return nil, false
+	}
+	return synthLocation(name, abbrev, offset), true
+}
+
+// syntheticOffset returns the zone abbreviation and offset of name if
+// syntheticZone can build it, without building it.
+func syntheticOffset(name string) (abbrev string, offset int, ok bool) {
+	if abbrev, ok := utcZoneNames[name]; ok {
+		return abbrev, 0, true
+	}
+
+	const etc = "Etc/GMT"
+	if len(name) > len(etc)+1 && name[:len(etc)] == etc {
+		s := name[len(etc):]
+		h, rest, err := getnum(s[1:], false)
+		if err != nil || rest != "" || s[1] == '0' {
+			return "", 0, false
+		}
+		switch {
+		case s[0] == '+' && h <= 12:
+			h = -h
+		case s[0] == '-' && h <= 14:
+		default:
+			return "", 0, false
+		}
+		return offsetAbbrev(h * secondsPerHour), h * secondsPerHour, true
+	}
+
+	// UTC±HH:MM
+	if len(name) == 9 && name[:3] == "UTC" && (name[3] == '+' || name[3] == '-') && name[6] == ':' &&
+		isDigit(name, 4) && isDigit(name, 5) && isDigit(name, 7) && isDigit(name, 8) {
+		h := int(name[4]-'0')*10 + int(name[5]-'0')
+		m := int(name[7]-'0')*10 + int(name[8]-'0')
+		offset := h*secondsPerHour + m*secondsPerMinute
+		if name[3] == '-' {
+			offset = -offset
+		}
+		if m > 59 || offset < -12*secondsPerHour || offset > 14*secondsPerHour {
+			return "", 0, false
+		}
+		return offsetAbbrev(offset), offset, true
+	}
+	return "", 0, false
+}
+
+// synthLocation returns a fixed Location with the given name, zone
+// abbreviation and offset.
+func synthLocation(name, abbrev string, offset int) *Location {
+	l := fixedZone(abbrev, offset)
+	l.name = name
+	return l
+}
+
+// offsetAbbrev returns the abbreviation the database uses for a zone
+// with no name of its own: "+05", or "+0530" if there are minutes.
+func offsetAbbrev(offset int) string {
+	b := []byte{'+'}
+	if offset < 0 {
+		b[0] = '-'
+		offset = -offset
+	}
+	b = appendInt(b, offset/secondsPerHour, 2)
+	if m := offset % secondsPerHour / secondsPerMinute; m != 0 {
+		b = appendInt(b, m, 2)
+	}
+	return string(b)
+}
@@ -0,0 +1,189 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"errors"
+	"sync"
+	"syscall"
+)
+
+// A ZoneTabEntry is the line of the zone1970.tab file of the IANA
+// Time Zone database that describes a zone.
+// zone1970.tab 中一个时区的信息：国家代码、经纬度和说明
+type ZoneTabEntry struct {
+	Name string // zone name, such as "Europe/Paris"
+
+	// Countries lists the ISO 3166 alpha-2 codes of the countries
+	// that use the zone, the most populous first, such as ["FR", "MC"].
+	Countries []string
+
+	// Latitude and Longitude give the location of the zone's
+	// principal city in degrees, positive north and east.
+	Latitude, Longitude float64
+
+	// Comments distinguishes zones in the same country, such as
+	// "Eastern (most areas)". It is empty if there is only one.
+	Comments string
+}
+
+var (
+	zoneTabOnce sync.Once
+	zoneTab     map[string]*ZoneTabEntry
+	zoneTabErr  error
+)
+
+// ZoneMetadata returns the zone1970.tab entry of the named zone,
+// such as "America/New_York". User interfaces can use it to group
+// zones by country or to place them on a map.
+// 返回时区的国家代码和经纬度，可用于按国家分组时区
+//
+// The table is read once, from the first of the sources LoadLocation
+// uses that has it. Only canonical zone names are listed: aliases
+// such as "US/Eastern" and zones such as "UTC" are not, and for them
+// ZoneMetadata returns an error.
+func ZoneMetadata(name string) (*ZoneTabEntry, error) {
+	zoneTabOnce.Do(loadZoneTab)
+	if zoneTabErr != nil {
+		return nil, zoneTabErr
+	}
+	e, ok := zoneTab[name]
+	if !ok {
+		return nil, errors.New("time: zone " + name + " not listed in zone1970.tab")
+	}
+	// Copy, so that callers cannot change the table.
+	c := *e
+	c.Countries = append([]string(nil), e.Countries...)
+	return &c, nil
+}
+
+// loadZoneTab reads and parses zone1970.tab into zoneTab.
+func loadZoneTab() {
+	data, err := readZoneTabFile("zone1970.tab")
+	if err != nil {
+		zoneTabErr = err
+		return
+	}
+	zoneTab, zoneTabErr = parseZoneTab(data)
+}
+
+// readZoneTabFile reads one of the table files of the time zone
+// database, such as zone1970.tab, from the first of the sources
+// LoadLocation uses that has it.
+func readZoneTabFile(file string) ([]byte, error) {
+	sources := append(zoneinfoDirs(), systemZoneSources()...)
+
+	var firstErr error
+	for _, source := range sources {
+		if len(source) >= 6 && source[len(source)-6:] == "tzdata" {
+			// Android's tzdata file holds only zone.tab.
+			continue
+		}
+		data, err := loadTzinfoFromDirOrZip(source, file)
+		if err == nil {
+			return data, nil
+		}
+		if firstErr == nil && err != syscall.ENOENT && err != ErrUnknownZone {
+			firstErr = err
+		}
+	}
+	if firstErr == nil {
+		firstErr = errors.New("time: " + file + " not found")
+	}
+	return nil, firstErr
+}
+
+var errZoneTab = errors.New("time: malformed zone1970.tab")
+
+// parseZoneTab parses the contents of zone1970.tab. Each line that is
+// not a comment has tab-separated fields:
+//	codes coordinates TZ [comments]
+func parseZoneTab(data []byte) (map[string]*ZoneTabEntry, error) {
+	tab := make(map[string]*ZoneTabEntry)
+	for len(data) > 0 {
+		var line []byte
+		line, data = cutByte(data, '\n')
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		var codes, coords, name, comments []byte
+		codes, line = cutByte(line, '\t')
+		coords, line = cutByte(line, '\t')
+		name, comments = cutByte(line, '\t')
+		if len(codes) == 0 || len(name) == 0 {
+			return nil, errZoneTab
+		}
+
+		e := &ZoneTabEntry{Name: string(name), Comments: string(comments)}
+		for len(codes) > 0 {
+			var code []byte
+			code, codes = cutByte(codes, ',')
+			e.Countries = append(e.Countries, string(code))
+		}
+		var ok bool
+		if e.Latitude, e.Longitude, ok = parseISO6709(string(coords)); !ok {
+			return nil, errZoneTab
+		}
+		tab[e.Name] = e
+	}
+	return tab, nil
+}
+
+// cutByte slices b around the first instance of c, returning the
+// text before and after it. If c does not appear, it returns b, nil.
+func cutByte(b []byte, c byte) (before, after []byte) {
+	for i := range b {
+		if b[i] == c {
+			return b[:i], b[i+1:]
+		}
+	}
+	return b, nil
+}
+
+// parseISO6709 parses coordinates in the form used by zone1970.tab,
+// ±DDMM±DDDMM or ±DDMMSS±DDDMMSS, and returns them in degrees.
+func parseISO6709(s string) (lat, long float64, ok bool) {
+	n := len(s)
+	if n != 11 && n != 15 {
+		return 0, 0, false
+	}
+	// The latitude has 2 digits of degrees, the longitude 3.
+	split := (n - 1) / 2
+	if lat, ok = parseDegrees(s[:split], 2); !ok {
+		return 0, 0, false
+	}
+	if long, ok = parseDegrees(s[split:], 3); !ok {
+		return 0, 0, false
+	}
+	return lat, long, true
+}
+
+// parseDegrees parses a sign, degrees of width digits, minutes and
+// optionally seconds, such as "+4852" or "-0740023".
+func parseDegrees(s string, width int) (float64, bool) {
+	if len(s) < 1+width+2 || s[0] != '+' && s[0] != '-' {
+		return 0, false
+	}
+	var parts [3]int
+	rest := s[1:]
+	for i, w := 0, width; len(rest) > 0; i, w = i+1, 2 {
+		if i == len(parts) || len(rest) < w {
+			return 0, false
+		}
+		for _, c := range []byte(rest[:w]) {
+			if c < '0' || c > '9' {
+				return 0, false
+			}
+			parts[i] = parts[i]*10 + int(c-'0')
+		}
+		rest = rest[w:]
+	}
+	deg := float64(parts[0]) + float64(parts[1])/60 + float64(parts[2])/3600
+	if s[0] == '-' {
+		deg = -deg
+	}
+	return deg, true
+}
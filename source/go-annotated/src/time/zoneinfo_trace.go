@@ -0,0 +1,42 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "sync/atomic"
+
+// Kinds of events reported to the load tracer.
+// These values are shared with package time/timedebug.
+const (
+	traceLoad  = iota // LoadLocation returned
+	traceRead         // zone data read from one source (directory, zip or tzdata file)
+	traceParse        // LoadLocationFromTZData returned
+)
+
+// A loadTraceFunc observes one step of loading a Location:
+// the zone name, the source consulted (empty for traceLoad and traceParse),
+// the number of bytes read or parsed, the elapsed time in nanoseconds,
+// and the resulting error.
+// 记录加载 Location 过程中的每一步：读了哪个源、读了多少字节、解析耗时
+type loadTraceFunc func(kind int, name, source string, size int, elapsed int64, err error)
+
+// loadTraceHolder wraps the tracer because atomic.Value cannot store nil.
+type loadTraceHolder struct {
+	f loadTraceFunc
+}
+
+var loadTrace atomic.Value // of loadTraceHolder
+
+// loadTracer returns the installed tracer, or nil.
+// The common untraced case costs a single atomic load.
+func loadTracer() loadTraceFunc {
+	h, _ := loadTrace.Load().(loadTraceHolder)
+	return h.f
+}
+
+// setLoadTracer installs f as the load tracer; nil removes it.
+// It is called from package time/timedebug through go:linkname.
+func setLoadTracer(f func(kind int, name, source string, size int, elapsed int64, err error)) {
+	loadTrace.Store(loadTraceHolder{f})
+}
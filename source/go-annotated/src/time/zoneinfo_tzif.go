@@ -0,0 +1,205 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "errors"
+
+// AppendTZif appends to dst the contents of an IANA Time Zone database
+// file (TZif, RFC 8536) describing l, and returns the extended buffer.
+// It is the inverse of LoadLocationFromTZData: loading the result
+// gives a Location that reports the same zones as l at every instant.
+// 把 Location 写回标准的 tzfile，可以交给其他语言或者 zdump 使用
+//
+// The file uses version 2 of the format. Its version 1 data block
+// holds the transitions that fit in 32 bits, for older readers.
+// The name of l is not recorded, and neither are the standard/wall
+// and UTC/local indicators, which do not affect lookups.
+func (l *Location) AppendTZif(dst []byte) ([]byte, error) {
+	l = l.get()
+
+	zones := l.zone
+	extend := l.extend
+	if len(zones) == 0 {
+		// UTC.
+		zones = []zone{{"UTC", 0, false}}
+		extend = "UTC0"
+	}
+	for i := 0; i < len(extend); i++ {
+		if extend[i] == '\n' {
+			return nil, errors.New("Location.AppendTZif: invalid extend rule")
+		}
+	}
+
+	// Drop the fake transition built for fixed locations; the
+	// zone it switches to becomes the zone for the earliest times.
+	tx := l.transitions()
+	first := 0
+	if len(tx) > 0 && tx[0].when == alpha {
+		first = int(tx[0].index)
+		tx = tx[1:]
+	} else if len(l.zone) > 0 {
+		first = l.lookupFirstZone()
+	}
+
+	// Type 0 is the zone used before the first transition. Put a copy
+	// of that zone there unless zone 0 already is, and no transition
+	// refers to it, so readers do not have to guess.
+	// 类型 0 表示第一次转换之前的时区
+	shift := 1
+	if first == 0 {
+		shift = 0
+		for i := range tx {
+			if tx[i].index == 0 {
+				shift = 1
+				break
+			}
+		}
+	}
+	types := make([]zone, 0, len(zones)+shift)
+	if shift == 1 {
+		types = append(types, zones[first])
+	}
+	types = append(types, zones...)
+	if len(types) > 256 {
+		return nil, errors.New("Location.AppendTZif: too many zones")
+	}
+
+	// Time zone abbreviations, each NUL-terminated, shared
+	// between zones with the same name.
+	var chars []byte
+	abbrev := make([]byte, len(types))
+	seen := make(map[string]int)
+	for i := range types {
+		name := types[i].name
+		j, ok := seen[name]
+		if !ok {
+			j = len(chars)
+			seen[name] = j
+			chars = append(chars, name...)
+			chars = append(chars, 0)
+		}
+		if j > 255 {
+			return nil, errors.New("Location.AppendTZif: zone abbreviations too long")
+		}
+		abbrev[i] = byte(j)
+	}
+
+	// Files with a leap second table count leap seconds in their
+	// transition times too; see LoadLocationFromTZData.
+	when := make([]int64, len(tx))
+	for i := range tx {
+		when[i] = tx[i].when + l.leapCorr(tx[i].when)
+	}
+	leap := make([]leapSecond, len(l.leap))
+	for i := range l.leap {
+		leap[i] = l.leap[i]
+		if i > 0 {
+			leap[i].when += l.leap[i-1].corr
+		}
+	}
+
+	w := tzifWriter{
+		types:  types,
+		abbrev: abbrev,
+		chars:  chars,
+		shift:  shift,
+	}
+
+	// Version 1 data block: only the transitions that fit in 32 bits.
+	// If earlier ones are dropped, start with a transition at the
+	// smallest 32-bit time to the zone they led to.
+	const min32, max32 = -1 << 31, 1<<31 - 1
+	lo, hi := 0, len(tx)
+	for lo < hi && when[lo] < min32 {
+		lo++
+	}
+	for hi > lo && when[hi-1] > max32 {
+		hi--
+	}
+	var tx32 []zoneTrans
+	var when32 []int64
+	if lo > 0 {
+		tx32 = append(tx32, tx[lo-1])
+		when32 = append(when32, min32)
+	}
+	tx32 = append(tx32, tx[lo:hi]...)
+	when32 = append(when32, when[lo:hi]...)
+	var leap32 []leapSecond
+	for i := range leap {
+		if min32 <= leap[i].when && leap[i].when <= max32 {
+			leap32 = append(leap32, leap[i])
+		}
+	}
+
+	dst = w.appendBlock(dst, false, tx32, when32, leap32)
+	dst = w.appendBlock(dst, true, tx, when, leap)
+
+	// Footer: the POSIX TZ rule for times after the last transition.
+	dst = append(dst, '\n')
+	dst = append(dst, extend...)
+	dst = append(dst, '\n')
+	return dst, nil
+}
+
+// A tzifWriter holds the tables shared by the data blocks of a TZif file.
+type tzifWriter struct {
+	types  []zone
+	abbrev []byte // index into chars of the name of each type
+	chars  []byte
+	shift  int // added to a zone index to get its type index
+}
+
+// appendBlock appends a header and a data block using 32-bit or,
+// if is64 is set, 64-bit times.
+func (w *tzifWriter) appendBlock(dst []byte, is64 bool, tx []zoneTrans, when []int64, leap []leapSecond) []byte {
+	// 4-byte magic "TZif", 1-byte version, then 15 bytes of padding.
+	dst = append(dst, "TZif2"...)
+	dst = append(dst, make([]byte, 15)...)
+
+	// UTC/local and standard/wall indicator counts, then the
+	// counts of leap seconds, transitions, zones and characters.
+	for _, n := range [6]int{0, 0, len(leap), len(tx), len(w.types), len(w.chars)} {
+		dst = appendBig4(dst, uint32(n))
+	}
+
+	for _, t := range when {
+		if is64 {
+			dst = appendBig8(dst, uint64(t))
+		} else {
+			dst = appendBig4(dst, uint32(t))
+		}
+	}
+	for i := range tx {
+		dst = append(dst, byte(int(tx[i].index)+w.shift))
+	}
+	for i := range w.types {
+		z := &w.types[i]
+		dst = appendBig4(dst, uint32(int32(z.offset)))
+		if z.isDST {
+			dst = append(dst, 1)
+		} else {
+			dst = append(dst, 0)
+		}
+		dst = append(dst, w.abbrev[i])
+	}
+	dst = append(dst, w.chars...)
+	for i := range leap {
+		if is64 {
+			dst = appendBig8(dst, uint64(leap[i].when))
+		} else {
+			dst = appendBig4(dst, uint32(leap[i].when))
+		}
+		dst = appendBig4(dst, uint32(int32(leap[i].corr)))
+	}
+	return dst
+}
+
+func appendBig4(b []byte, n uint32) []byte {
+	return append(b, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+}
+
+func appendBig8(b []byte, n uint64) []byte {
+	return appendBig4(appendBig4(b, uint32(n>>32)), uint32(n))
+}
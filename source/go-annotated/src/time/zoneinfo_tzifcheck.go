@@ -0,0 +1,232 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// A TZifProblem is one problem ValidateTZif found in a TZif file.
+type TZifProblem struct {
+	Block string // "v1" or "v2" for the data block concerned, "" for the file
+	What  string // "header", "transition", "type", "abbrev", "leap", "indicator" or "footer"
+	Index int    // index of the record concerned, or -1
+	Msg   string
+}
+
+func (p TZifProblem) String() string {
+	s := p.What
+	if p.Block != "" {
+		s = p.Block + " " + s
+	}
+	if p.Index >= 0 {
+		s += " " + string(appendInt(nil, p.Index, 0))
+	}
+	return s + ": " + p.Msg
+}
+
+// A TZifError lists the problems ValidateTZif found, in the order of
+// the file.
+type TZifError struct {
+	Problems []TZifProblem
+}
+
+func (e *TZifError) Error() string {
+	s := "time: invalid TZif file: " + e.Problems[0].String()
+	if n := len(e.Problems) - 1; n > 0 {
+		s += " (and " + string(appendInt(nil, n, 0)) + " more)"
+	}
+	return s
+}
+
+// ValidateTZif checks the TZif file in data against RFC 8536, for
+// packagers who build their own time zone data and want to check it
+// before shipping it. It returns nil if the file is valid, the error
+// of ParseTZif if it cannot be decoded, and otherwise a *TZifError
+// listing every problem found:
+//	- counts of records the format requires, or forbids;
+//	- transitions out of order, and type indexes out of range;
+//	- abbreviation indexes out of range, abbreviations that are not
+//	  NUL-terminated or not of 3 to 6 letters, digits, '-' or '+';
+//	- leap second records out of order, less than 28 days apart, or
+//	  changing the correction by other than one second;
+//	- UT indicators set on types whose standard indicator is not;
+//	- version 1 data that disagrees with the 64-bit data;
+//	- a footer that is not a valid POSIX TZ string, or that does not
+//	  agree with the type of the last transition.
+// LoadLocationFromTZData accepts many files that fail these checks.
+// 严格检查 tzfile，列出所有问题，方便打包自定义 tzdata 前验证
+func ValidateTZif(data []byte) error {
+	f, err := ParseTZif(data)
+	if err != nil {
+		return err
+	}
+	var c tzifChecker
+	c.block("v1", &f.V1)
+	if f.V2 != nil {
+		c.block("v2", f.V2)
+		c.consistent(f)
+		c.footer(f)
+	}
+	if len(c.problems) > 0 {
+		return &TZifError{c.problems}
+	}
+	return nil
+}
+
+// A tzifChecker collects the problems of a TZif file.
+type tzifChecker struct {
+	problems []TZifProblem
+}
+
+func (c *tzifChecker) add(block, what string, index int, msg string) {
+	c.problems = append(c.problems, TZifProblem{block, what, index, msg})
+}
+
+// block checks one data block on its own.
+func (c *tzifChecker) block(name string, b *TZifBlock) {
+	h := &b.Header
+	if h.TypeCount == 0 {
+		c.add(name, "header", -1, "no local time types")
+	}
+	if h.CharCount == 0 {
+		c.add(name, "header", -1, "empty abbreviation table")
+	}
+	if h.IsStdCount != 0 && h.IsStdCount != h.TypeCount {
+		c.add(name, "header", -1, "standard/wall indicator count differs from type count")
+	}
+	if h.IsUTCount != 0 && h.IsUTCount != h.TypeCount {
+		c.add(name, "header", -1, "UT/local indicator count differs from type count")
+	}
+
+	for i, when := range b.Transitions {
+		if i > 0 && when <= b.Transitions[i-1] {
+			c.add(name, "transition", i, "not after the previous transition")
+		}
+		if int(b.TransitionTypes[i]) >= len(b.Types) {
+			c.add(name, "transition", i, "type index out of range")
+		}
+	}
+
+	if n := len(b.Abbrevs); n > 0 && b.Abbrevs[n-1] != 0 {
+		c.add(name, "abbrev", -1, "abbreviation table not NUL-terminated")
+	}
+	for i, t := range b.Types {
+		if t.Offset == -1<<31 {
+			c.add(name, "type", i, "offset -2^31 is not allowed")
+		}
+		if int(t.AbbrevIndex) >= len(b.Abbrevs) {
+			c.add(name, "type", i, "abbreviation index out of range")
+		} else if !validTZifAbbrev(t.Abbrev) {
+			c.add(name, "type", i, "invalid abbreviation "+quote(t.Abbrev))
+		}
+	}
+
+	for i, l := range b.Leaps {
+		switch {
+		case i == 0 && l.Occurrence < 0:
+			c.add(name, "leap", i, "before 1970")
+		case i == 0:
+			if l.Correction != 1 && l.Correction != -1 {
+				c.add(name, "leap", i, "first correction is not one second")
+			}
+		case l.Occurrence-b.Leaps[i-1].Occurrence < 28*secondsPerDay-1:
+			c.add(name, "leap", i, "less than 28 days after the previous leap second")
+		case l.Correction-b.Leaps[i-1].Correction != 1 && l.Correction-b.Leaps[i-1].Correction != -1:
+			c.add(name, "leap", i, "correction does not change by one second")
+		}
+	}
+
+	for i := range b.IsUT {
+		if b.IsUT[i] && (i >= len(b.IsStd) || !b.IsStd[i]) {
+			c.add(name, "indicator", i, "UT indicator set without the standard indicator")
+		}
+	}
+}
+
+// validTZifAbbrev reports whether s is an abbreviation of the form
+// RFC 8536 asks for.
+func validTZifAbbrev(s string) bool {
+	if len(s) < 3 || len(s) > 6 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '+') {
+			return false
+		}
+	}
+	return true
+}
+
+// consistent checks that the version 1 block of a file of version 2
+// or later agrees with the 64-bit block: its headers, and the
+// transitions and leap seconds it has, which must be those of the
+// 64-bit block that fit in 32 bits.
+func (c *tzifChecker) consistent(f *TZifFile) {
+	v1, v2 := &f.V1, f.V2
+	if v1.Header.Version != v2.Header.Version {
+		c.add("v2", "header", -1, "version differs from the first header")
+	}
+
+	// Writers may leave the version 1 block empty, as zic -b slim
+	// does; only the transitions it has are compared. A transition at
+	// -2^31, the earliest 32-bit time, stands for the type of the
+	// earlier times the 32-bit data cannot represent.
+	j := 0
+	for i, when := range v1.Transitions {
+		if when == -1<<31 {
+			continue
+		}
+		for j < len(v2.Transitions) && v2.Transitions[j] < when {
+			j++
+		}
+		if j == len(v2.Transitions) || v2.Transitions[j] != when {
+			c.add("v1", "transition", i, "not in the 64-bit data")
+			continue
+		}
+		t1, t2 := tzifTypeAt(v1, i), tzifTypeAt(v2, j)
+		if t1 != nil && t2 != nil && (t1.Offset != t2.Offset || t1.IsDST != t2.IsDST || t1.Abbrev != t2.Abbrev) {
+			c.add("v1", "transition", i, "type differs from the 64-bit data")
+		}
+	}
+
+	j = 0
+	for i, l := range v1.Leaps {
+		for j < len(v2.Leaps) && v2.Leaps[j].Occurrence < l.Occurrence {
+			j++
+		}
+		if j == len(v2.Leaps) || v2.Leaps[j] != l {
+			c.add("v1", "leap", i, "not in the 64-bit data")
+		}
+	}
+}
+
+// tzifTypeAt returns the type of transition i of b, or nil if its
+// index is out of range, which block reports.
+func tzifTypeAt(b *TZifBlock, i int) *TZifType {
+	if k := int(b.TransitionTypes[i]); k < len(b.Types) {
+		return &b.Types[k]
+	}
+	return nil
+}
+
+// footer checks the footer of a file of version 2 or later.
+func (c *tzifChecker) footer(f *TZifFile) {
+	if f.Footer == "" {
+		return
+	}
+	b := f.V2
+	last := int64(alpha)
+	if n := len(b.Transitions); n > 0 {
+		last = b.Transitions[n-1]
+	}
+	name, offset, _, _, isDST, ok := tzset(f.Footer, last, last)
+	if !ok {
+		c.add("", "footer", -1, "invalid POSIX TZ string "+quote(f.Footer))
+		return
+	}
+	if n := len(b.Transitions); n > 0 {
+		if t := tzifTypeAt(b, n-1); t != nil && (int(t.Offset) != offset || t.IsDST != isDST || t.Abbrev != name) {
+			c.add("", "footer", -1, "disagrees with the type of the last transition")
+		}
+	}
+}
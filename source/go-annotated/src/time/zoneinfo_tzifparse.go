@@ -0,0 +1,228 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import "errors"
+
+// A TZifFile is the decoded contents of an IANA Time Zone database
+// file (TZif, RFC 8536), as read by ParseTZif. Unlike a Location, it
+// holds the file as written: both data blocks, the raw abbreviation
+// table and all the indicators, with nothing checked beyond the
+// structure of the file.
+// tzfile 的原始内容，供检查、转换 tzdata 的工具使用
+type TZifFile struct {
+	// Version is the version of the format: 1, 2, 3 or 4.
+	Version int
+
+	// V1 is the version 1 data block, with 32-bit times. Files of
+	// version 2 and later may leave it empty.
+	V1 TZifBlock
+
+	// V2 is the data block with 64-bit times that follows the
+	// version 1 block in files of version 2 and later; nil in
+	// version 1 files.
+	V2 *TZifBlock
+
+	// Footer is the POSIX TZ string of the footer, without the
+	// surrounding newlines, for times after the last transition.
+	// Only files of version 2 and later have a footer; it may be
+	// empty.
+	Footer string
+}
+
+// A TZifBlock is one data block of a TZif file, with its header.
+type TZifBlock struct {
+	Header TZifHeader
+
+	// Transitions holds the transition times, in seconds since the
+	// Unix epoch, and TransitionTypes the index in Types of the
+	// local time type that starts at each.
+	Transitions     []int64
+	TransitionTypes []uint8
+
+	Types   []TZifType
+	Abbrevs []byte // the abbreviation table, NUL-terminated strings
+	Leaps   []TZifLeap
+
+	// IsStd and IsUT hold the standard/wall and UT/local indicators
+	// of the local time types; either may be empty.
+	IsStd []bool
+	IsUT  []bool
+}
+
+// A TZifHeader is the header of a TZif data block.
+type TZifHeader struct {
+	Version byte // 0 for version 1, or '2', '3', '4'
+
+	IsUTCount  uint32
+	IsStdCount uint32
+	LeapCount  uint32
+	TimeCount  uint32
+	TypeCount  uint32
+	CharCount  uint32
+}
+
+// A TZifType is a local time type record.
+type TZifType struct {
+	Offset      int32 // seconds east of UT
+	IsDST       bool
+	AbbrevIndex uint8 // index of the abbreviation in the block's Abbrevs
+
+	// Abbrev is the abbreviation AbbrevIndex points to, or "" if
+	// AbbrevIndex is out of range.
+	Abbrev string
+}
+
+// A TZifLeap is a leap second record.
+type TZifLeap struct {
+	Occurrence int64 // time, in seconds since the Unix epoch, at which the correction applies
+	Correction int32 // total correction after it, in seconds
+}
+
+// ParseTZif decodes the TZif file in data. It reports an error only
+// if data is not a TZif file or is truncated; ValidateTZif checks that
+// the contents make sense, such as that the transitions are in order.
+func ParseTZif(data []byte) (*TZifFile, error) {
+	d := dataIO{data, false}
+	f := new(TZifFile)
+	h, err := readTZifHeader(&d)
+	if err != nil {
+		return nil, err
+	}
+	f.Version = tzifVersion(h.Version)
+	if f.Version == 0 {
+		return nil, errors.New("time: TZif: unknown version " + quote(string(h.Version)))
+	}
+	if f.V1, err = readTZifBlock(&d, h, false); err != nil {
+		return nil, err
+	}
+	if f.Version == 1 {
+		if len(d.p) != 0 {
+			return nil, errors.New("time: TZif: extra data after the data block")
+		}
+		return f, nil
+	}
+
+	if h, err = readTZifHeader(&d); err != nil {
+		return nil, err
+	}
+	v2, err := readTZifBlock(&d, h, true)
+	if err != nil {
+		return nil, err
+	}
+	f.V2 = &v2
+
+	rest := d.rest()
+	if len(rest) < 2 || rest[0] != '\n' {
+		return nil, errors.New("time: TZif: missing footer")
+	}
+	i := 1
+	for i < len(rest) && rest[i] != '\n' {
+		i++
+	}
+	if i == len(rest) {
+		return nil, errors.New("time: TZif: unterminated footer")
+	}
+	if i+1 != len(rest) {
+		return nil, errors.New("time: TZif: extra data after the footer")
+	}
+	f.Footer = string(rest[1:i])
+	return f, nil
+}
+
+// tzifVersion returns the version number of a header version byte,
+// or 0 if it is unknown.
+func tzifVersion(b byte) int {
+	switch b {
+	case 0:
+		return 1
+	case '2', '3', '4':
+		return int(b - '0')
+	}
+	return 0
+}
+
+func readTZifHeader(d *dataIO) (TZifHeader, error) {
+	var h TZifHeader
+	if magic := d.read(4); string(magic) != "TZif" {
+		return h, errors.New("time: TZif: bad magic number")
+	}
+	p := d.read(16)
+	if len(p) != 16 {
+		return h, errors.New("time: TZif: truncated header")
+	}
+	h.Version = p[0]
+	for _, c := range []*uint32{&h.IsUTCount, &h.IsStdCount, &h.LeapCount, &h.TimeCount, &h.TypeCount, &h.CharCount} {
+		n, ok := d.big4()
+		if !ok {
+			return h, errors.New("time: TZif: truncated header")
+		}
+		*c = n
+	}
+	return h, nil
+}
+
+func readTZifBlock(d *dataIO, h TZifHeader, is64 bool) (TZifBlock, error) {
+	b := TZifBlock{Header: h}
+	size := 4
+	if is64 {
+		size = 8
+	}
+	// Check the length first, so that counts made up by a corrupt
+	// header do not make us allocate.
+	n := uint64(h.TimeCount)*uint64(size+1) + uint64(h.TypeCount)*6 + uint64(h.CharCount) +
+		uint64(h.LeapCount)*uint64(size+4) + uint64(h.IsStdCount) + uint64(h.IsUTCount)
+	if n > uint64(len(d.p)) {
+		return b, errors.New("time: TZif: truncated data block")
+	}
+
+	b.Transitions = make([]int64, h.TimeCount)
+	for i := range b.Transitions {
+		b.Transitions[i] = tzifTime(d, is64)
+	}
+	b.TransitionTypes = append([]uint8(nil), d.read(int(h.TimeCount))...)
+
+	types := d.read(int(h.TypeCount) * 6)
+	b.Abbrevs = append([]byte(nil), d.read(int(h.CharCount))...)
+	b.Types = make([]TZifType, h.TypeCount)
+	for i := range b.Types {
+		t := &b.Types[i]
+		p := types[i*6:]
+		t.Offset = int32(uint32(p[0])<<24 | uint32(p[1])<<16 | uint32(p[2])<<8 | uint32(p[3]))
+		t.IsDST = p[4] != 0
+		t.AbbrevIndex = p[5]
+		if int(t.AbbrevIndex) < len(b.Abbrevs) {
+			t.Abbrev = byteString(b.Abbrevs[t.AbbrevIndex:])
+		}
+	}
+
+	b.Leaps = make([]TZifLeap, h.LeapCount)
+	for i := range b.Leaps {
+		b.Leaps[i].Occurrence = tzifTime(d, is64)
+		c, _ := d.big4()
+		b.Leaps[i].Correction = int32(c)
+	}
+
+	b.IsStd = tzifBools(d.read(int(h.IsStdCount)))
+	b.IsUT = tzifBools(d.read(int(h.IsUTCount)))
+	return b, nil
+}
+
+func tzifTime(d *dataIO, is64 bool) int64 {
+	if is64 {
+		n, _ := d.big8()
+		return int64(n)
+	}
+	n, _ := d.big4()
+	return int64(int32(n))
+}
+
+func tzifBools(p []byte) []bool {
+	b := make([]bool, len(p))
+	for i, c := range p {
+		b[i] = c != 0
+	}
+	return b
+}
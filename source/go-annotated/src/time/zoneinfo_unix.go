@@ -25,30 +25,47 @@ var zoneSources = []string{
 	runtime.GOROOT() + "/lib/time/zoneinfo.zip",
 }
 
-func initLocal() {
+// readLocal returns the local time zone and a summary of the
+// settings it was read from; see initLocal.
+func readLocal() (*Location, string) {
 	// consult $TZ to find the time zone to use.
 	// no $TZ means use the system default /etc/localtime.
 	// $TZ="" means use UTC.
 	// $TZ="foo" means use /usr/share/zoneinfo/foo.
+	// $TZ=":foo" means the same; the colon only marks a file name.
+	// $TZ="CST6CDT,M3.2.0,M11.1.0" with no such file is a POSIX rule.
 
 	//获取服务启动时配置的时区
 	tz, ok := syscall.Getenv("TZ")
 	// 初始化 localLoc
 	switch {
 	case !ok:
-		z, err := loadLocation("localtime", []string{"/etc/"})
-		if err == nil {
-			localLoc = *z
-			localLoc.name = "Local"
-			return
+		// Read the file once, so the data and the summary agree.
+		// In sandbox mode, do not read it at all.
+		if !zoneSandboxed() {
+			data, err := readFile("/etc/localtime")
+			if err == nil {
+				if z, err := LoadLocationFromTZData("Local", data); err == nil {
+					z.source = LocationSource{Kind: SourceSystem, Dir: "/etc/localtime"}
+					return z, "localtime:" + string(data)
+				}
+			}
+		}
+		return &Location{name: "UTC"}, "localtime:"
+	case tz != "" && tz[0] == ':':
+		if z, ok := loadLocalZone(tz[1:]); ok {
+			return z, "TZ=" + tz
 		}
 	case tz != "" && tz != "UTC":
-		if z, err := loadLocation(tz, zoneSources); err == nil {
-			localLoc = *z
-			return
+		if z, ok := loadLocalZone(tz); ok {
+			return z, "TZ=" + tz
+		}
+		// 没有对应的时区文件，按 POSIX TZ 规则解析
+		if z, ok := tzsetLocation(tz, tz); ok {
+			return z, "TZ=" + tz
 		}
 	}
 
 	// Fall back to UTC.
-	localLoc.name = "UTC"
+	return &Location{name: "UTC"}, "TZ=" + tz
 }
@@ -0,0 +1,112 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// ValidZoneName reports whether name is a time zone name that
+// LoadLocation can load, such as "America/New_York", without
+// loading it. It is meant for checking user input, for example in
+// a web form, where a full LoadLocation for each bad name would
+// cost too much.
+// 只检查时区名格式以及数据源中是否存在，不解析时区数据，也不写入缓存
+//
+// The name must follow the IANA rules for zone names: one or more
+// components separated by slashes, each at most 14 bytes of ASCII
+// letters, digits, '.', '_', '-' and '+', not starting with '-' and
+// not "." or "..". The names "", "UTC" and "Local" are valid, and so
+// are the fixed-offset zones LoadLocation builds without a database,
+// such as "Etc/GMT+5" and "UTC+05:30". POSIX TZ strings, which
+// LoadLocation also accepts, are not.
+func ValidZoneName(name string) bool {
+	if name == "" || name == "UTC" || name == "Local" {
+		return true
+	}
+	if _, _, ok := syntheticOffset(name); ok {
+		return true
+	}
+	if !validZoneNameSyntax(name) {
+		return false
+	}
+
+	c := &locationCache
+	c.Lock()
+	_, ok := c.loc[name]
+	_, miss := c.miss[name]
+	c.Unlock()
+	if ok || miss {
+		return ok
+	}
+	return zoneExists(name)
+}
+
+// validZoneNameSyntax reports whether name is well formed
+// as described for ValidZoneName.
+func validZoneNameSyntax(name string) bool {
+	n := 0 // length of the current component
+	for i := 0; i <= len(name); i++ {
+		if i == len(name) || name[i] == '/' {
+			c := name[i-n : i]
+			if n == 0 || c == "." || c == ".." || c[0] == '-' {
+				return false
+			}
+			n = 0
+			continue
+		}
+		switch ch := name[i]; {
+		case 'a' <= ch && ch <= 'z', 'A' <= ch && ch <= 'Z', '0' <= ch && ch <= '9':
+		case ch == '.' || ch == '_' || ch == '-' || ch == '+':
+		default:
+			return false
+		}
+		if n++; n > 14 {
+			return false
+		}
+	}
+	return true
+}
+
+// zoneExists reports whether one of the sources LoadLocation
+// consults has data for the named zone. It reads the data but
+// only checks that it starts like a tzfile.
+func zoneExists(name string) bool {
+	isTZif := func(data []byte) bool {
+		return len(data) >= 4 && string(data[:4]) == "TZif"
+	}
+	tzinfoExists := func(source string) bool {
+		data, release, err := loadTzinfo(name, source)
+		ok := err == nil && isTZif(data)
+		if release != nil {
+			release()
+		}
+		return ok
+	}
+
+	for _, dir := range zoneinfoDirs() {
+		if tzinfoExists(dir) {
+			return true
+		}
+	}
+
+	zoneSourcesMu.Lock()
+	srcs := registeredZoneSources
+	zoneSourcesMu.Unlock()
+	for _, src := range srcs {
+		if data, err := readZoneSource(src, name); err == nil && isTZif(data) {
+			return true
+		}
+	}
+
+	for _, source := range systemZoneSources() {
+		if tzinfoExists(source) {
+			return true
+		}
+	}
+
+	if loadFromEmbeddedTZData != nil {
+		if data, err := loadFromEmbeddedTZData(name); err == nil && isTZif([]byte(data)) {
+			return true
+		}
+	}
+	return false
+}
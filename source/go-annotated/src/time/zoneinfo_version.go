@@ -0,0 +1,91 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"errors"
+	"syscall"
+)
+
+// TZDataVersion returns the release of the IANA Time Zone database
+// that LoadLocation reads, such as "2018e", so operators can check
+// that a host has the release with a recent rule change.
+// 返回正在使用的时区数据库版本，例如 "2018e"
+//
+// The version is read from the first of the sources LoadLocation
+// uses that records one: the +VERSION file or the version line of
+// tzdata.zi in a zoneinfo directory or zip file, or the header of
+// an Android tzdata file. Sources added by RegisterZoneSource and
+// the database embedded by time/tzdata record no version.
+func TZDataVersion() (string, error) {
+	sources := append(zoneinfoDirs(), systemZoneSources()...)
+
+	var firstErr error
+	for _, source := range sources {
+		v, err := sourceVersion(source)
+		if err == nil {
+			return v, nil
+		}
+		if firstErr == nil && err != syscall.ENOENT && err != ErrUnknownZone {
+			firstErr = err
+		}
+	}
+	if firstErr == nil {
+		firstErr = errors.New("time: time zone database version not found")
+	}
+	return "", firstErr
+}
+
+// sourceVersion returns the version of the time zone database in
+// source, a directory, zip file or tzdata file as for loadTzinfo.
+func sourceVersion(source string) (string, error) {
+	if len(source) >= 6 && source[len(source)-6:] == "tzdata" && tzdataVersion != nil {
+		return tzdataVersion(source)
+	}
+
+	// +VERSION holds just the version, as written by "make install".
+	data, err := loadTzinfoFromDirOrZip(source, "+VERSION")
+	if err == nil {
+		if v := trimSpace(string(data)); v != "" {
+			return v, nil
+		}
+	}
+
+	// tzdata.zi, the compact zic input, starts with "# version 2018e".
+	data, err2 := loadTzinfoFromDirOrZip(source, "tzdata.zi")
+	if err2 == nil {
+		const prefix = "# version "
+		line := data
+		for i, c := range data {
+			if c == '\n' {
+				line = data[:i]
+				break
+			}
+		}
+		if len(line) > len(prefix) && string(line[:len(prefix)]) == prefix {
+			return trimSpace(string(line[len(prefix):])), nil
+		}
+		return "", errors.New("time: no version in " + source + "/tzdata.zi")
+	}
+	if err == nil || err == syscall.ENOENT || err == ErrUnknownZone {
+		err = err2
+	}
+	return "", err
+}
+
+// trimSpace returns s without leading and trailing ASCII white space.
+func trimSpace(s string) string {
+	for len(s) > 0 && (s[0] == ' ' || s[0] == '\t' || s[0] == '\n' || s[0] == '\r') {
+		s = s[1:]
+	}
+	for len(s) > 0 {
+		c := s[len(s)-1]
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+			break
+		}
+		s = s[:len(s)-1]
+	}
+	return s
+}
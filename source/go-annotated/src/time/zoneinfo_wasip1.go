@@ -0,0 +1,60 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build wasip1
+
+package time
+
+import (
+	"runtime"
+	"syscall"
+)
+
+// A WASI module can only open files below the directories its host
+// preopened, so these are found only if the host grants access to
+// them, for example with "wasmtime run --dir /usr/share/zoneinfo".
+// WASI 模块只能访问宿主预先打开的目录
+var zoneSources = []string{
+	"/usr/share/zoneinfo/",
+	"/usr/share/lib/zoneinfo/",
+	"/usr/lib/locale/TZ/",
+	runtime.GOROOT() + "/lib/time/zoneinfo.zip",
+}
+
+// readLocal follows the same rules as on Unix systems, reading
+// /etc/localtime if the host preopened /etc. When no zone file can
+// be read, a TZ value in POSIX form, such as "CET-1CEST,M3.5.0,M10.5.0/3",
+// still gives the right Local. It returns the zone and a summary of
+// the settings it was read from; see initLocal.
+func readLocal() (*Location, string) {
+	tz, ok := syscall.Getenv("TZ")
+	if ok && tz != "" && tz[0] == ':' {
+		tz = tz[1:]
+	}
+	switch {
+	case !ok:
+		if zoneSandboxed() {
+			break
+		}
+		data, err := readFile("/etc/localtime")
+		if err == nil {
+			if z, err := LoadLocationFromTZData("Local", data); err == nil {
+				z.source = LocationSource{Kind: SourceSystem, Dir: "/etc/localtime"}
+				return z, "localtime:" + string(data)
+			}
+		}
+		return &Location{name: "UTC"}, "localtime:"
+	case tz != "" && tz != "UTC":
+		if z, ok := loadLocalZone(tz); ok {
+			return z, "TZ=" + tz
+		}
+		// 读不到时区文件时，按 POSIX TZ 规则解析
+		if z, ok := tzsetLocation(tz, tz); ok {
+			return z, "TZ=" + tz
+		}
+	}
+
+	// Fall back to UTC.
+	return &Location{name: "UTC"}, "TZ=" + tz
+}
@@ -0,0 +1,169 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+// Windows describes a time zone by a rule: a bias from UTC and the
+// days on which daylight saving time starts and ends, such as "the
+// second Sunday of March". The rule of each key under
+// HKLM\SOFTWARE\Microsoft\Windows NT\CurrentVersion\Time Zones is
+// only the current one; the zones whose rules changed also have a
+// "Dynamic DST" subkey holding the rule of each year from FirstEntry
+// to LastEntry, in values named by the year. A Location built from a
+// single rule gets every other year wrong; dynamicDSTLocation builds
+// the local zone from the yearly rules instead, as
+// GetTimeZoneInformationForYear applies them: the rule of FirstEntry
+// before it, that of LastEntry after it.
+// Windows 注册表 Dynamic DST 子键按年份保存规则，据此生成多年的转换表
+//
+// Each rule is a REG_TZI_FORMAT value, the binary form of
+//	struct {
+//		LONG Bias, StandardBias, DaylightBias;
+//		SYSTEMTIME StandardDate, DaylightDate;
+//	}
+// in little-endian order, 44 bytes in all.
+
+// A systemTime is a Windows SYSTEMTIME, giving when daylight saving
+// time starts or ends. If year is 0, the date is in "day in month"
+// form: day is the week of the month, 1 to 5 with 5 the last, and
+// dayOfWeek is the weekday, 0 for Sunday. The time is the wall clock
+// time before the transition.
+type systemTime struct {
+	year, month, dayOfWeek, day uint16
+	hour, minute, second, msec  uint16
+}
+
+// A tziRule is a REG_TZI_FORMAT rule. Biases are minutes west of UTC.
+type tziRule struct {
+	bias, stdBias, dstBias int32
+	stdDate, dstDate       systemTime
+}
+
+// parseTZI parses a REG_TZI_FORMAT value.
+func parseTZI(b []byte) (r tziRule, ok bool) {
+	if len(b) != 44 {
+		return r, false
+	}
+	le4 := func(i int) int32 {
+		return int32(uint32(b[i]) | uint32(b[i+1])<<8 | uint32(b[i+2])<<16 | uint32(b[i+3])<<24)
+	}
+	st := func(i int) systemTime {
+		var f [8]uint16
+		for j := range f {
+			f[j] = uint16(b[i+2*j]) | uint16(b[i+2*j+1])<<8
+		}
+		return systemTime{f[0], f[1], f[2], f[3], f[4], f[5], f[6], f[7]}
+	}
+	r.bias, r.stdBias, r.dstBias = le4(0), le4(4), le4(8)
+	r.stdDate, r.dstDate = st(12), st(28)
+	return r, true
+}
+
+// hasDST reports whether r has daylight saving time.
+func (r *tziRule) hasDST() bool {
+	return r.stdDate.month != 0
+}
+
+// offsets returns the offsets of standard and daylight saving time
+// under r, in seconds east of UTC. StandardBias is ignored when there
+// is no daylight saving time, as Windows does.
+func (r *tziRule) offsets() (std, dst int) {
+	if !r.hasDST() {
+		return -int(r.bias) * 60, -int(r.bias) * 60
+	}
+	return -int(r.bias+r.stdBias) * 60, -int(r.bias+r.dstBias) * 60
+}
+
+// wallSeconds returns the wall clock time at which d falls in year,
+// as seconds since 1970 read as UTC.
+func (d *systemTime) wallSeconds(year int) int64 {
+	sec := int(d.second) + (int(d.msec)+999)/1000 // 23:59:59.999 is midnight
+	if d.year != 0 {
+		return Date(year, Month(d.month), int(d.day), int(d.hour), int(d.minute), sec, 0, UTC).Unix()
+	}
+	t := Date(year, Month(d.month), 1, int(d.hour), int(d.minute), sec, 0, UTC)
+	day := 1 + (int(d.dayOfWeek)-int(t.Weekday())+7)%7
+	if week := int(d.day) - 1; week < 4 {
+		day += week * 7
+	} else {
+		// "Last" instance of the day.
+		day += 4 * 7
+		if day > daysIn(Month(d.month), year) {
+			day -= 7
+		}
+	}
+	return t.Unix() + int64(day-1)*secondsPerDay
+}
+
+// dynamicDSTYears is how many years after the last rule the
+// transitions of a Location built by dynamicDSTLocation reach.
+const dynamicDSTYears = 100
+
+// dynamicDSTLocation returns a Location named name whose year first+i
+// follows rules[i], naming its zones stdName and dstName. Years from
+// 1970 until first follow rules[0], and the last rule holds for
+// dynamicDSTYears years after its own. rules must not be empty.
+func dynamicDSTLocation(name, stdName, dstName string, first int, rules []tziRule) *Location {
+	l := &Location{name: name}
+	zoneIndex := func(offset int, isDST bool) uint8 {
+		zname := stdName
+		if isDST {
+			zname = dstName
+		}
+		for i, z := range l.zone {
+			if z.name == zname && z.offset == offset && z.isDST == isDST {
+				return uint8(i)
+			}
+		}
+		l.zone = append(l.zone, zone{name: zname, offset: offset, isDST: isDST})
+		return uint8(len(l.zone) - 1)
+	}
+	// The zone in effect, and the offset of the clocks before the
+	// next transition.
+	cur := -1
+	curOffset, _ := rules[0].offsets()
+	add := func(wall int64, index uint8) {
+		if int(index) == cur {
+			return
+		}
+		l.tx = append(l.tx, zoneTrans{when: wall - int64(curOffset), index: index})
+		cur, curOffset = int(index), l.zone[index].offset
+	}
+
+	start, last := first, first+len(rules)-1
+	if start > 1970 {
+		start = 1970
+	}
+	for y := start; y <= last+dynamicDSTYears; y++ {
+		i := y - first
+		switch {
+		case i < 0:
+			i = 0
+		case i >= len(rules):
+			i = len(rules) - 1
+		}
+		r := &rules[i]
+		std, dst := r.offsets()
+		jan1 := Date(y, January, 1, 0, 0, 0, 0, UTC).Unix()
+		if !r.hasDST() {
+			add(jan1, zoneIndex(std, false))
+			continue
+		}
+		// The rule of the year takes effect at its start.
+		dstStart, dstEnd := r.dstDate.wallSeconds(y), r.stdDate.wallSeconds(y)
+		if dstStart < dstEnd {
+			add(jan1, zoneIndex(std, false))
+			add(dstStart, zoneIndex(dst, true))
+			add(dstEnd, zoneIndex(std, false))
+		} else {
+			// Daylight saving time spans New Year, as in the
+			// southern hemisphere.
+			add(jan1, zoneIndex(dst, true))
+			add(dstEnd, zoneIndex(std, false))
+			add(dstStart, zoneIndex(dst, true))
+		}
+	}
+	l.resetCache()
+	return l
+}
@@ -0,0 +1,32 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+//go:generate go run genwinzones.go -output zoneinfo_winzones.go
+
+// WindowsZoneToIANA returns the IANA name of the zone with the given
+// Windows time zone name, as used by Windows APIs, Exchange and Active
+// Directory: WindowsZoneToIANA("Pacific Standard Time") returns
+// "America/Los_Angeles". It reports false if the name is not known.
+// 把 Windows 的时区名转换为 IANA 时区名
+//
+// The mapping is CLDR's windowsZones table. A Windows zone that covers
+// several IANA zones maps to the one CLDR lists for territory "001".
+// Names are available on every platform, not only on Windows.
+func WindowsZoneToIANA(name string) (string, bool) {
+	iana, ok := windowsZones[name]
+	return iana, ok
+}
+
+// LoadWindowsLocation returns the Location for the zone with the
+// given Windows time zone name, such as "Pacific Standard Time",
+// loaded by LoadLocation from its IANA name.
+func LoadWindowsLocation(name string) (*Location, error) {
+	iana, ok := windowsZones[name]
+	if !ok {
+		return nil, &LoadError{Name: name, Err: ErrUnknownZone}
+	}
+	return LoadLocation(iana)
+}
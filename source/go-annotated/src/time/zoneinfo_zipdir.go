@@ -0,0 +1,182 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time
+
+import (
+	"errors"
+	"sync"
+)
+
+// A zipEntry is a file listed in the central directory of a zip file.
+type zipEntry struct {
+	meth int // compression method; 0 is stored
+	size int // uncompressed size
+	off  int // offset of the per-file header
+}
+
+// A zipDir is the parsed central directory of a zip file.
+type zipDir struct {
+	tail  string   // end of central directory record it was read after
+	names []string // file names, in directory order
+	files map[string]zipEntry
+
+	// data is the whole zip file mapped read-only into memory, or nil
+	// if it could not be mapped. loadTzinfoFromZip copies the files
+	// it reads out of it, so that no Location depends on the mapping:
+	// a zip file rewritten in place, rather than replaced, would make
+	// reading it fault. Once cached, data is never unmapped, as other
+	// goroutines may be reading it; a replaced zip file stays mapped,
+	// but its pages are freed with the file. For a compressed zip
+	// file, data is the decompressed file, on the heap.
+	data []byte
+}
+
+// zipDirs caches the central directories of the zip files read by
+// loadTzinfoFromZip and listZip, by file name, so that loading many
+// zones from one zoneinfo.zip scans its directory only once.
+// 缓存 zip 的中央目录，批量加载时区时不必每次都重新扫描
+var zipDirs struct {
+	sync.Mutex
+	m map[string]*zipDir
+}
+
+const (
+	zecheader = 0x06054b50 // end of central directory record
+	zcheader  = 0x02014b50 // central directory entry
+	ztailsize = 22         // size of the end of central directory record
+)
+
+// readZipDir returns the central directory of the open zip file fd,
+// named zipfile. The end of central directory record is read every
+// time; while it is unchanged, the directory is taken from the cache.
+// A zip file replaced by another, as when the time zone database is
+// updated, has a different record, giving its size and offset.
+//
+// A directory missing from the cache is read holding the lock of
+// zipDirs, so that goroutines missing it together map the file once.
+// 未命中时持锁读取，避免并发时重复映射同一文件
+func readZipDir(fd uintptr, zipfile string) (*zipDir, error) {
+	buf := make([]byte, ztailsize)
+	if err := preadn(fd, buf, -ztailsize); err != nil || get4(buf) != zecheader {
+		return nil, errors.New("corrupt zip file " + zipfile)
+	}
+	tail := string(buf)
+
+	zipDirs.Lock()
+	defer zipDirs.Unlock()
+	dir := zipDirs.m[zipfile]
+	if dir != nil && dir.tail == tail {
+		return dir, nil
+	}
+	dir, err := mapZipDir(fd, zipfile, tail)
+	if err != nil {
+		return nil, err
+	}
+	if zipDirs.m == nil {
+		zipDirs.m = make(map[string]*zipDir)
+	}
+	zipDirs.m[zipfile] = dir
+	return dir, nil
+}
+
+// mapZipDir reads the central directory of the open zip file fd,
+// named zipfile, whose end of central directory record is tail,
+// mapping the file into memory if it can. On error, the file is
+// left unmapped.
+func mapZipDir(fd uintptr, zipfile, tail string) (dir *zipDir, err error) {
+	buf := []byte(tail)
+
+	n := get2(buf[10:])
+	size := get4(buf[12:])
+	off := get4(buf[16:])
+
+	dir = &zipDir{tail: tail, names: make([]string, 0, n), files: make(map[string]zipEntry, n)}
+	if fsize, ferr := fileSize(fd); ferr == nil {
+		if data, merr := mmapFd(fd, fsize); merr == nil {
+			if len(data) < ztailsize || string(data[len(data)-ztailsize:]) != tail {
+				// Replaced between reading the tail and mapping.
+				munmap(data)
+			} else {
+				dir.data = data
+				defer func() {
+					if err != nil {
+						munmap(data)
+					}
+				}()
+			}
+		}
+	}
+
+	if dir.data != nil {
+		if off < 0 || size < 0 || off+size > len(dir.data) {
+			return nil, errors.New("corrupt zip file " + zipfile)
+		}
+		buf = dir.data[off : off+size]
+	} else {
+		buf = make([]byte, size)
+		if err := preadn(fd, buf, off); err != nil {
+			return nil, errors.New("corrupt zip file " + zipfile)
+		}
+	}
+
+	if err := dir.readEntries(buf, n, zipfile); err != nil {
+		return nil, err
+	}
+	return dir, nil
+}
+
+// cacheZipDir records dir as the central directory of zipfile.
+func cacheZipDir(zipfile string, dir *zipDir) {
+	zipDirs.Lock()
+	if zipDirs.m == nil {
+		zipDirs.m = make(map[string]*zipDir)
+	}
+	zipDirs.m[zipfile] = dir
+	zipDirs.Unlock()
+}
+
+// readEntries adds to dir the n entries of the central directory buf
+// of zipfile.
+func (dir *zipDir) readEntries(buf []byte, n int, zipfile string) error {
+	for i := 0; i < n; i++ {
+		// zip entry layout:
+		//	0	magic[4]
+		//	4	madevers[1]
+		//	5	madeos[1]
+		//	6	extvers[1]
+		//	7	extos[1]
+		//	8	flags[2]
+		//	10	meth[2]
+		//	12	modtime[2]
+		//	14	moddate[2]
+		//	16	crc[4]
+		//	20	csize[4]
+		//	24	uncsize[4]
+		//	28	namelen[2]
+		//	30	xlen[2]
+		//	32	fclen[2]
+		//	34	disknum[2]
+		//	36	iattr[2]
+		//	38	eattr[4]
+		//	42	off[4]
+		//	46	name[namelen]
+		//	46+namelen+xlen+fclen - next header
+		//
+		if get4(buf) != zcheader {
+			break
+		}
+		namelen := get2(buf[28:])
+		xlen := get2(buf[30:])
+		fclen := get2(buf[32:])
+		if len(buf) < 46+namelen+xlen+fclen {
+			return errors.New("corrupt zip file " + zipfile)
+		}
+		name := string(buf[46 : 46+namelen])
+		dir.names = append(dir.names, name)
+		dir.files[name] = zipEntry{meth: get2(buf[10:]), size: get4(buf[24:]), off: get4(buf[42:])}
+		buf = buf[46+namelen+xlen+fclen:]
+	}
+	return nil
+}
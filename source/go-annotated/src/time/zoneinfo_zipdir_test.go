@@ -0,0 +1,49 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package time_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	. "time"
+)
+
+// TestZipRewrittenInPlace checks that the data read from a zip file,
+// which may be mapped into memory, stays readable when the file is
+// truncated in place.
+func TestZipRewrittenInPlace(t *testing.T) {
+	want := bytes.Repeat([]byte("TZif"), 1024)
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.CreateHeader(&zip.FileHeader{Name: "Test/Zone", Method: zip.Store})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write(want)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Join(t.TempDir(), "zoneinfo.zip")
+	if err := os.WriteFile(name, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := LoadTzinfoFromZip(name, "Test/Zone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(name, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, want) {
+		t.Error("data changed with the zip file")
+	}
+	if _, err := LoadTzinfoFromZip(name, "Test/Zone"); err == nil {
+		t.Error("truncated zip file read without error")
+	}
+}